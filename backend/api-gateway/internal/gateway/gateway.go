@@ -31,8 +31,9 @@ type ErrorResponse struct {
 
 // Server represents the API Gateway server
 type Server struct {
-	config *config.Config
-	mux    *runtime.ServeMux
+	config  *config.Config
+	mux     *runtime.ServeMux
+	scoring scoringpb.ScoringServiceClient // Streams the gateway mux cannot serve
 }
 
 // NewServer creates a new API Gateway server
@@ -69,6 +70,12 @@ func NewServer(cfg *config.Config) (*Server, error) {
 		return nil, err
 	}
 
+	// Connect to scoring service for leaderboard streams
+	scoringConn, err := grpc.NewClient(cfg.ScoringService, opts...)
+	if err != nil {
+		return nil, err
+	}
+
 	// Register sports service
 	err = sportspb.RegisterSportsServiceHandlerFromEndpoint(ctx, mux, cfg.SportsService, opts)
 	if err != nil {
@@ -94,8 +101,9 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	}
 
 	return &Server{
-		config: cfg,
-		mux:    mux,
+		config:  cfg,
+		mux:     mux,
+		scoring: scoringpb.NewScoringServiceClient(scoringConn),
 	}, nil
 }
 
@@ -106,6 +114,9 @@ func (s *Server) Handler() http.Handler {
 	
 	// Add health check endpoint
 	handler = s.addHealthCheck(handler)
+
+	// Add leaderboard stream endpoint
+	handler = s.addLeaderboardStream(handler)
 	
 	// Add middleware in reverse order (last added = first executed)
 	handler = middleware.JWTMiddleware([]byte(s.config.JWTSecret))(handler)
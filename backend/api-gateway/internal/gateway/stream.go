@@ -0,0 +1,123 @@
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// heartbeatInterval keeps idle leaderboard streams open through proxies
+const heartbeatInterval = 15 * time.Second
+
+// leaderboardWatchPath matches the leaderboard stream with or without the /api prefix
+var leaderboardWatchPath = regexp.MustCompile(`^(?:/api)?/v1/contests/(\d+)/leaderboard/watch$`)
+
+// addLeaderboardStream serves WatchLeaderboard as Server-Sent Events, since the gateway mux
+// only maps unary calls to plain HTTP responses browsers can consume
+func (s *Server) addLeaderboardStream(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		match := leaderboardWatchPath.FindStringSubmatch(r.URL.Path)
+		if match == nil || r.Method != http.MethodGet {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		contestID, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			writeStreamError(w, http.StatusBadRequest, "invalid contest ID")
+			return
+		}
+		s.streamLeaderboard(w, r, uint32(contestID))
+	})
+}
+
+// streamLeaderboard relays a contest's leaderboard updates as "leaderboard" events until the
+// client disconnects or the scoring service ends the stream with an "error" event
+func (s *Server) streamLeaderboard(w http.ResponseWriter, r *http.Request, contestID uint32) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeStreamError(w, http.StatusInternalServerError, "streaming is not supported")
+		return
+	}
+
+	ctx := r.Context()
+	stream, err := s.scoring.WatchLeaderboard(ctx, &scoringpb.WatchLeaderboardRequest{ContestId: contestID})
+	if err != nil {
+		writeStreamError(w, http.StatusServiceUnavailable, status.Convert(err).Message())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	updates := make(chan *scoringpb.LeaderboardUpdate)
+	errs := make(chan error, 1)
+	go func() {
+		for {
+			update, err := stream.Recv()
+			if err != nil {
+				errs <- err
+				return
+			}
+			select {
+			case updates <- update:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			flusher.Flush()
+
+		case update := <-updates:
+			data, err := protojson.Marshal(update)
+			if err != nil {
+				log.Printf("[ERROR] Failed to encode leaderboard update: %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: leaderboard\ndata: %s\n\n", data)
+			flusher.Flush()
+
+		case err := <-errs:
+			if ctx.Err() == nil {
+				data, _ := protojson.Marshal(status.Convert(err).Proto())
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+				flusher.Flush()
+			}
+			return
+		}
+	}
+}
+
+// writeStreamError writes an error response before a stream has started
+func writeStreamError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(ErrorResponse{
+		Error:   "Request failed",
+		Code:    code,
+		Message: message,
+	})
+}
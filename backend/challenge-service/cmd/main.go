@@ -1,12 +1,14 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
 
+	"github.com/sports-prediction-contests/challenge-service/internal/clients"
 	"github.com/sports-prediction-contests/challenge-service/internal/config"
 	"github.com/sports-prediction-contests/challenge-service/internal/models"
 	"github.com/sports-prediction-contests/challenge-service/internal/repository"
@@ -39,8 +41,20 @@ func main() {
 	challengeRepo := repository.NewChallengeRepository(db)
 	participantRepo := repository.NewChallengeParticipantRepository(db)
 
+	// Initialize scoring client
+	scoringClient, err := clients.NewScoringClient(cfg.ScoringServiceEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to create scoring client: %v", err)
+	}
+	defer scoringClient.Close()
+
 	// Initialize services
-	challengeService := service.NewChallengeService(challengeRepo, participantRepo)
+	challengeService := service.NewChallengeService(challengeRepo, participantRepo, scoringClient)
+
+	// Complete challenges once their event is settled
+	completionCtx, stopCompletion := context.WithCancel(context.Background())
+	defer stopCompletion()
+	go challengeService.RunCompletion(completionCtx, cfg.CompletionInterval)
 
 	// Create gRPC server with JWT interceptor
 	server := grpc.NewServer(
@@ -74,6 +88,7 @@ func main() {
 	log.Println("[INFO] Shutting down Challenge Service...")
 
 	// Gracefully stop the server
+	stopCompletion()
 	server.GracefulStop()
 	
 	// Close database connection
@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// ScoringClient wraps the gRPC scoring service client
+type ScoringClient struct {
+	client scoringpb.ScoringServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewScoringClient creates a new scoring service client
+func NewScoringClient(endpoint string) (*ScoringClient, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to scoring service: %w", err)
+	}
+
+	client := scoringpb.NewScoringServiceClient(conn)
+	return &ScoringClient{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the gRPC connection
+func (c *ScoringClient) Close() error {
+	return c.conn.Close()
+}
+
+// EvaluateAchievements asks the scoring service to unlock the badges users have earned. The
+// call is made on behalf of the first user, since completions are not triggered by a caller.
+func (c *ScoringClient) EvaluateAchievements(ctx context.Context, userIDs ...uint) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", strconv.FormatUint(uint64(userIDs[0]), 10))
+
+	ids := make([]uint32, len(userIDs))
+	for i, userID := range userIDs {
+		ids[i] = uint32(userID)
+	}
+	resp, err := c.client.EvaluateAchievements(ctx, &scoringpb.EvaluateAchievementsRequest{UserIds: ids})
+	if err != nil {
+		return fmt.Errorf("failed to evaluate achievements: %w", err)
+	}
+
+	if !resp.Response.Success {
+		return fmt.Errorf("scoring service error: %s", resp.Response.Message)
+	}
+
+	return nil
+}
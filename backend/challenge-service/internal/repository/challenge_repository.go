@@ -17,6 +17,8 @@ type ChallengeRepositoryInterface interface {
 	ListByUser(userID uint, status string, limit, offset int) ([]*models.Challenge, int64, error)
 	ListByEvent(eventID uint, limit, offset int) ([]*models.Challenge, int64, error)
 	GetExpiredChallenges() ([]*models.Challenge, error)
+	GetFinishedChallenges() ([]*models.Challenge, error)
+	GetEventPoints(eventID uint, userIDs []uint) (map[uint]float64, error)
 	UpdateStatus(id uint, status string) error
 }
 
@@ -249,6 +251,47 @@ func (r *ChallengeRepository) GetExpiredChallenges() ([]*models.Challenge, error
 	return challenges, nil
 }
 
+// GetFinishedChallenges retrieves accepted challenges whose event is completed and both
+// users' predictions on it are scored. Events, predictions and scores are owned by other
+// services but live in the shared database.
+func (r *ChallengeRepository) GetFinishedChallenges() ([]*models.Challenge, error) {
+	var challenges []*models.Challenge
+	result := r.db.Where("status IN ?", []string{"accepted", "active"}).
+		Where("EXISTS (SELECT 1 FROM events e WHERE e.id = challenges.event_id AND e.status = ? AND e.deleted_at IS NULL)", "completed").
+		Where(`NOT EXISTS (SELECT 1 FROM predictions p WHERE p.event_id = challenges.event_id
+			AND p.user_id IN (challenges.challenger_id, challenges.opponent_id) AND p.status = ? AND p.deleted_at IS NULL)`, "pending").
+		Find(&challenges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return challenges, nil
+}
+
+// GetEventPoints retrieves each user's best score on an event across their contests, keyed by
+// user. Users without a scored prediction on the event are absent.
+func (r *ChallengeRepository) GetEventPoints(eventID uint, userIDs []uint) (map[uint]float64, error) {
+	var rows []struct {
+		UserID uint
+		Points float64
+	}
+	err := r.db.Raw(`
+		SELECT s.user_id, MAX(s.points) AS points FROM scores s
+		JOIN predictions p ON p.id = s.prediction_id
+		WHERE p.event_id = ? AND s.user_id IN ? AND s.deleted_at IS NULL AND p.deleted_at IS NULL
+		GROUP BY s.user_id
+	`, eventID, userIDs).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		points[row.UserID] = row.Points
+	}
+	return points, nil
+}
+
 // UpdateStatus updates the status of a challenge
 func (r *ChallengeRepository) UpdateStatus(id uint, status string) error {
 	if id == 0 {
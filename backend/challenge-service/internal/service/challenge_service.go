@@ -5,6 +5,7 @@ import (
 	"log"
 	"time"
 
+	"github.com/sports-prediction-contests/challenge-service/internal/clients"
 	"github.com/sports-prediction-contests/challenge-service/internal/models"
 	"github.com/sports-prediction-contests/challenge-service/internal/repository"
 	"github.com/sports-prediction-contests/shared/auth"
@@ -19,13 +20,15 @@ type ChallengeService struct {
 	pb.UnimplementedChallengeServiceServer
 	challengeRepo     repository.ChallengeRepositoryInterface
 	participantRepo   repository.ChallengeParticipantRepositoryInterface
+	scoringClient     *clients.ScoringClient
 }
 
 // NewChallengeService creates a new ChallengeService instance
-func NewChallengeService(challengeRepo repository.ChallengeRepositoryInterface, participantRepo repository.ChallengeParticipantRepositoryInterface) *ChallengeService {
+func NewChallengeService(challengeRepo repository.ChallengeRepositoryInterface, participantRepo repository.ChallengeParticipantRepositoryInterface, scoringClient *clients.ScoringClient) *ChallengeService {
 	return &ChallengeService{
 		challengeRepo:   challengeRepo,
 		participantRepo: participantRepo,
+		scoringClient:   scoringClient,
 	}
 }
 
@@ -43,6 +43,8 @@ func (m *mockChallengeRepository) Delete(id uint) error { return nil }
 func (m *mockChallengeRepository) ListByUser(userID uint, status string, limit, offset int) ([]*models.Challenge, int64, error) { return nil, 0, nil }
 func (m *mockChallengeRepository) ListByEvent(eventID uint, limit, offset int) ([]*models.Challenge, int64, error) { return nil, 0, nil }
 func (m *mockChallengeRepository) GetExpiredChallenges() ([]*models.Challenge, error) { return nil, nil }
+func (m *mockChallengeRepository) GetFinishedChallenges() ([]*models.Challenge, error) { return nil, nil }
+func (m *mockChallengeRepository) GetEventPoints(eventID uint, userIDs []uint) (map[uint]float64, error) { return nil, nil }
 func (m *mockChallengeRepository) UpdateStatus(id uint, status string) error { return nil }
 
 type mockChallengeParticipantRepository struct{}
@@ -61,7 +63,7 @@ func TestServiceFixes(t *testing.T) {
 	}
 	mockParticipantRepo := &mockChallengeParticipantRepository{}
 	
-	service := NewChallengeService(mockRepo, mockParticipantRepo)
+	service := NewChallengeService(mockRepo, mockParticipantRepo, nil)
 
 	t.Run("Proto conversion handles nil input", func(t *testing.T) {
 		result := service.challengeModelToProto(nil)
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CompleteFinishedChallenges completes every accepted challenge whose event has been settled.
+// Each side scores their best points on the event, and both users' badges are evaluated so
+// challenge wins unlock right away. It returns how many challenges were completed.
+func (s *ChallengeService) CompleteFinishedChallenges(ctx context.Context) (int, error) {
+	challenges, err := s.challengeRepo.GetFinishedChallenges()
+	if err != nil {
+		return 0, err
+	}
+
+	completed := 0
+	for _, challenge := range challenges {
+		points, err := s.challengeRepo.GetEventPoints(challenge.EventID, []uint{challenge.ChallengerID, challenge.OpponentID})
+		if err != nil {
+			log.Printf("[ERROR] Failed to get event points for challenge %d: %v", challenge.ID, err)
+			continue
+		}
+
+		challenge.Complete(points[challenge.ChallengerID], points[challenge.OpponentID])
+		if err := s.challengeRepo.Update(challenge); err != nil {
+			log.Printf("[ERROR] Failed to complete challenge %d: %v", challenge.ID, err)
+			continue
+		}
+		completed++
+
+		if err := s.scoringClient.EvaluateAchievements(ctx, challenge.ChallengerID, challenge.OpponentID); err != nil {
+			log.Printf("[WARN] Failed to evaluate achievements for challenge %d: %v", challenge.ID, err)
+		}
+	}
+
+	if completed > 0 {
+		log.Printf("[INFO] Completed %d challenges", completed)
+	}
+	return completed, nil
+}
+
+// RunCompletion completes finished challenges every interval until the context is cancelled
+func (s *ChallengeService) RunCompletion(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.CompleteFinishedChallenges(ctx); err != nil {
+				log.Printf("[ERROR] Failed to complete finished challenges: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
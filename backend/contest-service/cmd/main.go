@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
@@ -55,6 +56,11 @@ func main() {
 	teamService := service.NewTeamService(teamRepo, memberRepo, contestEntryRepo)
 	teamServiceGRPC := service.NewTeamServiceGRPC(teamService)
 
+	// Scheduled rules replace a contest's rules once they take effect
+	promotionCtx, stopPromotion := context.WithCancel(context.Background())
+	defer stopPromotion()
+	go contestService.RunRulesPromotion(promotionCtx, cfg.RulesPromotionInterval)
+
 	// Create gRPC server with JWT interceptor
 	server := grpc.NewServer(
 		grpc.UnaryInterceptor(auth.JWTUnaryInterceptor([]byte(cfg.JWTSecret))),
@@ -88,6 +94,7 @@ func main() {
 	log.Println("[INFO] Shutting down Contest & Team Service...")
 
 	// Gracefully stop the server
+	stopPromotion()
 	server.GracefulStop()
 	
 	// Close database connection
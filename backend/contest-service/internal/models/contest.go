@@ -2,9 +2,11 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/sports-prediction-contests/shared/scoring"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +17,7 @@ type Contest struct {
 	Description        string    `json:"description"`
 	SportType          string    `gorm:"not null" json:"sport_type"`
 	Rules              string    `gorm:"type:text" json:"rules"` // JSON string for flexible rule configuration
+	RulesBasis         string    `gorm:"size:20;default:'submission'" json:"rules_basis"` // "submission" or "kickoff"
 	PredictionSchema   []byte    `gorm:"type:jsonb" json:"prediction_schema"`
 	Status             string    `gorm:"not null;default:'draft'" json:"status"` // "draft", "active", "completed", "cancelled"
 	StartDate          time.Time `gorm:"not null" json:"start_date"`
@@ -102,12 +105,49 @@ func (c *Contest) ValidateMaxParticipants() error {
 	return nil
 }
 
+// ValidateRules checks that the rules describe a registered contest type with valid settings.
+// Empty rules are allowed and mean a standard contest with default scoring.
+func (c *Contest) ValidateRules() error {
+	return ValidateRules(c.Rules)
+}
+
+// ValidateRules checks rules JSON the way Contest.ValidateRules does, for rules that are not
+// stored on the contest yet, such as a scheduled rules version
+func ValidateRules(rulesJSON string) error {
+	if strings.TrimSpace(rulesJSON) == "" {
+		return nil
+	}
+
+	rules, err := scoring.ParseRules(rulesJSON)
+	if err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+	if err := rules.Validate(); err != nil {
+		return fmt.Errorf("invalid rules: %w", err)
+	}
+
+	return nil
+}
+
+// ValidateRulesBasis checks that predictions are scored by the rules version in force at
+// submission or at kickoff
+func (c *Contest) ValidateRulesBasis() error {
+	if !scoring.ValidRulesBasis(c.RulesBasis) {
+		return errors.New("rules basis must be submission or kickoff")
+	}
+
+	return nil
+}
+
 // BeforeCreate is a GORM hook that runs before creating a contest
 func (c *Contest) BeforeCreate(tx *gorm.DB) error {
 	// Set default status if not provided
 	if c.Status == "" {
 		c.Status = "draft"
 	}
+	if c.RulesBasis == "" {
+		c.RulesBasis = scoring.RulesBasisSubmission
+	}
 
 	// Validate fields
 	if err := c.ValidateTitle(); err != nil {
@@ -134,6 +174,14 @@ func (c *Contest) BeforeCreate(tx *gorm.DB) error {
 		return err
 	}
 
+	if err := c.ValidateRules(); err != nil {
+		return err
+	}
+
+	if err := c.ValidateRulesBasis(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -13,8 +13,9 @@ type Participant struct {
 	ContestID uint      `gorm:"not null;index" json:"contest_id"`
 	UserID    uint      `gorm:"not null;index" json:"user_id"`
 	Role      string    `gorm:"not null;default:'participant'" json:"role"` // "admin", "participant"
-	Status    string    `gorm:"not null;default:'active'" json:"status"`    // "active", "inactive", "banned"
+	Status    string    `gorm:"not null;default:'active'" json:"status"`    // "active", "inactive", "banned", "eliminated"
 	JoinedAt  time.Time `gorm:"not null;default:CURRENT_TIMESTAMP" json:"joined_at"`
+	LivesLost uint      `gorm:"not null;default:0" json:"lives_lost"` // Survivor contests: lost picks, maintained by the scoring service
 	gorm.Model
 
 	// Relationships
@@ -35,7 +36,7 @@ func (p *Participant) ValidateRole() error {
 
 // ValidateStatus checks if the status is valid
 func (p *Participant) ValidateStatus() error {
-	validStatuses := []string{"active", "inactive", "banned"}
+	validStatuses := []string{"active", "inactive", "banned", "eliminated"}
 	for _, validStatus := range validStatuses {
 		if p.Status == validStatus {
 			return nil
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ContestRulesVersion is an immutable snapshot of a contest's rules. Every rules change adds
+// a version, and predictions are scored with the version in force at the time the contest's
+// rules basis names.
+type ContestRulesVersion struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	ContestID     uint      `gorm:"not null;uniqueIndex:idx_contest_rules_version" json:"contest_id"`
+	Version       uint      `gorm:"not null;uniqueIndex:idx_contest_rules_version" json:"version"`
+	Rules         string    `gorm:"type:text" json:"rules"`
+	EffectiveFrom time.Time `gorm:"not null" json:"effective_from"`
+	CreatedBy     uint      `json:"created_by"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a rules version
+func (v *ContestRulesVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ContestID == 0 {
+		return errors.New("contest ID cannot be empty")
+	}
+	if v.Version == 0 {
+		return errors.New("rules version must be positive")
+	}
+	if v.EffectiveFrom.IsZero() {
+		return errors.New("effective from cannot be empty")
+	}
+	return nil
+}
+
+// BeforeUpdate is a GORM hook that keeps rules versions immutable
+func (v *ContestRulesVersion) BeforeUpdate(tx *gorm.DB) error {
+	return errors.New("contest rules versions cannot be changed")
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/sports-prediction-contests/contest-service/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ContestRepositoryInterface defines the contract for contest repository
@@ -12,6 +15,10 @@ type ContestRepositoryInterface interface {
 	Create(contest *models.Contest) error
 	GetByID(id uint) (*models.Contest, error)
 	Update(contest *models.Contest) error
+	UpdateRules(contest *models.Contest, rules string, effectiveFrom time.Time, changedBy uint) (*models.ContestRulesVersion, error)
+	ListRulesVersions(contestID uint) ([]*models.ContestRulesVersion, error)
+	PromoteDueRules(now time.Time) (int64, error)
+	HasPredictions(contestID uint) (bool, error)
 	Delete(id uint) error
 	List(limit, offset int, status, sportType string) ([]*models.Contest, int64, error)
 	GetByCreatorID(creatorID uint) ([]*models.Contest, error)
@@ -51,18 +58,25 @@ func NewParticipantRepository(db *gorm.DB) ParticipantRepositoryInterface {
 
 // Contest Repository Methods
 
-// Create creates a new contest in the database
+// Create creates a new contest in the database along with version 1 of its rules
 func (r *ContestRepository) Create(contest *models.Contest) error {
 	if contest == nil {
 		return errors.New("contest cannot be nil")
 	}
 
-	result := r.db.Create(contest)
-	if result.Error != nil {
-		return result.Error
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(contest).Error; err != nil {
+			return err
+		}
 
-	return nil
+		return tx.Create(&models.ContestRulesVersion{
+			ContestID:     contest.ID,
+			Version:       1,
+			Rules:         contest.Rules,
+			EffectiveFrom: contest.CreatedAt,
+			CreatedBy:     contest.CreatorID,
+		}).Error
+	})
 }
 
 // GetByID retrieves a contest by ID
@@ -105,6 +119,125 @@ func (r *ContestRepository) Update(contest *models.Contest) error {
 	return nil
 }
 
+// UpdateRules saves a contest and records new rules as its next rules version, in force from
+// effectiveFrom. The contest's rules only change once the version is in force, until then
+// PromoteDueRules leaves them alone. A contest created before rules were versioned first gets
+// its stored rules recorded as version 1.
+func (r *ContestRepository) UpdateRules(contest *models.Contest, rules string, effectiveFrom time.Time, changedBy uint) (*models.ContestRulesVersion, error) {
+	if contest == nil {
+		return nil, errors.New("contest cannot be nil")
+	}
+
+	if contest.ID == 0 {
+		return nil, errors.New("contest ID cannot be zero")
+	}
+
+	var version *models.ContestRulesVersion
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Lock the contest so concurrent rule changes get consecutive versions
+		var stored models.Contest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&stored, contest.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return errors.New("contest not found")
+			}
+			return err
+		}
+
+		var latest models.ContestRulesVersion
+		err := tx.Where("contest_id = ?", contest.ID).Order("version DESC").Take(&latest).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			latest = models.ContestRulesVersion{
+				ContestID:     contest.ID,
+				Version:       1,
+				Rules:         stored.Rules,
+				EffectiveFrom: stored.CreatedAt,
+				CreatedBy:     stored.CreatorID,
+			}
+			err = tx.Create(&latest).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if effectiveFrom.Before(latest.EffectiveFrom) {
+			return fmt.Errorf("rules cannot take effect before rules version %d", latest.Version)
+		}
+
+		version = &models.ContestRulesVersion{
+			ContestID:     contest.ID,
+			Version:       latest.Version + 1,
+			Rules:         rules,
+			EffectiveFrom: effectiveFrom,
+			CreatedBy:     changedBy,
+		}
+		if err := tx.Create(version).Error; err != nil {
+			return err
+		}
+
+		if !effectiveFrom.After(time.Now()) {
+			contest.Rules = rules
+		}
+		return tx.Save(contest).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// ListRulesVersions retrieves a contest's rules history, oldest version first
+func (r *ContestRepository) ListRulesVersions(contestID uint) ([]*models.ContestRulesVersion, error) {
+	if contestID == 0 {
+		return nil, errors.New("invalid contest ID")
+	}
+
+	var versions []*models.ContestRulesVersion
+	result := r.db.Where("contest_id = ?", contestID).Order("version ASC").Find(&versions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return versions, nil
+}
+
+// PromoteDueRules copies the latest rules version in force into every contest whose stored
+// rules differ, so contests.rules always holds the rules in force for services that read it.
+// It returns how many contests changed.
+func (r *ContestRepository) PromoteDueRules(now time.Time) (int64, error) {
+	result := r.db.Exec(`
+		UPDATE contests SET rules = v.rules, updated_at = ?
+		FROM contest_rules_versions v
+		WHERE v.contest_id = contests.id
+		  AND v.version = (
+			SELECT MAX(version) FROM contest_rules_versions
+			WHERE contest_id = contests.id AND effective_from <= ?
+		  )
+		  AND contests.rules IS DISTINCT FROM v.rules
+		  AND contests.deleted_at IS NULL`, now, now)
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+// HasPredictions checks if any prediction was made in a contest. Predictions are owned by
+// the prediction service but live in the shared database.
+func (r *ContestRepository) HasPredictions(contestID uint) (bool, error) {
+	if contestID == 0 {
+		return false, errors.New("invalid contest ID")
+	}
+
+	var exists bool
+	result := r.db.Raw("SELECT EXISTS (SELECT 1 FROM predictions WHERE contest_id = ? AND deleted_at IS NULL)", contestID).Scan(&exists)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return exists, nil
+}
+
 // Delete deletes a contest by ID
 func (r *ContestRepository) Delete(id uint) error {
 	if id == 0 {
@@ -52,6 +52,7 @@ func (s *ContestService) CreateContest(ctx context.Context, req *pb.CreateContes
 		Description:     req.Description,
 		SportType:       req.SportType,
 		Rules:           req.Rules,
+		RulesBasis:      req.RulesBasis,
 		StartDate:       req.StartDate.AsTime(),
 		EndDate:         req.EndDate.AsTime(),
 		MaxParticipants: uint(req.MaxParticipants),
@@ -157,20 +158,114 @@ func (s *ContestService) UpdateContest(ctx context.Context, req *pb.UpdateContes
 		}
 	}
 
-	// Update fields
+	// Changed rules become a new version so predictions keep the rules they were made under
+	rulesChanged := req.Rules != contest.Rules
+	effectiveFrom := time.Now()
+	if rulesChanged {
+		// Scheduled rules are not stored on the contest until they take effect, so they are
+		// validated here rather than by the contest's update hook
+		if err := models.ValidateRules(req.Rules); err != nil {
+			return &pb.UpdateContestResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   err.Error(),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+
+		if req.RulesEffectiveFrom != nil {
+			if req.RulesEffectiveFrom.AsTime().Before(effectiveFrom) {
+				return &pb.UpdateContestResponse{
+					Response: &common.Response{
+						Success:   false,
+						Message:   "Rules cannot take effect in the past",
+						Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+						Timestamp: timestamppb.Now(),
+					},
+				}, nil
+			}
+			effectiveFrom = req.RulesEffectiveFrom.AsTime()
+		}
+
+		versions, err := s.contestRepo.ListRulesVersions(contest.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to list rules versions of contest %d: %v", contest.ID, err)
+			return &pb.UpdateContestResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Failed to load rules history",
+					Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		if pending := len(versions); pending > 0 && versions[pending-1].EffectiveFrom.After(effectiveFrom) {
+			scheduled := versions[pending-1]
+			return &pb.UpdateContestResponse{
+				Response: &common.Response{
+					Success: false,
+					Message: fmt.Sprintf("Rules version %d is scheduled to take effect at %s; new rules cannot take effect before it",
+						scheduled.Version, scheduled.EffectiveFrom.UTC().Format(time.RFC3339)),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+	}
+
+	// The rules basis decides which version scores a prediction, so it is frozen once
+	// predictions exist
+	if req.RulesBasis != "" && req.RulesBasis != contest.RulesBasis {
+		hasPredictions, err := s.contestRepo.HasPredictions(contest.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to check predictions of contest %d: %v", contest.ID, err)
+			return &pb.UpdateContestResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Failed to check contest predictions",
+					Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		if hasPredictions {
+			return &pb.UpdateContestResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Rules basis cannot change once predictions exist",
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+	}
+
+	// Update fields; changed rules replace the stored ones only once their version is in force
 	contest.Title = req.Title
 	contest.Description = req.Description
 	contest.SportType = req.SportType
-	contest.Rules = req.Rules
 	contest.StartDate = req.StartDate.AsTime()
 	contest.EndDate = req.EndDate.AsTime()
 	contest.MaxParticipants = uint(req.MaxParticipants)
 	if req.Status != "" {
 		contest.Status = req.Status
 	}
+	if req.RulesBasis != "" {
+		contest.RulesBasis = req.RulesBasis
+	}
 
 	// Save to database
-	if err := s.contestRepo.Update(contest); err != nil {
+	if rulesChanged {
+		var version *models.ContestRulesVersion
+		if version, err = s.contestRepo.UpdateRules(contest, req.Rules, effectiveFrom, userID); err == nil {
+			log.Printf("[INFO] Contest %d rules version %d takes effect at %s", contest.ID, version.Version, version.EffectiveFrom.Format(time.RFC3339))
+		}
+	} else {
+		err = s.contestRepo.Update(contest)
+	}
+	if err != nil {
 		return &pb.UpdateContestResponse{
 			Response: &common.Response{
 				Success:   false,
@@ -208,6 +303,37 @@ func (s *ContestService) GetContest(ctx context.Context, req *pb.GetContestReque
 		}, nil
 	}
 
+	var rulesHistory []*pb.ContestRulesVersion
+	if req.IncludeRulesHistory {
+		versions, err := s.contestRepo.ListRulesVersions(contest.ID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to list rules versions of contest %d: %v", contest.ID, err)
+			return &pb.GetContestResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Failed to load rules history",
+					Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		// Contests created before rules were versioned have only their current rules
+		if len(versions) == 0 {
+			versions = []*models.ContestRulesVersion{{
+				ContestID:     contest.ID,
+				Version:       1,
+				Rules:         contest.Rules,
+				EffectiveFrom: contest.CreatedAt,
+				CreatedBy:     contest.CreatorID,
+				CreatedAt:     contest.CreatedAt,
+			}}
+		}
+		rulesHistory = make([]*pb.ContestRulesVersion, len(versions))
+		for i, version := range versions {
+			rulesHistory[i] = s.rulesVersionToProto(version)
+		}
+	}
+
 	return &pb.GetContestResponse{
 		Response: &common.Response{
 			Success:   true,
@@ -215,7 +341,8 @@ func (s *ContestService) GetContest(ctx context.Context, req *pb.GetContestReque
 			Code:      0,
 			Timestamp: timestamppb.Now(),
 		},
-		Contest: s.modelToProto(contest),
+		Contest:      s.modelToProto(contest),
+		RulesHistory: rulesHistory,
 	}, nil
 }
 
@@ -571,6 +698,7 @@ func (s *ContestService) modelToProto(contest *models.Contest) *pb.Contest {
 		Description:         contest.Description,
 		SportType:           contest.SportType,
 		Rules:               contest.Rules,
+		RulesBasis:          contest.RulesBasis,
 		Status:              contest.GetComputedStatus(), // Use computed status based on dates
 		StartDate:           timestamppb.New(contest.StartDate),
 		EndDate:             timestamppb.New(contest.EndDate),
@@ -582,6 +710,17 @@ func (s *ContestService) modelToProto(contest *models.Contest) *pb.Contest {
 	}
 }
 
+// rulesVersionToProto converts ContestRulesVersion model to proto message
+func (s *ContestService) rulesVersionToProto(version *models.ContestRulesVersion) *pb.ContestRulesVersion {
+	return &pb.ContestRulesVersion{
+		Version:       uint32(version.Version),
+		Rules:         version.Rules,
+		EffectiveFrom: timestamppb.New(version.EffectiveFrom),
+		CreatedBy:     uint32(version.CreatedBy),
+		CreatedAt:     timestamppb.New(version.CreatedAt),
+	}
+}
+
 // participantModelToProto converts Participant model to proto message
 func (s *ContestService) participantModelToProto(participant *models.Participant) *pb.Participant {
 	return &pb.Participant{
@@ -591,5 +730,6 @@ func (s *ContestService) participantModelToProto(participant *models.Participant
 		Role:      participant.Role,
 		Status:    participant.Status,
 		JoinedAt:  timestamppb.New(participant.JoinedAt),
+		LivesLost: uint32(participant.LivesLost),
 	}
 }
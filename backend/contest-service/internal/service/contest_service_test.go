@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sports-prediction-contests/contest-service/internal/models"
+	"github.com/sports-prediction-contests/contest-service/internal/repository"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/contest"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeContestRepository keeps one contest and its rules versions in memory; methods a test
+// does not need are left to the embedded interface and panic if called
+type fakeContestRepository struct {
+	repository.ContestRepositoryInterface
+	contest      *models.Contest
+	versions     []*models.ContestRulesVersion
+	rulesUpdates int
+}
+
+func (r *fakeContestRepository) GetByID(id uint) (*models.Contest, error) {
+	found := *r.contest
+	return &found, nil
+}
+
+func (r *fakeContestRepository) ListRulesVersions(contestID uint) ([]*models.ContestRulesVersion, error) {
+	return r.versions, nil
+}
+
+func (r *fakeContestRepository) UpdateRules(contest *models.Contest, rules string, effectiveFrom time.Time, changedBy uint) (*models.ContestRulesVersion, error) {
+	r.rulesUpdates++
+	version := &models.ContestRulesVersion{
+		ContestID:     contest.ID,
+		Version:       uint(len(r.versions) + 1),
+		Rules:         rules,
+		EffectiveFrom: effectiveFrom,
+		CreatedBy:     changedBy,
+	}
+	r.versions = append(r.versions, version)
+	return version, nil
+}
+
+func newRulesTestService(t *testing.T) (*ContestService, *fakeContestRepository, context.Context) {
+	t.Helper()
+	start := time.Now().Add(24 * time.Hour)
+	repo := &fakeContestRepository{
+		contest: &models.Contest{
+			ID:        1,
+			Title:     "Premier League",
+			SportType: "football",
+			Rules:     `{"type":"standard"}`,
+			StartDate: start,
+			EndDate:   start.Add(30 * 24 * time.Hour),
+			Status:    "active",
+			CreatorID: 7,
+		},
+		versions: []*models.ContestRulesVersion{
+			{ContestID: 1, Version: 1, Rules: `{"type":"standard"}`, EffectiveFrom: time.Now().Add(-time.Hour)},
+		},
+	}
+	ctx := context.WithValue(context.Background(), "user_id", uint(7))
+	return NewContestService(repo, nil), repo, ctx
+}
+
+func rulesUpdateRequest(contest *models.Contest, rules string, effectiveFrom time.Time) *pb.UpdateContestRequest {
+	return &pb.UpdateContestRequest{
+		Id:                 uint32(contest.ID),
+		Title:              contest.Title,
+		SportType:          contest.SportType,
+		Rules:              rules,
+		StartDate:          timestamppb.New(contest.StartDate),
+		EndDate:            timestamppb.New(contest.EndDate),
+		RulesEffectiveFrom: timestamppb.New(effectiveFrom),
+	}
+}
+
+func TestUpdateContestRejectsInvalidScheduledRules(t *testing.T) {
+	svc, repo, ctx := newRulesTestService(t)
+
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"malformed JSON", `{"type":`},
+		{"unknown contest type", `{"type":"knockout_bingo"}`},
+		{"unknown sport", `{"type":"standard","sport":"curling"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := rulesUpdateRequest(repo.contest, tt.rules, time.Now().Add(48*time.Hour))
+			resp, err := svc.UpdateContest(ctx, req)
+			if err != nil {
+				t.Fatalf("UpdateContest returned error: %v", err)
+			}
+			if resp.Response.Success || resp.Response.Code != int32(common.ErrorCode_INVALID_ARGUMENT) {
+				t.Errorf("response = %v (%s), want INVALID_ARGUMENT", resp.Response.Code, resp.Response.Message)
+			}
+		})
+	}
+
+	if repo.rulesUpdates != 0 {
+		t.Errorf("invalid rules were stored as %d new versions", repo.rulesUpdates)
+	}
+}
+
+func TestUpdateContestRulesAfterPendingVersion(t *testing.T) {
+	svc, repo, ctx := newRulesTestService(t)
+
+	scheduled := time.Now().Add(48 * time.Hour)
+	resp, err := svc.UpdateContest(ctx, rulesUpdateRequest(repo.contest, `{"type":"standard","sport":"football"}`, scheduled))
+	if err != nil || !resp.Response.Success {
+		t.Fatalf("scheduling rules failed: %v %v", err, resp.GetResponse().GetMessage())
+	}
+
+	// An immediate change would take effect before the pending version
+	resp, err = svc.UpdateContest(ctx, rulesUpdateRequest(repo.contest, `{"type":"standard","sport":"basketball"}`, time.Now().Add(time.Minute)))
+	if err != nil {
+		t.Fatalf("UpdateContest returned error: %v", err)
+	}
+	if resp.Response.Success || resp.Response.Code != int32(common.ErrorCode_INVALID_ARGUMENT) {
+		t.Fatalf("response = %v (%s), want INVALID_ARGUMENT", resp.Response.Code, resp.Response.Message)
+	}
+	if !strings.Contains(resp.Response.Message, "Rules version 2 is scheduled") {
+		t.Errorf("message %q does not name the pending version", resp.Response.Message)
+	}
+
+	// Rules after the pending version are scheduled behind it
+	resp, err = svc.UpdateContest(ctx, rulesUpdateRequest(repo.contest, `{"type":"standard","sport":"basketball"}`, scheduled.Add(time.Hour)))
+	if err != nil || !resp.Response.Success {
+		t.Fatalf("scheduling rules after the pending version failed: %v %v", err, resp.GetResponse().GetMessage())
+	}
+	if repo.rulesUpdates != 2 {
+		t.Errorf("stored %d rules versions, want 2", repo.rulesUpdates)
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// PromoteDueRules moves contests whose scheduled rules version took effect onto it, so
+// services reading a contest's rules never see rules before they are in force. It returns
+// how many contests changed.
+func (s *ContestService) PromoteDueRules(ctx context.Context) (int64, error) {
+	promoted, err := s.contestRepo.PromoteDueRules(time.Now())
+	if err != nil {
+		return 0, err
+	}
+	if promoted > 0 {
+		log.Printf("[INFO] Scheduled rules took effect in %d contests", promoted)
+	}
+	return promoted, nil
+}
+
+// RunRulesPromotion promotes scheduled rules versions every interval until the context is done
+func (s *ContestService) RunRulesPromotion(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := s.PromoteDueRules(ctx); err != nil {
+				log.Printf("[ERROR] Failed to promote scheduled contest rules: %v", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -54,6 +54,13 @@ func main() {
 	}
 	defer teamClient.Close()
 
+	// Initialize scoring client
+	scoringClient, err := clients.NewScoringClient(cfg.ScoringServiceEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to create scoring client: %v", err)
+	}
+	defer scoringClient.Close()
+
 	// Initialize repositories
 	predictionRepo := repository.NewPredictionRepository(db)
 	eventRepo := repository.NewEventRepository(db)
@@ -62,7 +69,7 @@ func main() {
 	relayRepo := repository.NewRelayRepository(db)
 
 	// Initialize services
-	predictionService := service.NewPredictionService(predictionRepo, eventRepo, propTypeRepo, riskyEventRepo, relayRepo, contestClient, teamClient)
+	predictionService := service.NewPredictionService(predictionRepo, eventRepo, propTypeRepo, riskyEventRepo, relayRepo, contestClient, teamClient, scoringClient)
 
 	// Create gRPC server with JWT interceptor
 	server := grpc.NewServer(
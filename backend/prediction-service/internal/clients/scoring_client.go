@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/sports-prediction-contests/shared/auth"
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// ScoringClient wraps the gRPC scoring service client
+type ScoringClient struct {
+	client scoringpb.ScoringServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewScoringClient creates a new scoring service client
+func NewScoringClient(endpoint string) (*ScoringClient, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to scoring service: %w", err)
+	}
+
+	client := scoringpb.NewScoringServiceClient(conn)
+	return &ScoringClient{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the gRPC connection
+func (c *ScoringClient) Close() error {
+	return c.conn.Close()
+}
+
+// SettleEvent asks the scoring service to score all pending predictions for a completed event
+func (c *ScoringClient) SettleEvent(ctx context.Context, eventID uint32) (*scoringpb.SettleEventResponse, error) {
+	ctx = forwardUserID(ctx)
+
+	resp, err := c.client.SettleEvent(ctx, &scoringpb.SettleEventRequest{EventId: eventID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to settle event: %w", err)
+	}
+
+	if !resp.Response.Success {
+		return resp, fmt.Errorf("scoring service error: %s", resp.Response.Message)
+	}
+
+	return resp, nil
+}
+
+// RescoreEvent asks the scoring service to re-evaluate scored predictions after a result correction
+func (c *ScoringClient) RescoreEvent(ctx context.Context, eventID uint32, reason, previousResultData string) (*scoringpb.RescoreEventResponse, error) {
+	ctx = forwardUserID(ctx)
+
+	resp, err := c.client.RescoreEvent(ctx, &scoringpb.RescoreEventRequest{
+		EventId:            eventID,
+		Reason:             reason,
+		PreviousResultData: previousResultData,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rescore event: %w", err)
+	}
+
+	if !resp.Response.Success {
+		return resp, fmt.Errorf("scoring service error: %s", resp.Response.Message)
+	}
+
+	return resp, nil
+}
+
+// forwardUserID passes the caller identity so the scoring service accepts internal calls
+func forwardUserID(ctx context.Context) context.Context {
+	if userID, ok := auth.GetUserIDFromContext(ctx); ok {
+		return metadata.AppendToOutgoingContext(ctx, "x-user-id", strconv.FormatUint(uint64(userID), 10))
+	}
+	return ctx
+}
@@ -5,19 +5,22 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sports-prediction-contests/shared/scoring"
 	"gorm.io/gorm"
 )
 
 // Event represents a sports event that can be predicted
 type Event struct {
 	gorm.Model
-	Title      string    `gorm:"not null" json:"title"`
-	SportType  string    `gorm:"not null;index" json:"sport_type"`
-	HomeTeam   string    `gorm:"not null" json:"home_team"`
-	AwayTeam   string    `gorm:"not null" json:"away_team"`
-	EventDate  time.Time `gorm:"not null;index" json:"event_date"`
-	Status     string    `gorm:"not null;default:'scheduled';index" json:"status"` // "scheduled", "live", "completed", "cancelled"
-	ResultData string    `gorm:"type:jsonb" json:"result_data"` // JSON string for event results
+	Title            string    `gorm:"not null" json:"title"`
+	SportType        string    `gorm:"not null;index" json:"sport_type"`
+	HomeTeam         string    `gorm:"not null" json:"home_team"`
+	AwayTeam         string    `gorm:"not null" json:"away_team"`
+	EventDate        time.Time `gorm:"not null;index" json:"event_date"`
+	Status           string    `gorm:"not null;default:'scheduled';index" json:"status"` // "scheduled", "live", "completed", "cancelled"
+	ResultData       string    `gorm:"type:jsonb" json:"result_data"`                    // JSON string for event results
+	Rosters          string    `gorm:"type:text" json:"rosters"`                         // JSON scoring.Rosters naming the players player props may pick
+	ScoredResultData string    `gorm:"type:text" json:"scored_result_data"`              // Result scores were last settled against while a correction awaits rescoring
 }
 
 // ValidateTitle checks if the title is valid
@@ -92,12 +95,28 @@ func (e *Event) ValidateStatus() error {
 
 // ValidateResultData checks if the result data is valid
 func (e *Event) ValidateResultData() error {
-	if len(e.ResultData) > 5000 {
-		return errors.New("result data cannot exceed 5000 characters")
+	// Player stats for both squads make results far longer than a score line
+	if len(e.ResultData) > 20000 {
+		return errors.New("result data cannot exceed 20000 characters")
 	}
 	return nil
 }
 
+// ValidateRosters checks the players listed for the match
+func (e *Event) ValidateRosters() error {
+	_, err := scoring.ParseRosters(e.Rosters)
+	return err
+}
+
+// PlayerRosters returns the players listed for the match, empty when none are
+func (e *Event) PlayerRosters() *scoring.Rosters {
+	rosters, err := scoring.ParseRosters(e.Rosters)
+	if err != nil {
+		return &scoring.Rosters{}
+	}
+	return rosters
+}
+
 // BeforeCreate is a GORM hook that runs before creating an event
 func (e *Event) BeforeCreate(tx *gorm.DB) error {
 	// Set default status if not provided
@@ -130,6 +149,10 @@ func (e *Event) BeforeCreate(tx *gorm.DB) error {
 		return err
 	}
 
+	if err := e.ValidateRosters(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -11,6 +11,7 @@ type MatchRiskyEvent struct {
 	Points           float64 `gorm:"type:decimal(5,2);not null" json:"points"`
 	IsEnabled        bool    `gorm:"default:true" json:"is_enabled"`
 	Outcome          *bool   `json:"outcome"` // nil=pending, true=happened, false=didn't happen
+	OutcomeSource    string  `gorm:"size:10" json:"outcome_source"` // OutcomeSourceAuto or OutcomeSourceManual, empty while pending
 
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -25,6 +26,12 @@ func (MatchRiskyEvent) TableName() string {
 	return "match_risky_events"
 }
 
+// Where a match risky event outcome came from
+const (
+	OutcomeSourceAuto   = "auto"   // derived from the event's result data
+	OutcomeSourceManual = "manual" // set by an admin, never replaced by derived outcomes
+)
+
 // MatchRiskyEventView is a view combining event type info with match-specific overrides
 type MatchRiskyEventView struct {
 	RiskyEventTypeID uint    `json:"risky_event_type_id"`
@@ -36,6 +43,7 @@ type MatchRiskyEventView struct {
 	Points           float64 `json:"points"`    // Final points (override or default)
 	IsEnabled        bool    `json:"is_enabled"`
 	Outcome          *bool   `json:"outcome"`
+	OutcomeSource    string  `json:"outcome_source"`
 	IsOverridden     bool    `json:"is_overridden"` // True if points differ from default
 }
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/sports-prediction-contests/shared/scoring"
 	"gorm.io/gorm"
 )
 
@@ -20,6 +21,7 @@ type PropType struct {
 	MinValue      *float64 `json:"min_value"`
 	MaxValue      *float64 `json:"max_value"`
 	PointsCorrect float64  `gorm:"not null;default:2" json:"points_correct"`
+	Evaluation    string   `gorm:"type:text" json:"evaluation"` // JSON scoring.PropEvaluation telling the scoring service how to settle the prop
 	IsActive      bool     `gorm:"default:true" json:"is_active"`
 }
 
@@ -71,6 +73,16 @@ func (p *PropType) ValidateSlug() error {
 	return nil
 }
 
+// ValidateEvaluation checks the settlement definition. Empty is allowed for prop types the
+// scoring service settles with a built-in definition.
+func (p *PropType) ValidateEvaluation() error {
+	if strings.TrimSpace(p.Evaluation) == "" {
+		return nil
+	}
+	_, err := scoring.ParsePropEvaluation(p.Evaluation)
+	return err
+}
+
 func (p *PropType) BeforeCreate(tx *gorm.DB) error {
 	if err := p.ValidateSportType(); err != nil {
 		return err
@@ -84,5 +96,8 @@ func (p *PropType) BeforeCreate(tx *gorm.DB) error {
 	if err := p.ValidateCategory(); err != nil {
 		return err
 	}
+	if err := p.ValidateEvaluation(); err != nil {
+		return err
+	}
 	return p.ValidateValueType()
 }
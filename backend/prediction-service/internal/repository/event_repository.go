@@ -25,6 +25,7 @@ type EventRepositoryInterface interface {
 	RemoveEventsFromContest(contestID uint, eventIDs []uint) error
 	SetContestEvents(contestID uint, eventIDs []uint) error
 	GetContestEventCount(contestID uint) (int64, error)
+	GetContestEventIDs(contestID uint) ([]uint, error)
 }
 
 // EventRepository implements EventRepositoryInterface
@@ -237,3 +238,10 @@ func (r *EventRepository) GetContestEventCount(contestID uint) (int64, error) {
 	err := r.db.Raw("SELECT COUNT(*) FROM contest_events WHERE contest_id = ?", contestID).Scan(&count).Error
 	return count, err
 }
+
+// GetContestEventIDs returns the IDs of the events in a contest
+func (r *EventRepository) GetContestEventIDs(contestID uint) ([]uint, error) {
+	var eventIDs []uint
+	err := r.db.Raw("SELECT event_id FROM contest_events WHERE contest_id = ? ORDER BY event_id", contestID).Scan(&eventIDs).Error
+	return eventIDs, err
+}
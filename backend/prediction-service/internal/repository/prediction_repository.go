@@ -17,6 +17,8 @@ type PredictionRepositoryInterface interface {
 	Delete(id uint) error
 	List(limit, offset int, contestID uint, userID uint) ([]*models.Prediction, int64, error)
 	CountByContest(contestID uint) (int64, error)
+	SaveSet(predictions []*models.Prediction) error
+	GetParticipantStatus(contestID, userID uint) (string, error)
 }
 
 // PredictionRepository implements PredictionRepositoryInterface
@@ -140,3 +142,42 @@ func (r *PredictionRepository) CountByContest(contestID uint) (int64, error) {
 	err := r.db.Model(&models.Prediction{}).Where("contest_id = ?", contestID).Count(&count).Error
 	return count, err
 }
+
+// SaveSet creates or updates a set of predictions in a single transaction,
+// so a round is never left half written
+func (r *PredictionRepository) SaveSet(predictions []*models.Prediction) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, prediction := range predictions {
+			if prediction == nil {
+				return errors.New("prediction cannot be nil")
+			}
+			if prediction.ID == 0 {
+				if err := tx.Omit("Event").Create(prediction).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			// Use raw SQL update to avoid GORM trying to save related Event
+			if err := tx.Exec(
+				"UPDATE predictions SET prediction_data = ?, submitted_at = ?, updated_at = NOW() WHERE id = ?",
+				prediction.PredictionData, prediction.SubmittedAt, prediction.ID,
+			).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetParticipantStatus returns a user's participant status in a contest, or an empty status if
+// the user has not joined. Participants are owned by the contest service but live in the
+// shared database.
+func (r *PredictionRepository) GetParticipantStatus(contestID, userID uint) (string, error) {
+	var statuses []string
+	err := r.db.Raw("SELECT status FROM participants WHERE contest_id = ? AND user_id = ? AND deleted_at IS NULL LIMIT 1",
+		contestID, userID).Scan(&statuses).Error
+	if err != nil || len(statuses) == 0 {
+		return "", err
+	}
+	return statuses[0], nil
+}
@@ -130,7 +130,9 @@ func (r *RiskyEventRepository) GetMatchRiskyEvents(eventID uint, contestRulesJSO
 				view.Points = mo.Points
 				view.IsEnabled = mo.IsEnabled
 				view.Outcome = mo.Outcome
-				view.IsOverridden = true
+				view.OutcomeSource = mo.OutcomeSource
+				// Rows created only to hold an outcome keep the points
+				view.IsOverridden = view.IsOverridden || mo.Points != ce.Points || !mo.IsEnabled
 			}
 
 			result = append(result, view)
@@ -155,7 +157,9 @@ func (r *RiskyEventRepository) GetMatchRiskyEvents(eventID uint, contestRulesJSO
 				view.Points = mo.Points
 				view.IsEnabled = mo.IsEnabled
 				view.Outcome = mo.Outcome
-				view.IsOverridden = true
+				view.OutcomeSource = mo.OutcomeSource
+				// Rows created only to hold an outcome keep the points
+				view.IsOverridden = view.IsOverridden || mo.Points != et.DefaultPoints || !mo.IsEnabled
 			}
 
 			result = append(result, view)
@@ -188,11 +192,66 @@ func (r *RiskyEventRepository) SetMatchEventOverride(eventID uint, riskyEventTyp
 	return r.db.Save(&existing).Error
 }
 
-// SetMatchEventOutcome records the outcome of a risky event after match completion
+// SetMatchEventOutcome records the outcome of a risky event after match completion. Outcomes
+// set by hand are never replaced by derived ones.
 func (r *RiskyEventRepository) SetMatchEventOutcome(eventID uint, riskyEventTypeID uint, happened bool) error {
-	return r.db.Model(&models.MatchRiskyEvent{}).
-		Where("event_id = ? AND risky_event_type_id = ?", eventID, riskyEventTypeID).
-		Update("outcome", happened).Error
+	eventType, err := r.GetEventType(riskyEventTypeID)
+	if err != nil {
+		return err
+	}
+	return setOutcome(r.db, eventID, eventType, happened, models.OutcomeSourceManual)
+}
+
+// SetDerivedOutcomes records outcomes derived from the match result, keyed by risky event
+// type ID, skipping those set by hand. It returns how many outcomes were recorded.
+func (r *RiskyEventRepository) SetDerivedOutcomes(eventID uint, eventTypes []models.RiskyEventType, outcomes map[uint]bool) (int, error) {
+	recorded := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		recorded = 0
+		for i := range eventTypes {
+			happened, ok := outcomes[eventTypes[i].ID]
+			if !ok {
+				continue
+			}
+			var existing models.MatchRiskyEvent
+			err := tx.Where("event_id = ? AND risky_event_type_id = ?", eventID, eventTypes[i].ID).First(&existing).Error
+			if err == nil && existing.OutcomeSource == models.OutcomeSourceManual {
+				continue
+			}
+			if err != nil && err != gorm.ErrRecordNotFound {
+				return err
+			}
+			if err := setOutcome(tx, eventID, &eventTypes[i], happened, models.OutcomeSourceAuto); err != nil {
+				return err
+			}
+			recorded++
+		}
+		return nil
+	})
+	return recorded, err
+}
+
+// setOutcome writes a match risky event outcome, creating the match row with the event
+// type's default points when the match has no override
+func setOutcome(tx *gorm.DB, eventID uint, eventType *models.RiskyEventType, happened bool, source string) error {
+	var existing models.MatchRiskyEvent
+	err := tx.Where("event_id = ? AND risky_event_type_id = ?", eventID, eventType.ID).First(&existing).Error
+	if err == gorm.ErrRecordNotFound {
+		return tx.Create(&models.MatchRiskyEvent{
+			EventID:          eventID,
+			RiskyEventTypeID: eventType.ID,
+			Points:           eventType.DefaultPoints,
+			IsEnabled:        true,
+			Outcome:          &happened,
+			OutcomeSource:    source,
+		}).Error
+	} else if err != nil {
+		return err
+	}
+
+	existing.Outcome = &happened
+	existing.OutcomeSource = source
+	return tx.Save(&existing).Error
 }
 
 // DeleteMatchEventOverride removes a match-level override
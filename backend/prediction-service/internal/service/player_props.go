@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sports-prediction-contests/prediction-service/internal/models"
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// validatePlayerProps checks that every player prop pick names a player listed on the
+// event's rosters. Picks of prop types that are not about a player are left alone.
+func (s *PredictionService) validatePlayerProps(ctx context.Context, event *models.Event, predictionData string) error {
+	prediction, err := scoring.ParsePrediction(predictionData)
+	if err != nil || prediction.Type != "props" {
+		return nil
+	}
+
+	rosters := event.PlayerRosters()
+	for _, prop := range prediction.Props {
+		needsPlayer, err := s.propNeedsPlayer(ctx, prop)
+		if err != nil {
+			return err
+		}
+		if !needsPlayer {
+			continue
+		}
+		if prop.PlayerID == "" {
+			return fmt.Errorf("prop %s requires a player", propName(prop))
+		}
+		if rosters.IsEmpty() {
+			return errors.New("player props are not available until the match rosters are set")
+		}
+		if !rosters.Has(prop.PlayerID) {
+			return fmt.Errorf("player %s is not on the rosters of this match", prop.PlayerID)
+		}
+	}
+	return nil
+}
+
+// propNeedsPlayer reports whether a pick's prop type is about a player, going by the stored
+// prop type or, for picks without a prop type ID, the built-in evaluation of the slug
+func (s *PredictionService) propNeedsPlayer(ctx context.Context, prop scoring.PropPrediction) (bool, error) {
+	if prop.PropTypeID == 0 {
+		evaluation, ok := scoring.BuiltinPropEvaluations[prop.PropSlug]
+		return ok && evaluation.NeedsPlayer(), nil
+	}
+
+	propType, err := s.propTypeRepo.GetByID(ctx, prop.PropTypeID)
+	if err != nil {
+		return false, fmt.Errorf("prop type %d not found", prop.PropTypeID)
+	}
+	if propType.Category == "player" {
+		return true, nil
+	}
+	if propType.Evaluation != "" {
+		evaluation, err := scoring.ParsePropEvaluation(propType.Evaluation)
+		return err == nil && evaluation.NeedsPlayer(), nil
+	}
+	return false, nil
+}
+
+// propName names a pick's prop type in validation errors
+func propName(prop scoring.PropPrediction) string {
+	if prop.PropSlug != "" {
+		return prop.PropSlug
+	}
+	return fmt.Sprintf("%d", prop.PropTypeID)
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/sports-prediction-contests/shared/coefficient"
 	"github.com/sports-prediction-contests/shared/proto/common"
 	pb "github.com/sports-prediction-contests/shared/proto/prediction"
+	"github.com/sports-prediction-contests/shared/scoring"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -29,6 +32,7 @@ type PredictionService struct {
 	relayRepo        repository.RelayRepositoryInterface
 	contestClient    *clients.ContestClient
 	teamClient       *clients.TeamClient
+	scoringClient    *clients.ScoringClient
 }
 
 // NewPredictionService creates a new PredictionService instance
@@ -40,6 +44,7 @@ func NewPredictionService(
 	relayRepo repository.RelayRepositoryInterface,
 	contestClient *clients.ContestClient,
 	teamClient *clients.TeamClient,
+	scoringClient *clients.ScoringClient,
 ) *PredictionService {
 	return &PredictionService{
 		predictionRepo:   predictionRepo,
@@ -49,6 +54,7 @@ func NewPredictionService(
 		relayRepo:        relayRepo,
 		contestClient:    contestClient,
 		teamClient:       teamClient,
+		scoringClient:    scoringClient,
 	}
 }
 
@@ -65,10 +71,33 @@ func (s *PredictionService) SubmitPrediction(ctx context.Context, req *pb.Submit
 	_ = s.contestClient.ValidateContestParticipation(ctx, req.ContestId, uint32(userID))
 
 	// Check if contest is relay type - user can only predict assigned events
+	var rules *scoring.ContestRules
 	contest, err := s.contestClient.GetContest(ctx, req.ContestId)
 	if err == nil && contest != nil {
-		contestType := parseContestType(contest.Rules)
-		if contestType == "relay" {
+		rules = scoring.ParseRulesOrDefault(scoring.RulesWithSport(contest.Rules, contest.SportType))
+		if err := rules.ValidatePrediction(req.PredictionData); err != nil {
+			return &pb.SubmitPredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Invalid prediction: " + err.Error(),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+
+		if rules.RequiresPredictionSet() {
+			return &pb.SubmitPredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Picks in this contest must be submitted together as a prediction set",
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+
+		if rules.Type == scoring.ContestTypeRelay {
 			// For relay contests, validate user is assigned to this event
 			canPredict, err := s.relayRepo.ValidateUserCanPredict(
 				uint(req.ContestId), 0, uint(userID), uint(req.EventId),
@@ -120,6 +149,32 @@ func (s *PredictionService) SubmitPrediction(ctx context.Context, req *pb.Submit
 		}, nil
 	}
 
+	if err := s.validatePlayerProps(ctx, event, req.PredictionData); err != nil {
+		return &pb.SubmitPredictionResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Invalid prediction: " + err.Error(),
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	predictionData := req.PredictionData
+	if rules != nil && rules.Type == scoring.ContestTypeSurvivor {
+		predictionData, err = s.prepareSurvivorPick(ctx, rules, contest, userID, event, req.PredictionData)
+		if err != nil {
+			return &pb.SubmitPredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   err.Error(),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+	}
+
 	// Check for existing prediction
 	existingPrediction, err := s.predictionRepo.GetByUserContestAndEvent(userID, uint(req.ContestId), uint(req.EventId))
 	if err != nil {
@@ -135,7 +190,7 @@ func (s *PredictionService) SubmitPrediction(ctx context.Context, req *pb.Submit
 
 	// If prediction exists, update it (allow changing prediction before match starts)
 	if existingPrediction != nil {
-		existingPrediction.PredictionData = req.PredictionData
+		existingPrediction.PredictionData = predictionData
 		existingPrediction.SubmittedAt = time.Now().UTC()
 		
 		if err := s.predictionRepo.Update(existingPrediction); err != nil {
@@ -165,7 +220,7 @@ func (s *PredictionService) SubmitPrediction(ctx context.Context, req *pb.Submit
 		ContestID:      uint(req.ContestId),
 		UserID:         userID,
 		EventID:        uint(req.EventId),
-		PredictionData: req.PredictionData,
+		PredictionData: predictionData,
 		Status:         "pending",
 		SubmittedAt:    time.Now().UTC(),
 	}
@@ -350,8 +405,65 @@ func (s *PredictionService) UpdatePrediction(ctx context.Context, req *pb.Update
 		}, nil
 	}
 
+	// Validate the new payload against the contest type when the contest is reachable
+	predictionData := req.PredictionData
+	if contest, err := s.contestClient.GetContest(ctx, uint32(prediction.ContestID)); err == nil && contest != nil {
+		rules := scoring.ParseRulesOrDefault(scoring.RulesWithSport(contest.Rules, contest.SportType))
+		if err := rules.ValidatePrediction(req.PredictionData); err != nil {
+			return &pb.UpdatePredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Invalid prediction: " + err.Error(),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+
+		if rules.RequiresPredictionSet() {
+			return &pb.UpdatePredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Picks in this contest must be submitted together as a prediction set",
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+
+		if rules.Type == scoring.ContestTypeSurvivor {
+			event, err := s.eventRepo.GetByID(prediction.EventID)
+			if err == nil {
+				predictionData, err = s.prepareSurvivorPick(ctx, rules, contest, userID, event, req.PredictionData)
+			}
+			if err != nil {
+				return &pb.UpdatePredictionResponse{
+					Response: &common.Response{
+						Success:   false,
+						Message:   err.Error(),
+						Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+						Timestamp: timestamppb.Now(),
+					},
+				}, nil
+			}
+		}
+	}
+
+	if event, err := s.eventRepo.GetByID(prediction.EventID); err == nil {
+		if err := s.validatePlayerProps(ctx, event, req.PredictionData); err != nil {
+			return &pb.UpdatePredictionResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Invalid prediction: " + err.Error(),
+					Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+	}
+
 	// Update prediction data
-	prediction.PredictionData = req.PredictionData
+	prediction.PredictionData = predictionData
 
 	if err := s.predictionRepo.Update(prediction); err != nil {
 		return &pb.UpdatePredictionResponse{
@@ -436,6 +548,7 @@ func (s *PredictionService) CreateEvent(ctx context.Context, req *pb.CreateEvent
 		AwayTeam:  req.AwayTeam,
 		EventDate: req.EventDate.AsTime(),
 		Status:    "scheduled",
+		Rosters:   req.Rosters,
 	}
 
 	if err := s.eventRepo.Create(event); err != nil {
@@ -582,6 +695,9 @@ func (s *PredictionService) UpdateEvent(ctx context.Context, req *pb.UpdateEvent
 	if req.ResultData != "" {
 		event.ResultData = req.ResultData
 	}
+	if req.Rosters != "" {
+		event.Rosters = req.Rosters
+	}
 
 	if err := s.eventRepo.Update(event); err != nil {
 		return &pb.UpdateEventResponse{
@@ -594,6 +710,13 @@ func (s *PredictionService) UpdateEvent(ctx context.Context, req *pb.UpdateEvent
 		}, nil
 	}
 
+	// Settle predictions once the event is completed with a result.
+	// Settlement is idempotent, so re-sending the result is harmless.
+	if (req.Status != "" || req.ResultData != "") && event.Status == "completed" && event.ResultData != "" {
+		s.resolveRiskyOutcomes(event)
+		s.settleEvent(ctx, event.ID)
+	}
+
 	return &pb.UpdateEventResponse{
 		Response: &common.Response{
 			Success:   true,
@@ -605,6 +728,129 @@ func (s *PredictionService) UpdateEvent(ctx context.Context, req *pb.UpdateEvent
 	}, nil
 }
 
+// CorrectEventResult replaces the result of a completed event and rescores every affected prediction
+func (s *PredictionService) CorrectEventResult(ctx context.Context, req *pb.CorrectEventResultRequest) (*pb.CorrectEventResultResponse, error) {
+	// TODO: Add admin role check
+
+	if !json.Valid([]byte(req.ResultData)) {
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Result data must be valid JSON",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	event, err := s.eventRepo.GetByID(uint(req.EventId))
+	if err != nil {
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   err.Error(),
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if event.Status != "completed" {
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Only completed events can have their result corrected",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	// Scores keep the result they were settled against until a rescore succeeds, so a retried
+	// correction reports that result rather than the one stored by the failed attempt
+	previousResult := event.ResultData
+	if event.ScoredResultData != "" {
+		previousResult = event.ScoredResultData
+	}
+	event.ResultData = req.ResultData
+	event.ScoredResultData = previousResult
+	if err := s.eventRepo.Update(event); err != nil {
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   err.Error(),
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	log.Printf("[INFO] Event %d result corrected: reason=%q", event.ID, req.Reason)
+	s.resolveRiskyOutcomes(event)
+
+	if s.scoringClient == nil {
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Result corrected but scoring service is not configured",
+				Code:      int32(common.ErrorCode_UNAVAILABLE),
+				Timestamp: timestamppb.Now(),
+			},
+			Event: s.eventModelToPB(event),
+		}, nil
+	}
+
+	resp, err := s.scoringClient.RescoreEvent(ctx, uint32(event.ID), req.Reason, previousResult)
+	if err != nil {
+		// The correction is stored; calling again with the same result retries the rescore
+		log.Printf("[ERROR] Rescoring of event %d failed: %v", event.ID, err)
+		return &pb.CorrectEventResultResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Result corrected but rescoring failed, retry the correction",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+			Event: s.eventModelToPB(event),
+		}, nil
+	}
+
+	event.ScoredResultData = ""
+	if err := s.eventRepo.Update(event); err != nil {
+		log.Printf("[WARN] Failed to clear settled result of event %d: %v", event.ID, err)
+	}
+
+	// Predictions that were still pending are settled against the corrected result
+	s.settleEvent(ctx, event.ID)
+
+	return &pb.CorrectEventResultResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Event result corrected",
+			Code:      0,
+			Timestamp: timestamppb.Now(),
+		},
+		Event:         s.eventModelToPB(event),
+		RescoredCount: resp.RescoredCount,
+	}, nil
+}
+
+// settleEvent triggers scoring for a completed event. Failures are logged and do not
+// fail the event update; settlement can be re-run via the scoring service.
+func (s *PredictionService) settleEvent(ctx context.Context, eventID uint) {
+	if s.scoringClient == nil {
+		return
+	}
+
+	resp, err := s.scoringClient.SettleEvent(ctx, uint32(eventID))
+	if err != nil {
+		log.Printf("[WARN] Settlement of event %d incomplete: %v", eventID, err)
+		return
+	}
+
+	log.Printf("[INFO] Event %d settled: %d scored, %d already scored", eventID, resp.SettledCount, resp.SkippedCount)
+}
+
 // Check implements health check
 func (s *PredictionService) Check(ctx context.Context, req *emptypb.Empty) (*common.Response, error) {
 	return &common.Response{
@@ -649,6 +895,7 @@ func (s *PredictionService) eventModelToPB(event *models.Event) *pb.Event {
 		EventDate:  timestamppb.New(event.EventDate),
 		Status:     event.Status,
 		ResultData: event.ResultData,
+		Rosters:    event.Rosters,
 		CreatedAt:  timestamppb.New(event.CreatedAt),
 		UpdatedAt:  timestamppb.New(event.UpdatedAt),
 	}
@@ -754,6 +1001,7 @@ func (s *PredictionService) propTypeToProto(pt *models.PropType) *pb.PropType {
 		ValueType:     pt.ValueType,
 		PointsCorrect: pt.PointsCorrect,
 		IsActive:      pt.IsActive,
+		Evaluation:    pt.Evaluation,
 	}
 	if pt.DefaultLine != nil {
 		proto.DefaultLine = *pt.DefaultLine
@@ -781,9 +1029,24 @@ func (s *PredictionService) GetPotentialCoefficient(ctx context.Context, req *pb
 		}, nil
 	}
 
+	// A contest may define its own tiers or disable the bonus, and contest types with fixed
+	// points never apply it
+	var config *coefficient.Config
+	appliesMultipliers := true
+	if req.ContestId > 0 {
+		if contest, err := s.contestClient.GetContest(ctx, req.ContestId); err == nil && contest != nil {
+			rules := scoring.ParseRulesOrDefault(contest.Rules)
+			config = rules.TimeCoefficient
+			appliesMultipliers = rules.AppliesMultipliers()
+		}
+	}
+
 	now := time.Now().UTC()
 	hoursUntilEvent := event.EventDate.Sub(now).Hours()
-	result := coefficient.Calculate(now, event.EventDate)
+	result := coefficient.CoefficientResult{Coefficient: 1.0, Tier: coefficient.StandardTier}
+	if appliesMultipliers {
+		result = config.Calculate(now, event.EventDate)
+	}
 
 	return &pb.GetPotentialCoefficientResponse{
 		Response: &common.Response{
@@ -971,14 +1234,13 @@ func (s *PredictionService) DeleteRiskyEventType(ctx context.Context, req *pb.De
 func (s *PredictionService) GetMatchRiskyEvents(ctx context.Context, req *pb.GetMatchRiskyEventsRequest) (*pb.GetMatchRiskyEventsResponse, error) {
 	// Get contest rules if contest_id provided
 	var contestRulesJSON string
-	maxSelections := 5 // default
+	maxSelections := scoring.DefaultRiskyRules().MaxSelections
 
 	if req.ContestId > 0 {
 		contest, err := s.contestClient.GetContest(ctx, req.ContestId)
 		if err == nil && contest != nil {
 			contestRulesJSON = contest.Rules
-			// Parse max_selections from rules
-			maxSelections = parseMaxSelections(contestRulesJSON)
+			maxSelections = scoring.ParseRulesOrDefault(contestRulesJSON).MaxRiskySelections()
 		}
 	}
 
@@ -1006,6 +1268,7 @@ func (s *PredictionService) GetMatchRiskyEvents(ctx context.Context, req *pb.Get
 			Points:           e.Points,
 			IsEnabled:        e.IsEnabled,
 			IsOverridden:     e.IsOverridden,
+			OutcomeSource:    e.OutcomeSource,
 		}
 		if e.Outcome != nil {
 			protoEvents[i].Outcome = e.Outcome
@@ -1055,7 +1318,8 @@ func (s *PredictionService) SetMatchRiskyEventOverride(ctx context.Context, req
 	}, nil
 }
 
-// SetMatchRiskyEventOutcome records the outcome of a risky event after match
+// SetMatchRiskyEventOutcome records the outcome of a risky event after match. Outcomes set
+// here override derived ones and are kept when the result is corrected.
 func (s *PredictionService) SetMatchRiskyEventOutcome(ctx context.Context, req *pb.SetMatchRiskyEventOutcomeRequest) (*pb.SetMatchRiskyEventOutcomeResponse, error) {
 	// TODO: Add admin role check
 
@@ -1102,42 +1366,6 @@ func (s *PredictionService) riskyEventTypeToProto(et *models.RiskyEventType) *pb
 	}
 }
 
-// Helper: parse max_selections from contest rules JSON
-func parseMaxSelections(rulesJSON string) int {
-	if rulesJSON == "" {
-		return 5
-	}
-	var rules struct {
-		Risky *struct {
-			MaxSelections int `json:"max_selections"`
-		} `json:"risky"`
-	}
-	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
-		return 5
-	}
-	if rules.Risky != nil && rules.Risky.MaxSelections > 0 {
-		return rules.Risky.MaxSelections
-	}
-	return 5
-}
-
-// Helper: parse contest type from rules JSON
-func parseContestType(rulesJSON string) string {
-	if rulesJSON == "" {
-		return "standard"
-	}
-	var rules struct {
-		Type string `json:"type"`
-	}
-	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
-		return "standard"
-	}
-	if rules.Type == "" {
-		return "standard"
-	}
-	return rules.Type
-}
-
 // SetContestEvents sets the events for a contest (replaces existing)
 func (s *PredictionService) SetContestEvents(ctx context.Context, req *pb.SetContestEventsRequest) (*pb.SetContestEventsResponse, error) {
 	if req.ContestId == 0 {
@@ -1155,6 +1383,28 @@ func (s *PredictionService) SetContestEvents(ctx context.Context, req *pb.SetCon
 		eventIDs[i] = uint(id)
 	}
 
+	// Confidence weights run 1..N, so the round must have exactly N events
+	if contest, err := s.contestClient.GetContest(ctx, uint32(req.ContestId)); err == nil && contest != nil {
+		rules := scoring.ParseRulesOrDefault(scoring.RulesWithSport(contest.Rules, contest.SportType))
+		if rules.Type == scoring.ContestTypeConfidence && len(eventIDs) != rules.Confidence.EventCount {
+			return &pb.SetContestEventsResponse{
+				Response: &common.Response{
+					Success: false,
+					Message: fmt.Sprintf("confidence contest requires exactly %d events", rules.Confidence.EventCount),
+				},
+			}, nil
+		}
+		// Bracket events are fixed by the bracket slots
+		if rules.Type == scoring.ContestTypeBracket && !sameEventSet(eventIDs, rules.Bracket.EventIDs()) {
+			return &pb.SetContestEventsResponse{
+				Response: &common.Response{
+					Success: false,
+					Message: "bracket contest events must match the events of its slots",
+				},
+			}, nil
+		}
+	}
+
 	// Set events for the contest
 	err := s.eventRepo.SetContestEvents(uint(req.ContestId), eventIDs)
 	if err != nil {
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/prediction-service/internal/models"
+	"github.com/sports-prediction-contests/shared/auth"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/prediction"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SubmitPredictionSet submits or replaces all of a user's picks for a contest round at once.
+// Contest types such as confidence pick'em can only validate picks together, so the set must
+// cover exactly the contest's events and is written in a single transaction.
+func (s *PredictionService) SubmitPredictionSet(ctx context.Context, req *pb.SubmitPredictionSetRequest) (*pb.SubmitPredictionSetResponse, error) {
+	userID, ok := auth.GetUserIDFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "user not authenticated")
+	}
+
+	if req.ContestId == 0 || len(req.Entries) == 0 {
+		return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT, "Contest ID and entries are required"), nil
+	}
+
+	contest, err := s.contestClient.GetContest(ctx, req.ContestId)
+	if err != nil || contest == nil {
+		return predictionSetFailure(common.ErrorCode_NOT_FOUND, "Contest not found"), nil
+	}
+
+	rules := scoring.ParseRulesOrDefault(scoring.RulesWithSport(contest.Rules, contest.SportType))
+	if !rules.RequiresPredictionSet() {
+		return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT, "This contest accepts individual predictions only"), nil
+	}
+
+	// Validate contest participation (non-blocking - auto-join on first prediction)
+	_ = s.contestClient.ValidateContestParticipation(ctx, req.ContestId, uint32(userID))
+
+	roundEventIDs, err := s.roundEventIDs(rules, uint(req.ContestId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to load events for contest %d: %v", req.ContestId, err)
+		return predictionSetFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to load contest events"), nil
+	}
+
+	inRound := make(map[uint]bool, len(roundEventIDs))
+	for _, eventID := range roundEventIDs {
+		inRound[eventID] = true
+	}
+	seen := make(map[uint]bool, len(req.Entries))
+	predictionsData := make([]string, len(req.Entries))
+	for i, entry := range req.Entries {
+		eventID := uint(entry.EventId)
+		if !inRound[eventID] || seen[eventID] {
+			return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT,
+				fmt.Sprintf("Picks must cover each of the contest's %d events exactly once", len(roundEventIDs))), nil
+		}
+		seen[eventID] = true
+
+		predictionsData[i] = entry.PredictionData
+		if rules.Type == scoring.ContestTypeBracket {
+			predictionsData[i], err = stampBracketSlot(rules.Bracket, eventID, entry.PredictionData)
+			if err != nil {
+				return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT, "Invalid prediction set: "+err.Error()), nil
+			}
+		}
+	}
+	if len(seen) != len(roundEventIDs) {
+		return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT,
+			fmt.Sprintf("Picks must cover each of the contest's %d events exactly once", len(roundEventIDs))), nil
+	}
+
+	if err := rules.ValidatePredictionSet(predictionsData); err != nil {
+		return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT, "Invalid prediction set: "+err.Error()), nil
+	}
+
+	// Picks are only valid as a whole, so the set is locked once any event of the round starts
+	now := time.Now().UTC()
+	predictions := make([]*models.Prediction, len(req.Entries))
+	for i, entry := range req.Entries {
+		event, err := s.eventRepo.GetByID(uint(entry.EventId))
+		if err != nil {
+			return predictionSetFailure(common.ErrorCode_NOT_FOUND, fmt.Sprintf("Event %d not found", entry.EventId)), nil
+		}
+		if !event.CanAcceptPredictions() {
+			return predictionSetFailure(common.ErrorCode_INVALID_ARGUMENT, "The round has started, picks can no longer be changed"), nil
+		}
+
+		prediction, err := s.predictionRepo.GetByUserContestAndEvent(userID, uint(req.ContestId), event.ID)
+		if err != nil {
+			return predictionSetFailure(common.ErrorCode_INTERNAL_ERROR, err.Error()), nil
+		}
+		if prediction == nil {
+			prediction = &models.Prediction{
+				ContestID: uint(req.ContestId),
+				UserID:    userID,
+				EventID:   event.ID,
+				Status:    "pending",
+			}
+		}
+		prediction.PredictionData = predictionsData[i]
+		prediction.SubmittedAt = now
+		predictions[i] = prediction
+	}
+
+	if err := s.predictionRepo.SaveSet(predictions); err != nil {
+		log.Printf("[ERROR] Failed to save prediction set for contest %d, user %d: %v", req.ContestId, userID, err)
+		return predictionSetFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to save prediction set"), nil
+	}
+
+	pbPredictions := make([]*pb.Prediction, len(predictions))
+	for i, prediction := range predictions {
+		pbPredictions[i] = s.modelToPB(prediction)
+	}
+
+	return &pb.SubmitPredictionSetResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Prediction set submitted successfully",
+			Code:      0,
+			Timestamp: timestamppb.Now(),
+		},
+		Predictions: pbPredictions,
+	}, nil
+}
+
+// roundEventIDs returns the events a prediction set must cover: the bracket's slot events,
+// otherwise the events selected for the contest
+func (s *PredictionService) roundEventIDs(rules *scoring.ContestRules, contestID uint) ([]uint, error) {
+	if rules.Type == scoring.ContestTypeBracket {
+		return rules.Bracket.EventIDs(), nil
+	}
+	return s.eventRepo.GetContestEventIDs(contestID)
+}
+
+// stampBracketSlot stores the slot an event is played in into a bracket pick,
+// so picks can be validated and scored by slot
+func stampBracketSlot(bracket *scoring.BracketRules, eventID uint, predictionData string) (string, error) {
+	slot, ok := bracket.SlotByEvent(eventID)
+	if !ok {
+		return "", fmt.Errorf("event %d is not part of the bracket", eventID)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(predictionData), &raw); err != nil {
+		return "", errors.New("invalid prediction data")
+	}
+	if existing, ok := raw["slot"].(string); ok && existing != "" && existing != slot.ID {
+		return "", fmt.Errorf("event %d is played in slot %q, not %q", eventID, slot.ID, existing)
+	}
+	raw["type"] = string(scoring.ContestTypeBracket)
+	raw["slot"] = slot.ID
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// predictionSetFailure builds a failed SubmitPredictionSet response
+func predictionSetFailure(code common.ErrorCode, message string) *pb.SubmitPredictionSetResponse {
+	return &pb.SubmitPredictionSetResponse{
+		Response: &common.Response{
+			Success:   false,
+			Message:   message,
+			Code:      int32(code),
+			Timestamp: timestamppb.Now(),
+		},
+	}
+}
+
+// sameEventSet checks if two lists contain the same event IDs, ignoring order and repeats
+func sameEventSet(a, b []uint) bool {
+	inA := make(map[uint]bool, len(a))
+	for _, id := range a {
+		inA[id] = true
+	}
+	inB := make(map[uint]bool, len(b))
+	for _, id := range b {
+		if !inA[id] {
+			return false
+		}
+		inB[id] = true
+	}
+	return len(inA) == len(inB)
+}
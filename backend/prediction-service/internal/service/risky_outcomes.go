@@ -0,0 +1,52 @@
+package service
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/sports-prediction-contests/prediction-service/internal/models"
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// resolveRiskyOutcomes derives the outcomes of the match's risky events from its result so
+// they are in place before settlement. Events the result says nothing about, and outcomes an
+// admin has set, are left for SetMatchRiskyEventOutcome. Failures are logged and never fail
+// the update that triggered them.
+func (s *PredictionService) resolveRiskyOutcomes(event *models.Event) {
+	if event.ResultData == "" {
+		return
+	}
+	var result scoring.ResultData
+	if err := json.Unmarshal([]byte(event.ResultData), &result); err != nil {
+		log.Printf("[WARN] Cannot derive risky outcomes of event %d: invalid result data: %v", event.ID, err)
+		return
+	}
+
+	eventTypes, err := s.riskyEventRepo.ListActiveEventTypes("")
+	if err != nil {
+		log.Printf("[WARN] Failed to load risky event types for event %d: %v", event.ID, err)
+		return
+	}
+
+	var resolvable []models.RiskyEventType
+	outcomes := make(map[uint]bool)
+	for _, eventType := range eventTypes {
+		if eventType.SportType != "" && !scoring.SameSport(eventType.SportType, event.SportType) {
+			continue
+		}
+		if occurred, known := scoring.ResolveRiskyOutcome(eventType.Slug, &result); known {
+			resolvable = append(resolvable, eventType)
+			outcomes[eventType.ID] = occurred
+		}
+	}
+	if len(resolvable) == 0 {
+		return
+	}
+
+	recorded, err := s.riskyEventRepo.SetDerivedOutcomes(event.ID, resolvable, outcomes)
+	if err != nil {
+		log.Printf("[WARN] Failed to record risky outcomes of event %d: %v", event.ID, err)
+		return
+	}
+	log.Printf("[INFO] Derived %d risky event outcomes for event %d", recorded, event.ID)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/sports-prediction-contests/prediction-service/internal/models"
+	contestpb "github.com/sports-prediction-contests/shared/proto/contest"
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// prepareSurvivorPick enforces the per-round survivor rules for a pick and returns the payload
+// stamped with the picked team name and round, so later picks can be checked against it:
+// eliminated participants cannot pick, each round allows one pick and each team can be used once
+func (s *PredictionService) prepareSurvivorPick(ctx context.Context, rules *scoring.ContestRules, contest *contestpb.Contest, userID uint, event *models.Event, predictionData string) (string, error) {
+	pick, err := scoring.ParsePrediction(predictionData)
+	if err != nil {
+		return "", err
+	}
+	if pick.Winner == nil {
+		return "", errors.New("survivor pick must be \"home\" or \"away\"")
+	}
+
+	status, err := s.predictionRepo.GetParticipantStatus(uint(contest.Id), userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to check survivor status for user %d in contest %d: %v", userID, contest.Id, err)
+		return "", errors.New("failed to check survivor status")
+	}
+	if status == "eliminated" {
+		return "", errors.New("you have been eliminated from this contest")
+	}
+
+	team := event.HomeTeam
+	if *pick.Winner == "away" {
+		team = event.AwayTeam
+	}
+
+	contestStart := event.EventDate
+	if contest.StartDate != nil {
+		contestStart = contest.StartDate.AsTime()
+	}
+	round := rules.Survivor.Round(contestStart, event.EventDate)
+
+	previous, err := s.predictionRepo.GetByUserAndContest(userID, uint(contest.Id))
+	if err != nil {
+		return "", fmt.Errorf("failed to load previous picks: %w", err)
+	}
+	for _, p := range previous {
+		if p.EventID == event.ID {
+			continue // The pick for this event is being replaced
+		}
+		prev, err := scoring.ParsePrediction(p.PredictionData)
+		if err != nil {
+			continue
+		}
+		if prev.Round == round {
+			return "", fmt.Errorf("you already made a pick for round %d", round)
+		}
+		if strings.EqualFold(prev.Team, team) {
+			return "", fmt.Errorf("%s was already picked in round %d", team, prev.Round)
+		}
+	}
+
+	// Stamp the raw payload so fields the client sent are kept as they were
+	var payload map[string]interface{}
+	if err := json.Unmarshal([]byte(predictionData), &payload); err != nil {
+		return "", errors.New("invalid prediction data")
+	}
+	payload["type"] = string(scoring.ContestTypeSurvivor)
+	payload["team"] = team
+	payload["round"] = round
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
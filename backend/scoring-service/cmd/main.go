@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/sports-prediction-contests/scoring-service/internal/cache"
+	"github.com/sports-prediction-contests/scoring-service/internal/clients"
 	"github.com/sports-prediction-contests/scoring-service/internal/config"
 	"github.com/sports-prediction-contests/scoring-service/internal/models"
 	"github.com/sports-prediction-contests/scoring-service/internal/repository"
@@ -39,7 +40,7 @@ func main() {
 	}
 
 	// Auto-migrate database schema
-	if err := db.AutoMigrate(&models.Score{}, &models.Leaderboard{}, &models.UserStreak{}); err != nil {
+	if err := db.AutoMigrate(&models.Score{}, &models.Leaderboard{}, &models.UserStreak{}, &models.ScoreAudit{}, &models.PoolDraw{}, &models.PoolPayout{}, &models.PoolJackpot{}, &models.LeaderboardRound{}, &models.LeaderboardSnapshot{}, &models.SkillRating{}, &models.SkillRatingChange{}, &models.UserAchievement{}); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
 
@@ -55,10 +56,23 @@ func main() {
 	leaderboardRepo := repository.NewLeaderboardRepository(db, redisCache)
 	streakRepo := repository.NewStreakRepository(db)
 	analyticsRepo := repository.NewAnalyticsRepository(db)
+	settlementRepo := repository.NewSettlementRepository(db)
+	auditRepo := repository.NewAuditRepository(db)
+	poolRepo := repository.NewPoolRepository(db)
+	snapshotRepo := repository.NewSnapshotRepository(db)
+	skillRepo := repository.NewSkillRepository(db)
+	achievementRepo := repository.NewAchievementRepository(db)
+
+	// Initialize notification client
+	notificationClient, err := clients.NewNotificationClient(cfg.NotificationServiceEndpoint)
+	if err != nil {
+		log.Fatalf("Failed to create notification client: %v", err)
+	}
+	defer notificationClient.Close()
 
 	// Initialize services
-	scoringService := service.NewScoringService(scoreRepo, leaderboardRepo, streakRepo, analyticsRepo)
-	leaderboardService := service.NewLeaderboardService(leaderboardRepo, scoreRepo, streakRepo)
+	scoringService := service.NewScoringService(scoreRepo, leaderboardRepo, streakRepo, analyticsRepo, settlementRepo, auditRepo, poolRepo, snapshotRepo, skillRepo, achievementRepo, notificationClient)
+	leaderboardService := service.NewLeaderboardService(leaderboardRepo, scoreRepo, streakRepo, settlementRepo, snapshotRepo)
 
 	// Create combined service that implements all methods
 	combinedService := &CombinedScoringService{
@@ -141,6 +155,10 @@ func (s *CombinedScoringService) CreateScore(ctx context.Context, req *pb.Create
 	return s.ScoringService.CreateScore(ctx, req)
 }
 
+func (s *CombinedScoringService) RescorePrediction(ctx context.Context, req *pb.RescorePredictionRequest) (*pb.RescorePredictionResponse, error) {
+	return s.ScoringService.RescorePrediction(ctx, req)
+}
+
 func (s *CombinedScoringService) GetScore(ctx context.Context, req *pb.GetScoreRequest) (*pb.GetScoreResponse, error) {
 	return s.ScoringService.GetScore(ctx, req)
 }
@@ -153,6 +171,22 @@ func (s *CombinedScoringService) CalculateScore(ctx context.Context, req *pb.Cal
 	return s.ScoringService.CalculateScore(ctx, req)
 }
 
+func (s *CombinedScoringService) SettleEvent(ctx context.Context, req *pb.SettleEventRequest) (*pb.SettleEventResponse, error) {
+	return s.ScoringService.SettleEvent(ctx, req)
+}
+
+func (s *CombinedScoringService) SettleTotalizatorDraw(ctx context.Context, req *pb.SettleTotalizatorDrawRequest) (*pb.SettleTotalizatorDrawResponse, error) {
+	return s.ScoringService.SettleTotalizatorDraw(ctx, req)
+}
+
+func (s *CombinedScoringService) RescoreEvent(ctx context.Context, req *pb.RescoreEventRequest) (*pb.RescoreEventResponse, error) {
+	return s.ScoringService.RescoreEvent(ctx, req)
+}
+
+func (s *CombinedScoringService) GetScoreBreakdown(ctx context.Context, req *pb.GetScoreBreakdownRequest) (*pb.GetScoreBreakdownResponse, error) {
+	return s.ScoringService.GetScoreBreakdown(ctx, req)
+}
+
 func (s *CombinedScoringService) GetLeaderboard(ctx context.Context, req *pb.GetLeaderboardRequest) (*pb.GetLeaderboardResponse, error) {
 	return s.LeaderboardService.GetLeaderboard(ctx, req)
 }
@@ -161,14 +195,34 @@ func (s *CombinedScoringService) GetUserRank(ctx context.Context, req *pb.GetUse
 	return s.LeaderboardService.GetUserRank(ctx, req)
 }
 
+func (s *CombinedScoringService) GetLeaderboardAroundUser(ctx context.Context, req *pb.GetLeaderboardAroundUserRequest) (*pb.GetLeaderboardAroundUserResponse, error) {
+	return s.LeaderboardService.GetLeaderboardAroundUser(ctx, req)
+}
+
 func (s *CombinedScoringService) GetUserStreak(ctx context.Context, req *pb.GetUserStreakRequest) (*pb.GetUserStreakResponse, error) {
 	return s.LeaderboardService.GetUserStreak(ctx, req)
 }
 
+func (s *CombinedScoringService) GetUserPointsTimeline(ctx context.Context, req *pb.GetUserPointsTimelineRequest) (*pb.GetUserPointsTimelineResponse, error) {
+	return s.LeaderboardService.GetUserPointsTimeline(ctx, req)
+}
+
 func (s *CombinedScoringService) UpdateLeaderboard(ctx context.Context, req *pb.UpdateLeaderboardRequest) (*pb.UpdateLeaderboardResponse, error) {
 	return s.LeaderboardService.UpdateLeaderboard(ctx, req)
 }
 
+func (s *CombinedScoringService) WatchLeaderboard(req *pb.WatchLeaderboardRequest, stream pb.ScoringService_WatchLeaderboardServer) error {
+	return s.LeaderboardService.WatchLeaderboard(req, stream)
+}
+
+func (s *CombinedScoringService) CreateLeaderboardSnapshot(ctx context.Context, req *pb.CreateLeaderboardSnapshotRequest) (*pb.CreateLeaderboardSnapshotResponse, error) {
+	return s.ScoringService.CreateLeaderboardSnapshot(ctx, req)
+}
+
+func (s *CombinedScoringService) GetRoundLeaderboard(ctx context.Context, req *pb.GetRoundLeaderboardRequest) (*pb.GetRoundLeaderboardResponse, error) {
+	return s.LeaderboardService.GetRoundLeaderboard(ctx, req)
+}
+
 func (s *CombinedScoringService) Check(ctx context.Context, req *emptypb.Empty) (*common.Response, error) {
 	return &common.Response{
 		Success:   true,
@@ -178,6 +232,10 @@ func (s *CombinedScoringService) Check(ctx context.Context, req *emptypb.Empty)
 	}, nil
 }
 
+func (s *CombinedScoringService) GetGlobalLeaderboard(ctx context.Context, req *pb.GetGlobalLeaderboardRequest) (*pb.GetGlobalLeaderboardResponse, error) {
+	return s.ScoringService.GetGlobalLeaderboard(ctx, req)
+}
+
 func (s *CombinedScoringService) GetUserAnalytics(ctx context.Context, req *pb.GetUserAnalyticsRequest) (*pb.GetUserAnalyticsResponse, error) {
 	return s.ScoringService.GetUserAnalytics(ctx, req)
 }
@@ -185,3 +243,19 @@ func (s *CombinedScoringService) GetUserAnalytics(ctx context.Context, req *pb.G
 func (s *CombinedScoringService) ExportAnalytics(ctx context.Context, req *pb.ExportAnalyticsRequest) (*pb.ExportAnalyticsResponse, error) {
 	return s.ScoringService.ExportAnalytics(ctx, req)
 }
+
+func (s *CombinedScoringService) ListAchievements(ctx context.Context, req *pb.ListAchievementsRequest) (*pb.ListAchievementsResponse, error) {
+	return s.ScoringService.ListAchievements(ctx, req)
+}
+
+func (s *CombinedScoringService) GetUserAchievements(ctx context.Context, req *pb.GetUserAchievementsRequest) (*pb.GetUserAchievementsResponse, error) {
+	return s.ScoringService.GetUserAchievements(ctx, req)
+}
+
+func (s *CombinedScoringService) EvaluateAchievements(ctx context.Context, req *pb.EvaluateAchievementsRequest) (*pb.EvaluateAchievementsResponse, error) {
+	return s.ScoringService.EvaluateAchievements(ctx, req)
+}
+
+func (s *CombinedScoringService) SimulateRules(ctx context.Context, req *pb.SimulateRulesRequest) (*pb.SimulateRulesResponse, error) {
+	return s.ScoringService.SimulateRules(ctx, req)
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"time"
@@ -84,44 +85,127 @@ func (r *RedisCache) SetLeaderboardScore(ctx context.Context, contestID uint, us
 	}).Err()
 }
 
-// GetLeaderboard retrieves the top N users from a contest leaderboard
-func (r *RedisCache) GetLeaderboard(ctx context.Context, contestID uint, limit int64) ([]LeaderboardEntry, error) {
+// SetLeaderboardRanking stores a contest's ranked leaderboard, entries ordered from first to
+// last. The order and ranks follow the contest's tie-breakers, so they are kept apart from the
+// points sorted set whose ties Redis would order by member.
+func (r *RedisCache) SetLeaderboardRanking(ctx context.Context, contestID uint, entries []LeaderboardEntry) error {
 	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
-	
-	// Get top scores in descending order
-	results, err := r.client.ZRevRangeWithScores(ctx, key, 0, limit-1).Result()
+	orderKey, ranksKey := rankOrderKey(contestID), ranksKey(contestID)
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, orderKey, ranksKey)
+		if len(entries) == 0 {
+			return nil
+		}
+
+		points := make([]*redis.Z, len(entries))
+		order := make([]*redis.Z, len(entries))
+		ranks := make([]interface{}, 0, 2*len(entries))
+		for i, entry := range entries {
+			member := strconv.FormatUint(uint64(entry.UserID), 10)
+			points[i] = &redis.Z{Score: entry.TotalPoints, Member: member}
+			order[i] = &redis.Z{Score: float64(i), Member: member}
+			ranks = append(ranks, member, entry.Rank)
+		}
+		pipe.ZAdd(ctx, key, points...)
+		pipe.ZAdd(ctx, orderKey, order...)
+		pipe.HSet(ctx, ranksKey, ranks...)
+		return nil
+	})
+	return err
+}
+
+// GetLeaderboard retrieves the top N users from a contest's ranked leaderboard. It returns no
+// entries until the ranking has been stored with SetLeaderboardRanking.
+func (r *RedisCache) GetLeaderboard(ctx context.Context, contestID uint, limit int64) ([]LeaderboardEntry, error) {
+	members, err := r.client.ZRange(ctx, rankOrderKey(contestID), 0, limit-1).Result()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
 	}
+	return r.rankedEntries(ctx, contestID, members)
+}
 
-	entries := make([]LeaderboardEntry, len(results))
-	for i, result := range results {
-		member, ok := result.Member.(string)
-		if !ok {
-			return nil, fmt.Errorf("failed to convert member to string: %v", result.Member)
+// GetLeaderboardAroundUser retrieves up to n users either side of a user in a contest's ranked
+// leaderboard, with the number of users ranked. Positions follow the tie-breakers, so they are
+// read from the order set rather than by ZREVRANK on points. It returns no entries when the
+// user is not in the stored ranking.
+func (r *RedisCache) GetLeaderboardAroundUser(ctx context.Context, contestID uint, userID uint, n int64) ([]LeaderboardEntry, int64, error) {
+	orderKey := rankOrderKey(contestID)
+	member := strconv.FormatUint(uint64(userID), 10)
+
+	position, err := r.client.ZRank(ctx, orderKey, member).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, 0, nil
 		}
-		
+		return nil, 0, fmt.Errorf("failed to get user position: %w", err)
+	}
+
+	var membersCmd *redis.StringSliceCmd
+	var totalCmd *redis.IntCmd
+	if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		membersCmd = pipe.ZRange(ctx, orderKey, max(0, position-n), position+n)
+		totalCmd = pipe.ZCard(ctx, orderKey)
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to get leaderboard around user: %w", err)
+	}
+
+	entries, err := r.rankedEntries(ctx, contestID, membersCmd.Val())
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, totalCmd.Val(), nil
+}
+
+// rankedEntries reads the ranks and points of leaderboard members listed in order
+func (r *RedisCache) rankedEntries(ctx context.Context, contestID uint, members []string) ([]LeaderboardEntry, error) {
+	if len(members) == 0 {
+		return nil, nil
+	}
+	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
+
+	var ranksCmd *redis.SliceCmd
+	var pointsCmd *redis.FloatSliceCmd
+	if _, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		ranksCmd = pipe.HMGet(ctx, ranksKey(contestID), members...)
+		pointsCmd = pipe.ZMScore(ctx, key, members...)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+	ranks, points := ranksCmd.Val(), pointsCmd.Val()
+
+	entries := make([]LeaderboardEntry, len(members))
+	for i, member := range members {
 		userID, err := strconv.ParseUint(member, 10, 32)
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse user ID: %w", err)
 		}
+		rankValue, ok := ranks[i].(string)
+		if !ok {
+			return nil, fmt.Errorf("missing rank for user %s", member)
+		}
+		rank, err := strconv.ParseUint(rankValue, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse rank: %w", err)
+		}
 
 		entries[i] = LeaderboardEntry{
 			UserID:      uint(userID),
-			TotalPoints: result.Score,
-			Rank:        uint(i + 1),
+			TotalPoints: points[i],
+			Rank:        uint(rank),
 		}
 	}
 
 	return entries, nil
 }
 
-// GetUserRank retrieves a user's rank in a contest leaderboard
+// GetUserRank retrieves a user's rank in a contest's ranked leaderboard
 func (r *RedisCache) GetUserRank(ctx context.Context, contestID uint, userID uint) (uint, error) {
-	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
 	member := strconv.FormatUint(uint64(userID), 10)
-	
-	rank, err := r.client.ZRevRank(ctx, key, member).Result()
+
+	rank, err := r.client.HGet(ctx, ranksKey(contestID), member).Uint64()
 	if err != nil {
 		if err == redis.Nil {
 			return 0, nil // User not found in leaderboard
@@ -129,7 +213,7 @@ func (r *RedisCache) GetUserRank(ctx context.Context, contestID uint, userID uin
 		return 0, fmt.Errorf("failed to get user rank: %w", err)
 	}
 
-	return uint(rank + 1), nil // Redis ranks are 0-based, we want 1-based
+	return uint(rank), nil
 }
 
 // GetUserScore retrieves a user's score in a contest leaderboard
@@ -152,8 +236,14 @@ func (r *RedisCache) GetUserScore(ctx context.Context, contestID uint, userID ui
 func (r *RedisCache) RemoveUserFromLeaderboard(ctx context.Context, contestID uint, userID uint) error {
 	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
 	member := strconv.FormatUint(uint64(userID), 10)
-	
-	return r.client.ZRem(ctx, key, member).Err()
+
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRem(ctx, key, member)
+		pipe.ZRem(ctx, rankOrderKey(contestID), member)
+		pipe.HDel(ctx, ranksKey(contestID), member)
+		return nil
+	})
+	return err
 }
 
 // GetLeaderboardSize returns the number of users in a contest leaderboard
@@ -165,13 +255,92 @@ func (r *RedisCache) GetLeaderboardSize(ctx context.Context, contestID uint) (in
 // ClearLeaderboard removes all entries from a contest leaderboard
 func (r *RedisCache) ClearLeaderboard(ctx context.Context, contestID uint) error {
 	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
-	return r.client.Del(ctx, key).Err()
+	return r.client.Del(ctx, key, rankOrderKey(contestID), ranksKey(contestID)).Err()
 }
 
 // SetLeaderboardTTL sets expiration time for a contest leaderboard
 func (r *RedisCache) SetLeaderboardTTL(ctx context.Context, contestID uint, ttl time.Duration) error {
 	key := fmt.Sprintf("contest:%d:leaderboard", contestID)
-	return r.client.Expire(ctx, key, ttl).Err()
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, k := range []string{key, rankOrderKey(contestID), ranksKey(contestID)} {
+			pipe.Expire(ctx, k, ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// LeaderboardDelta is a change to one participant's standing, published to leaderboard watchers
+type LeaderboardDelta struct {
+	UserID       uint    `json:"user_id"`
+	Rank         uint    `json:"rank"`
+	PreviousRank uint    `json:"previous_rank"`
+	TotalPoints  float64 `json:"total_points"`
+	PointsDelta  float64 `json:"points_delta"`
+}
+
+// PublishLeaderboardChanges notifies every scoring service instance watching a contest of
+// changes to its standings
+func (r *RedisCache) PublishLeaderboardChanges(ctx context.Context, contestID uint, deltas []LeaderboardDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	payload, err := json.Marshal(deltas)
+	if err != nil {
+		return fmt.Errorf("failed to encode leaderboard changes: %w", err)
+	}
+	return r.client.Publish(ctx, updatesChannel(contestID), payload).Err()
+}
+
+// LeaderboardSubscription receives the changes published for a contest's leaderboard
+type LeaderboardSubscription struct {
+	pubsub *redis.PubSub
+}
+
+// SubscribeLeaderboard starts receiving a contest's leaderboard changes. Changes published
+// before it returns are not received.
+func (r *RedisCache) SubscribeLeaderboard(ctx context.Context, contestID uint) (*LeaderboardSubscription, error) {
+	pubsub := r.client.Subscribe(ctx, updatesChannel(contestID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to leaderboard changes: %w", err)
+	}
+	return &LeaderboardSubscription{pubsub: pubsub}, nil
+}
+
+// Next blocks until the next batch of changes is published or the context is done
+func (s *LeaderboardSubscription) Next(ctx context.Context) ([]LeaderboardDelta, error) {
+	for {
+		msg, err := s.pubsub.ReceiveMessage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var deltas []LeaderboardDelta
+		if err := json.Unmarshal([]byte(msg.Payload), &deltas); err != nil {
+			continue // Skip payloads this version cannot read
+		}
+		return deltas, nil
+	}
+}
+
+// Close stops the subscription
+func (s *LeaderboardSubscription) Close() error {
+	return s.pubsub.Close()
+}
+
+// updatesChannel is the pub/sub channel of a contest's leaderboard changes
+func updatesChannel(contestID uint) string {
+	return fmt.Sprintf("contest:%d:leaderboard:updates", contestID)
+}
+
+// rankOrderKey is the sorted set of a contest's users by leaderboard position
+func rankOrderKey(contestID uint) string {
+	return fmt.Sprintf("contest:%d:leaderboard:order", contestID)
+}
+
+// ranksKey is the hash of a contest's users to their leaderboard rank
+func ranksKey(contestID uint) string {
+	return fmt.Sprintf("contest:%d:leaderboard:ranks", contestID)
 }
 
 // Close closes the Redis connection
@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	notificationpb "github.com/sports-prediction-contests/shared/proto/notification"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// NotificationClient wraps the gRPC notification service client
+type NotificationClient struct {
+	client notificationpb.NotificationServiceClient
+	conn   *grpc.ClientConn
+}
+
+// NewNotificationClient creates a new notification service client
+func NewNotificationClient(endpoint string) (*NotificationClient, error) {
+	conn, err := grpc.Dial(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to notification service: %w", err)
+	}
+
+	client := notificationpb.NewNotificationServiceClient(conn)
+	return &NotificationClient{
+		client: client,
+		conn:   conn,
+	}, nil
+}
+
+// Close closes the gRPC connection
+func (c *NotificationClient) Close() error {
+	return c.conn.Close()
+}
+
+// SendInApp sends a user an in-app notification. The call is made on behalf of the
+// recipient, since scoring notifications are not triggered by any one caller.
+func (c *NotificationClient) SendInApp(ctx context.Context, userID uint, notificationType notificationpb.NotificationType, title, message, data string) error {
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", strconv.FormatUint(uint64(userID), 10))
+
+	resp, err := c.client.SendNotification(ctx, &notificationpb.SendNotificationRequest{
+		UserId:   uint32(userID),
+		Type:     notificationType,
+		Title:    title,
+		Message:  message,
+		Data:     data,
+		Channels: []notificationpb.NotificationChannel{notificationpb.NotificationChannel_IN_APP},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	if !resp.Response.Success {
+		return fmt.Errorf("notification service error: %s", resp.Response.Message)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// AchievementProgress counts what a user has done towards badges across all contests
+type AchievementProgress struct {
+	Predictions   int64 // Scored predictions
+	ExactScores   int64
+	MaxStreak     int64 // Longest streak in any contest
+	ContestsWon   int64 // Finished contests closed in first place
+	PerfectRounds int64 // Contest matchdays with every prediction correct
+	ChallengesWon int64
+}
+
+// Achievement is a badge definition from the catalog
+type Achievement struct {
+	Code        string
+	Name        string
+	Description string
+	Threshold   int64
+	progress    func(AchievementProgress) int64
+}
+
+// Progress returns how far a user is towards the badge, capped at its threshold
+func (a Achievement) Progress(progress AchievementProgress) int64 {
+	return min(a.progress(progress), a.Threshold)
+}
+
+// Earned reports whether a user's progress unlocks the badge
+func (a Achievement) Earned(progress AchievementProgress) bool {
+	return a.progress(progress) >= a.Threshold
+}
+
+// PerfectRoundMinPredictions is how many predictions a matchday needs to count as a perfect round
+const PerfectRoundMinPredictions = 3
+
+// Achievements is the badge catalog, in display order
+var Achievements = []Achievement{
+	{
+		Code:        "first_exact_score",
+		Name:        "Bullseye",
+		Description: "Predict an exact score",
+		Threshold:   1,
+		progress:    func(p AchievementProgress) int64 { return p.ExactScores },
+	},
+	{
+		Code:        "streak_10",
+		Name:        "On Fire",
+		Description: "Get 10 predictions right in a row in a contest",
+		Threshold:   10,
+		progress:    func(p AchievementProgress) int64 { return p.MaxStreak },
+	},
+	{
+		Code:        "contest_winner",
+		Name:        "Champion",
+		Description: "Finish a contest in first place",
+		Threshold:   1,
+		progress:    func(p AchievementProgress) int64 { return p.ContestsWon },
+	},
+	{
+		Code:        "predictions_100",
+		Name:        "Centurion",
+		Description: "Have 100 predictions scored",
+		Threshold:   100,
+		progress:    func(p AchievementProgress) int64 { return p.Predictions },
+	},
+	{
+		Code:        "perfect_round",
+		Name:        "Perfect Round",
+		Description: "Get every prediction of a contest matchday right, with at least 3 predictions",
+		Threshold:   1,
+		progress:    func(p AchievementProgress) int64 { return p.PerfectRounds },
+	},
+	{
+		Code:        "challenger_5",
+		Name:        "Duelist",
+		Description: "Win 5 head-to-head challenges",
+		Threshold:   5,
+		progress:    func(p AchievementProgress) int64 { return p.ChallengesWon },
+	},
+}
+
+// NewlyEarned returns the badges a user's progress earns that are not unlocked yet
+func NewlyEarned(progress AchievementProgress, unlocked map[string]bool) []Achievement {
+	var earned []Achievement
+	for _, achievement := range Achievements {
+		if !unlocked[achievement.Code] && achievement.Earned(progress) {
+			earned = append(earned, achievement)
+		}
+	}
+	return earned
+}
+
+// UserAchievement is a badge a user has unlocked
+type UserAchievement struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	UserID     uint      `gorm:"not null;uniqueIndex:idx_user_achievement" json:"user_id"`
+	Code       string    `gorm:"not null;size:50;uniqueIndex:idx_user_achievement" json:"code"`
+	UnlockedAt time.Time `gorm:"not null" json:"unlocked_at"`
+}
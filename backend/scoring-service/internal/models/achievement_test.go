@@ -0,0 +1,34 @@
+package models
+
+import "testing"
+
+func TestNewlyEarned(t *testing.T) {
+	progress := AchievementProgress{Predictions: 120, ExactScores: 4, MaxStreak: 9, ChallengesWon: 5}
+
+	earned := NewlyEarned(progress, map[string]bool{"first_exact_score": true})
+	codes := make([]string, len(earned))
+	for i, achievement := range earned {
+		codes[i] = achievement.Code
+	}
+	want := []string{"predictions_100", "challenger_5"}
+	if len(codes) != len(want) {
+		t.Fatalf("NewlyEarned() = %v, want %v", codes, want)
+	}
+	for i := range want {
+		if codes[i] != want[i] {
+			t.Errorf("NewlyEarned()[%d] = %s, want %s", i, codes[i], want[i])
+		}
+	}
+
+	for _, achievement := range Achievements {
+		if achievement.Code == "streak_10" {
+			if achievement.Earned(progress) || achievement.Progress(progress) != 9 {
+				t.Errorf("streak_10 earned = %v with progress %d, want not earned with 9",
+					achievement.Earned(progress), achievement.Progress(progress))
+			}
+		}
+		if achievement.Code == "predictions_100" && achievement.Progress(progress) != 100 {
+			t.Errorf("predictions_100 progress = %d, want it capped at 100", achievement.Progress(progress))
+		}
+	}
+}
@@ -60,6 +60,9 @@ type UserAnalytics struct {
 	Trends             []AccuracyTrend          `json:"trends"`
 	PlatformComparison *PlatformStats           `json:"platform_comparison"`
 	TimeRange          string                   `json:"time_range"`
+	SkillRating        float64                  `json:"skill_rating"`
+	RatedEvents        uint                     `json:"rated_events"`
+	RatingHistory      []*SkillRatingChange     `json:"rating_history"`
 }
 
 // TimeRangeToDate converts time range string to start date
@@ -11,8 +11,9 @@ func CalculateTimeCoefficient(submittedAt, eventDate time.Time) float64 {
 	return coefficient.Calculate(submittedAt, eventDate).Coefficient
 }
 
-// CalculateWithTier returns both coefficient and tier in one call
-func CalculateWithTier(submittedAt, eventDate time.Time) (float64, string) {
-	result := coefficient.Calculate(submittedAt, eventDate)
+// CalculateWithTier returns both coefficient and tier in one call using a contest's tiers;
+// a nil config uses the default tiers
+func CalculateWithTier(submittedAt, eventDate time.Time, config *coefficient.Config) (float64, string) {
+	result := config.Calculate(submittedAt, eventDate)
 	return result.Coefficient, result.Tier
 }
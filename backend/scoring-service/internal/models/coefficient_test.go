@@ -3,6 +3,8 @@ package models
 import (
 	"testing"
 	"time"
+
+	"github.com/sports-prediction-contests/shared/coefficient"
 )
 
 func TestCalculateTimeCoefficient(t *testing.T) {
@@ -122,7 +124,7 @@ func TestCalculateWithTier(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			eventDate := now.Add(time.Duration(tt.hoursAhead) * time.Hour)
-			coeff, tier := CalculateWithTier(now, eventDate)
+			coeff, tier := CalculateWithTier(now, eventDate, nil)
 			if coeff != tt.expectedCoeff {
 				t.Errorf("CalculateWithTier() coeff = %v, want %v", coeff, tt.expectedCoeff)
 			}
@@ -132,3 +134,17 @@ func TestCalculateWithTier(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateWithTierContestConfig(t *testing.T) {
+	now := time.Now()
+	eventDate := now.Add(200 * time.Hour)
+
+	if coeff, tier := CalculateWithTier(now, eventDate, &coefficient.Config{Disabled: true}); coeff != 1.0 || tier != coefficient.StandardTier {
+		t.Errorf("CalculateWithTier() disabled = (%v, %v), want (1, Standard)", coeff, tier)
+	}
+
+	config := &coefficient.Config{Tiers: []coefficient.Tier{{Name: "Season Opener", FromHours: 100, Coefficient: 3.0}}}
+	if coeff, tier := CalculateWithTier(now, eventDate, config); coeff != 3.0 || tier != "Season Opener" {
+		t.Errorf("CalculateWithTier() custom = (%v, %v), want (3, Season Opener)", coeff, tier)
+	}
+}
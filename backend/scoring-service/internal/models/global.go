@@ -0,0 +1,135 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// How global leaderboards make contests of different sizes comparable
+const (
+	NormalizeRaw           = "raw"            // sum of points (default)
+	NormalizePerPrediction = "per_prediction" // average points per scored prediction
+	NormalizeRelative      = "relative"       // each contest's total divided by that contest's average total, summed
+	NormalizeRating        = "rating"         // skill rating, which does not grow with the number of contests joined
+)
+
+// GlobalLeaderboardFilter selects the scores a cross-contest leaderboard is computed from
+type GlobalLeaderboardFilter struct {
+	SportType string
+	LeagueID  uint
+	Season    string    // League season, e.g. "2025-26"
+	From      time.Time // Scored at or after, zero for no bound
+	To        time.Time // Scored before, zero for no bound
+}
+
+// MonthRange returns the bounds of a calendar month given as "2006-01", in UTC
+func MonthRange(month string) (time.Time, time.Time, error) {
+	from, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("month must look like 2006-01: %w", err)
+	}
+	return from, from.AddDate(0, 1, 0), nil
+}
+
+// ValidateNormalization checks that a normalization is known. Empty means raw.
+func ValidateNormalization(normalization string) error {
+	switch normalization {
+	case "", NormalizeRaw, NormalizePerPrediction, NormalizeRelative, NormalizeRating:
+		return nil
+	}
+	return errors.New("normalization must be raw, per_prediction, relative or rating")
+}
+
+// ContestTotal is a user's points and scored predictions in one contest
+type ContestTotal struct {
+	UserID      uint
+	ContestID   uint
+	Points      float64
+	Predictions int64
+}
+
+// GlobalStanding is a user's position on a cross-contest leaderboard
+type GlobalStanding struct {
+	UserID      uint
+	Rank        uint
+	Score       float64 // Points after normalization
+	TotalPoints float64
+	Predictions int64
+	Contests    int64
+}
+
+// RankGlobal combines users' contest totals into a ranked cross-contest leaderboard. Users with
+// fewer than minPredictions scored predictions are left out. Under relative normalization a
+// contest's average is taken over everyone who scored in it, and contests whose average is not
+// positive contribute nothing. Under rating normalization users are ranked by their skill
+// rating in ratings, and users who have not been rated yet hold the default rating.
+func RankGlobal(totals []ContestTotal, normalization string, minPredictions int64, ratings map[uint]float64) []GlobalStanding {
+	var averages map[uint]float64
+	if normalization == NormalizeRelative {
+		sums := make(map[uint]float64)
+		counts := make(map[uint]int64)
+		for _, total := range totals {
+			sums[total.ContestID] += total.Points
+			counts[total.ContestID]++
+		}
+		averages = make(map[uint]float64, len(sums))
+		for contestID, sum := range sums {
+			averages[contestID] = sum / float64(counts[contestID])
+		}
+	}
+
+	byUser := make(map[uint]*GlobalStanding)
+	var users []uint
+	relative := make(map[uint]float64)
+	for _, total := range totals {
+		standing, ok := byUser[total.UserID]
+		if !ok {
+			standing = &GlobalStanding{UserID: total.UserID}
+			byUser[total.UserID] = standing
+			users = append(users, total.UserID)
+		}
+		standing.TotalPoints += total.Points
+		standing.Predictions += total.Predictions
+		standing.Contests++
+		if average := averages[total.ContestID]; average > 0 {
+			relative[total.UserID] += total.Points / average
+		}
+	}
+
+	standings := make([]scoring.Standing, 0, len(users))
+	for _, userID := range users {
+		standing := byUser[userID]
+		if standing.Predictions < minPredictions {
+			continue
+		}
+		switch normalization {
+		case NormalizePerPrediction:
+			if standing.Predictions > 0 {
+				standing.Score = standing.TotalPoints / float64(standing.Predictions)
+			}
+		case NormalizeRelative:
+			standing.Score = relative[userID]
+		case NormalizeRating:
+			standing.Score = DefaultSkillRating
+			if rating, ok := ratings[userID]; ok {
+				standing.Score = rating
+			}
+		default:
+			standing.Score = standing.TotalPoints
+		}
+		standings = append(standings, scoring.Standing{UserID: userID, TotalPoints: standing.Score})
+	}
+
+	var rules *scoring.LeaderboardRules
+	ranks := rules.Rank(standings)
+
+	ranked := make([]GlobalStanding, len(standings))
+	for i, standing := range standings {
+		ranked[i] = *byUser[standing.UserID]
+		ranked[i].Rank = ranks[i]
+	}
+	return ranked
+}
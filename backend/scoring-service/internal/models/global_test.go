@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankGlobal(t *testing.T) {
+	// Contest 1 is large and high scoring, contest 2 small and low scoring
+	totals := []ContestTotal{
+		{UserID: 1, ContestID: 1, Points: 100, Predictions: 40},
+		{UserID: 2, ContestID: 1, Points: 60, Predictions: 40},
+		{UserID: 3, ContestID: 2, Points: 15, Predictions: 5},
+		{UserID: 4, ContestID: 2, Points: 5, Predictions: 5},
+		{UserID: 2, ContestID: 2, Points: 10, Predictions: 5},
+	}
+
+	tests := []struct {
+		name          string
+		normalization string
+		min           int64
+		wantUsers     []uint
+		wantScores    []float64
+	}{
+		{"raw", NormalizeRaw, 0, []uint{1, 2, 3, 4}, []float64{100, 70, 15, 5}},
+		{"per prediction", NormalizePerPrediction, 0, []uint{3, 1, 2, 4}, []float64{3, 2.5, 70.0 / 45, 1}},
+		{"relative", NormalizeRelative, 0, []uint{2, 3, 1, 4}, []float64{60.0/80 + 1, 1.5, 1.25, 0.5}},
+		{"minimum predictions", NormalizePerPrediction, 10, []uint{1, 2}, []float64{2.5, 70.0 / 45}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RankGlobal(totals, tt.normalization, tt.min, nil)
+			if len(got) != len(tt.wantUsers) {
+				t.Fatalf("got %d standings, want %d", len(got), len(tt.wantUsers))
+			}
+			for i, standing := range got {
+				if standing.UserID != tt.wantUsers[i] || standing.Score != tt.wantScores[i] {
+					t.Errorf("position %d = user %d with %v, want user %d with %v",
+						i+1, standing.UserID, standing.Score, tt.wantUsers[i], tt.wantScores[i])
+				}
+				if standing.Rank != uint(i+1) {
+					t.Errorf("position %d rank = %d", i+1, standing.Rank)
+				}
+			}
+		})
+	}
+
+	if got := RankGlobal(totals, NormalizeRaw, 0, nil)[1]; got.Contests != 2 || got.Predictions != 45 {
+		t.Errorf("user 2 contests = %d, predictions = %d, want 2 and 45", got.Contests, got.Predictions)
+	}
+
+	// Unrated user 4 holds the default rating
+	rated := RankGlobal(totals, NormalizeRating, 0, map[uint]float64{1: 1450, 2: 1600, 3: 1520})
+	for i, want := range []uint{2, 3, 4, 1} {
+		if rated[i].UserID != want {
+			t.Errorf("rating position %d = user %d, want user %d", i+1, rated[i].UserID, want)
+		}
+	}
+	if rated[2].Score != DefaultSkillRating {
+		t.Errorf("unrated score = %v, want %v", rated[2].Score, DefaultSkillRating)
+	}
+}
+
+func TestMonthRange(t *testing.T) {
+	from, to, err := MonthRange("2026-12")
+	if err != nil {
+		t.Fatalf("MonthRange() error = %v", err)
+	}
+	if !from.Equal(time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC)) || !to.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("MonthRange() = %v, %v", from, to)
+	}
+	if _, _, err := MonthRange("December"); err == nil {
+		t.Error("MonthRange() accepted an invalid month")
+	}
+}
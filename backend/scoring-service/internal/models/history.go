@@ -0,0 +1,101 @@
+package models
+
+import (
+	"time"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// ScoreHistory is a score with the times of the prediction it was credited for
+type ScoreHistory struct {
+	Score
+	SubmittedAt *time.Time // nil when the prediction no longer exists
+	EventDate   *time.Time
+}
+
+// HistoricalStanding is a participant's total, streak and tie-breaker statistics rebuilt from
+// the scores credited up to some moment
+type HistoricalStanding struct {
+	UserID          uint
+	TotalPoints     float64
+	ExactScores     int64
+	CorrectOutcomes int64
+	Scored          int64
+	Streak          UserStreak
+	leadHours       float64
+	timed           int64
+}
+
+// AvgLeadHours returns how many hours before kickoff the participant's scored predictions were made on average
+func (h *HistoricalStanding) AvgLeadHours() float64 {
+	if h.timed == 0 {
+		return 0
+	}
+	return h.leadHours / float64(h.timed)
+}
+
+// Standing returns the statistics tie-breakers compare. matches is the number of predictions
+// everyone is measured against for fewest missed.
+func (h *HistoricalStanding) Standing(matches int64) scoring.Standing {
+	return scoring.Standing{
+		UserID:          h.UserID,
+		TotalPoints:     h.TotalPoints,
+		ExactScores:     h.ExactScores,
+		CorrectOutcomes: h.CorrectOutcomes,
+		MaxStreak:       h.Streak.MaxStreak,
+		AvgLeadHours:    h.AvgLeadHours(),
+		Missed:          matches - h.Scored,
+	}
+}
+
+// ReplayScores rebuilds each participant's standing from scores given in streak chain order.
+// Points are recomputed from base points and time coefficients with the streak multipliers of
+// the replayed chain, so scores rewritten by later corrections do not change past standings.
+// rulesFor returns the rules version that scored an entry, and void predictions leave streaks
+// untouched. Standings are returned in the order participants first scored.
+func ReplayScores(history []*ScoreHistory, rulesFor func(*ScoreHistory) *scoring.ContestRules) []*HistoricalStanding {
+	correctOutcome := make(map[string]bool)
+	for _, rule := range scoring.CorrectOutcomeRules() {
+		correctOutcome[rule] = true
+	}
+
+	var standings []*HistoricalStanding
+	byUser := make(map[uint]*HistoricalStanding)
+	for _, score := range history {
+		standing, ok := byUser[score.UserID]
+		if !ok {
+			standing = &HistoricalStanding{
+				UserID: score.UserID,
+				Streak: UserStreak{UserID: score.UserID, ContestID: score.ContestID},
+			}
+			byUser[score.UserID] = standing
+			standings = append(standings, standing)
+		}
+
+		rules := rulesFor(score)
+		basePoints := score.GetBasePoints()
+		if score.RuleMatched != RuleVoid {
+			standing.Streak.RecordResult(score.PredictionID, rules.Streak.IsHit(score.RuleMatched, basePoints), rules.Streak)
+		}
+		streakMultiplier := standing.Streak.MultiplierFor(rules.Streak)
+		if !rules.AppliesMultipliers() {
+			streakMultiplier = 1.0
+		}
+		replayed := score.Score
+		replayed.ApplyMultipliers(basePoints, streakMultiplier)
+
+		standing.TotalPoints += replayed.Points
+		standing.Scored++
+		if score.RuleMatched == "exact_score" {
+			standing.ExactScores++
+		}
+		if correctOutcome[score.RuleMatched] {
+			standing.CorrectOutcomes++
+		}
+		if score.SubmittedAt != nil && score.EventDate != nil {
+			standing.leadHours += score.EventDate.Sub(*score.SubmittedAt).Hours()
+			standing.timed++
+		}
+	}
+	return standings
+}
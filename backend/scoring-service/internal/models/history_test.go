@@ -0,0 +1,103 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// defaultRules scores every replayed entry with the default contest rules
+func defaultRules(*ScoreHistory) *scoring.ContestRules {
+	return scoring.ParseRulesOrDefault("")
+}
+
+func TestReplayScores(t *testing.T) {
+	kickoff := time.Date(2026, 5, 10, 18, 0, 0, 0, time.UTC)
+	submitted := kickoff.Add(-4 * time.Hour)
+	scored := func(userID, predictionID uint, rule string, basePoints, streakMultiplier float64) *ScoreHistory {
+		score := Score{UserID: userID, ContestID: 1, PredictionID: predictionID, RuleMatched: rule, TimeCoefficient: 1}
+		score.ApplyMultipliers(basePoints, streakMultiplier)
+		return &ScoreHistory{Score: score, SubmittedAt: &submitted, EventDate: &kickoff}
+	}
+
+	history := []*ScoreHistory{
+		scored(2, 1, "correct_outcome", 1, 1),
+		scored(1, 2, "exact_score", 3, 1),
+		scored(1, 3, "exact_score", 3, 1),
+		scored(1, 4, "exact_score", 3, 1.25), // third hit in a row, credited with the multiplier of the time
+		scored(1, 5, "none", 0, 1),
+	}
+
+	standings := ReplayScores(history, defaultRules)
+	if len(standings) != 2 || standings[0].UserID != 2 || standings[1].UserID != 1 {
+		t.Fatalf("standings = %+v, want users 2 and 1 in order of first score", standings)
+	}
+
+	user := standings[1]
+	if user.TotalPoints != 9.75 {
+		t.Errorf("TotalPoints = %v, want 9.75", user.TotalPoints)
+	}
+	if user.ExactScores != 3 || user.CorrectOutcomes != 3 || user.Scored != 4 {
+		t.Errorf("exact = %d, outcomes = %d, scored = %d, want 3, 3, 4", user.ExactScores, user.CorrectOutcomes, user.Scored)
+	}
+	if user.Streak.CurrentStreak != 0 || user.Streak.MaxStreak != 3 {
+		t.Errorf("streak = %d (max %d), want 0 (max 3)", user.Streak.CurrentStreak, user.Streak.MaxStreak)
+	}
+	if got := user.AvgLeadHours(); got != 4 {
+		t.Errorf("AvgLeadHours() = %v, want 4", got)
+	}
+
+	standing := user.Standing(4)
+	if standing.Missed != 0 || standing.MaxStreak != 3 {
+		t.Errorf("Standing() = %+v, want no misses and max streak 3", standing)
+	}
+	if missed := standings[0].Standing(4).Missed; missed != 3 {
+		t.Errorf("Missed = %d, want 3", missed)
+	}
+}
+
+func TestReplayScoresSkipsVoid(t *testing.T) {
+	scored := func(predictionID uint, rule string, basePoints float64) *ScoreHistory {
+		score := Score{UserID: 1, ContestID: 1, PredictionID: predictionID, RuleMatched: rule, TimeCoefficient: 1}
+		score.ApplyMultipliers(basePoints, 1)
+		return &ScoreHistory{Score: score}
+	}
+
+	// A voided props prediction between two hits neither breaks nor extends the streak
+	standings := ReplayScores([]*ScoreHistory{
+		scored(1, "exact_score", 3),
+		scored(2, RuleVoid, 0),
+		scored(3, "exact_score", 3),
+	}, defaultRules)
+	if streak := standings[0].Streak; streak.CurrentStreak != 2 || streak.MaxStreak != 2 {
+		t.Errorf("streak = %d (max %d), want 2 (max 2)", streak.CurrentStreak, streak.MaxStreak)
+	}
+}
+
+func TestReplayScoresRecomputesRewrittenMultipliers(t *testing.T) {
+	scored := func(predictionID uint, basePoints, streakMultiplier float64) *ScoreHistory {
+		score := Score{UserID: 1, ContestID: 1, PredictionID: predictionID, RuleMatched: "exact_score", TimeCoefficient: 1.25}
+		score.ApplyMultipliers(basePoints, streakMultiplier)
+		return &ScoreHistory{Score: score}
+	}
+
+	// The third hit was later rewritten with the multiplier of a longer chain, e.g. after a
+	// late score was inserted before it; the past standing keeps the multiplier of its own chain
+	standings := ReplayScores([]*ScoreHistory{
+		scored(1, 4, 1),
+		scored(2, 4, 1),
+		scored(3, 4, 1.5),
+	}, defaultRules)
+	if got := standings[0].TotalPoints; got != 16.25 {
+		t.Errorf("TotalPoints = %v, want 16.25", got)
+	}
+
+	fixed := func(*ScoreHistory) *scoring.ContestRules {
+		return scoring.ParseRulesOrDefault(`{"type":"survivor"}`)
+	}
+	standings = ReplayScores([]*ScoreHistory{scored(1, 4, 1), scored(2, 4, 1), scored(3, 4, 1.25)}, fixed)
+	if got := standings[0].TotalPoints; got != 15 {
+		t.Errorf("TotalPoints with fixed points = %v, want 15", got)
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
@@ -37,10 +38,11 @@ func (l *Leaderboard) ValidateUserID() error {
 	return nil
 }
 
-// ValidateTotalPoints checks if the total points value is valid
+// ValidateTotalPoints checks if the total points value is valid.
+// Totals may go negative in risky contests.
 func (l *Leaderboard) ValidateTotalPoints() error {
-	if l.TotalPoints < 0 {
-		return errors.New("total points cannot be negative")
+	if math.IsNaN(l.TotalPoints) || math.IsInf(l.TotalPoints, 0) {
+		return errors.New("total points must be a finite number")
 	}
 	return nil
 }
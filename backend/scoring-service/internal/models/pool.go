@@ -0,0 +1,69 @@
+package models
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PoolDraw records the settlement of a parimutuel totalizator draw. One per contest.
+type PoolDraw struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	ContestID   uint      `gorm:"not null;uniqueIndex:idx_pool_draw_contest" json:"contest_id"`
+	Series      string    `gorm:"size:100;not null;index:idx_pool_draw_series" json:"series"`
+	PoolAmount  float64   `gorm:"not null;default:0" json:"pool_amount"`
+	JackpotIn   float64   `gorm:"not null;default:0" json:"jackpot_in"`   // Jackpot carried into this draw
+	RolloverOut float64   `gorm:"not null;default:0" json:"rollover_out"` // Unclaimed money carried to the next draw
+	SettledAt   time.Time `gorm:"not null" json:"settled_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a draw settlement
+func (d *PoolDraw) BeforeCreate(tx *gorm.DB) error {
+	if d.ContestID == 0 {
+		return errors.New("contest ID cannot be empty")
+	}
+	if d.Series == "" {
+		return errors.New("series cannot be empty")
+	}
+	if d.SettledAt.IsZero() {
+		d.SettledAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// ErrRolloverClaimed is returned when a corrected draw's rollover would change after a later
+// draw of its series already carried the old rollover into its pot
+var ErrRolloverClaimed = errors.New("a later draw of the series already carried this draw's rollover")
+
+// ResettleJackpot returns the series jackpot after a correction changed the draw's rollover
+// to rolloverOut. Once a later draw carried the old rollover the money is no longer waiting
+// in the jackpot, so a changed rollover is refused; the jackpot never drops below zero.
+func (d *PoolDraw) ResettleJackpot(jackpot, rolloverOut float64, laterDraw bool) (float64, error) {
+	if rolloverOut == d.RolloverOut {
+		return jackpot, nil
+	}
+	if laterDraw {
+		return 0, ErrRolloverClaimed
+	}
+	return math.Max(jackpot+rolloverOut-d.RolloverOut, 0), nil
+}
+
+// PoolPayout is a participant's winnings from a settled draw
+type PoolPayout struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ContestID uint      `gorm:"not null;uniqueIndex:idx_pool_payout_contest_user" json:"contest_id"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_pool_payout_contest_user" json:"user_id"`
+	Correct   int       `gorm:"not null;default:0" json:"correct"`
+	Amount    float64   `gorm:"not null;default:0" json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PoolJackpot is the money waiting to be won in the next draw of a series
+type PoolJackpot struct {
+	Series    string    `gorm:"primaryKey;size:100" json:"series"`
+	Amount    float64   `gorm:"not null;default:0" json:"amount"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
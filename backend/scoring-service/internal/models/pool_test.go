@@ -0,0 +1,36 @@
+package models
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPoolDrawResettleJackpot(t *testing.T) {
+	draw := &PoolDraw{ContestID: 1, Series: "weekly", RolloverOut: 400}
+
+	tests := []struct {
+		name      string
+		jackpot   float64
+		rollover  float64
+		laterDraw bool
+		want      float64
+		wantErr   error
+	}{
+		{"correction frees more money", 400, 550, false, 550, nil},
+		{"correction finds a winner", 400, 0, false, 0, nil},
+		{"unchanged rollover after a later draw", 120, 400, true, 120, nil},
+		{"correct draw A after draw B consumed its rollover", 0, 0, true, 0, ErrRolloverClaimed},
+		{"jackpot never drops below zero", 100, 0, false, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := draw.ResettleJackpot(tt.jackpot, tt.rollover, tt.laterDraw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ResettleJackpot() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResettleJackpot() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
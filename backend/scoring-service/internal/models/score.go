@@ -2,20 +2,31 @@ package models
 
 import (
 	"errors"
+	"math"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// RuleVoid marks props predictions whose every pick was voided or pushed, e.g. on players who
+// did not play; they score zero without counting as a miss
+const RuleVoid = "void"
+
 // Score represents a user's score for a specific prediction in a contest
 type Score struct {
-	ID              uint      `gorm:"primaryKey" json:"id"`
-	UserID          uint      `gorm:"not null;uniqueIndex:idx_user_contest_prediction" json:"user_id"`
-	ContestID       uint      `gorm:"not null;uniqueIndex:idx_user_contest_prediction;index:idx_contest_scores" json:"contest_id"`
-	PredictionID    uint      `gorm:"not null;uniqueIndex:idx_user_contest_prediction" json:"prediction_id"`
-	Points          float64   `gorm:"not null;default:0" json:"points"`
-	TimeCoefficient float64   `gorm:"not null;default:1.0" json:"time_coefficient"`
-	ScoredAt        time.Time `gorm:"not null" json:"scored_at"`
+	ID               uint      `gorm:"primaryKey" json:"id"`
+	UserID           uint      `gorm:"not null;index:idx_contest_user_scores" json:"user_id"`
+	ContestID        uint      `gorm:"not null;index:idx_contest_user_scores;index:idx_contest_scores" json:"contest_id"`
+	PredictionID     uint      `gorm:"not null;uniqueIndex:idx_score_prediction" json:"prediction_id"` // One score per prediction
+	Points           float64   `gorm:"not null;default:0" json:"points"`
+	BasePoints       float64   `gorm:"not null;default:0" json:"base_points"`         // Points before multipliers
+	StreakMultiplier float64   `gorm:"not null;default:1.0" json:"streak_multiplier"` // Streak multiplier applied at scoring time
+	TimeCoefficient  float64   `gorm:"not null;default:1.0" json:"time_coefficient"`
+	TimeTier         string    `gorm:"size:50" json:"time_tier"`     // Time coefficient tier, e.g. "Early Bird"
+	RuleMatched      string    `gorm:"size:50" json:"rule_matched"`  // Scoring rule that produced the base points
+	RulesVersion     string    `gorm:"size:64" json:"rules_version"` // Contest rules version and fingerprint used
+	Details          string    `gorm:"type:text" json:"details"`     // Calculator details as JSON
+	ScoredAt         time.Time `gorm:"not null" json:"scored_at"`
 	gorm.Model
 }
 
@@ -43,10 +54,11 @@ func (s *Score) ValidatePredictionID() error {
 	return nil
 }
 
-// ValidatePoints checks if the points value is valid
+// ValidatePoints checks if the points value is valid.
+// Negative points are allowed because risky contests subtract points for wrong picks.
 func (s *Score) ValidatePoints() error {
-	if s.Points < 0 {
-		return errors.New("points cannot be negative")
+	if math.IsNaN(s.Points) || math.IsInf(s.Points, 0) {
+		return errors.New("points must be a finite number")
 	}
 	return nil
 }
@@ -100,3 +112,30 @@ func (s *Score) BeforeUpdate(tx *gorm.DB) error {
 func (s *Score) IsPositive() bool {
 	return s.Points > 0
 }
+
+// GetBasePoints returns the points before multipliers.
+// Scores written before base points were stored fall back to dividing out the known coefficients.
+func (s *Score) GetBasePoints() float64 {
+	if s.BasePoints != 0 || s.Points == 0 {
+		return s.BasePoints
+	}
+	divisor := s.TimeCoefficient
+	if s.StreakMultiplier > 0 {
+		divisor *= s.StreakMultiplier
+	}
+	if divisor <= 0 {
+		return s.Points
+	}
+	return s.Points / divisor
+}
+
+// ApplyMultipliers recomputes final points from base points and the given streak multiplier
+func (s *Score) ApplyMultipliers(basePoints, streakMultiplier float64) {
+	s.BasePoints = basePoints
+	s.StreakMultiplier = streakMultiplier
+	timeCoefficient := s.TimeCoefficient
+	if timeCoefficient <= 0 {
+		timeCoefficient = 1.0
+	}
+	s.Points = basePoints * streakMultiplier * timeCoefficient
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScoreAudit records how an event result correction changed a user's contest total
+type ScoreAudit struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	EventID        uint      `gorm:"not null;index:idx_score_audit_event" json:"event_id"`
+	ContestID      uint      `gorm:"not null;index:idx_score_audit_contest_user" json:"contest_id"`
+	UserID         uint      `gorm:"not null;index:idx_score_audit_contest_user" json:"user_id"`
+	PreviousPoints float64   `gorm:"not null;default:0" json:"previous_points"`
+	NewPoints      float64   `gorm:"not null;default:0" json:"new_points"`
+	Reason         string    `gorm:"type:text" json:"reason"`
+	PreviousResult string    `gorm:"type:text" json:"previous_result"`
+	NewResult      string    `gorm:"type:text" json:"new_result"`
+	CorrectedBy    uint      `json:"corrected_by"` // User who triggered the correction, 0 for system
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ValidateEventID checks if the event ID is valid
+func (a *ScoreAudit) ValidateEventID() error {
+	if a.EventID == 0 {
+		return errors.New("event ID cannot be empty")
+	}
+	return nil
+}
+
+// BeforeCreate is a GORM hook that runs before creating an audit entry
+func (a *ScoreAudit) BeforeCreate(tx *gorm.DB) error {
+	if err := a.ValidateEventID(); err != nil {
+		return err
+	}
+	if a.ContestID == 0 || a.UserID == 0 {
+		return errors.New("contest ID and user ID cannot be empty")
+	}
+	return nil
+}
+
+// Delta returns the change in points caused by the correction
+func (a *ScoreAudit) Delta() float64 {
+	return a.NewPoints - a.PreviousPoints
+}
@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestScoreGetBasePoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		score    Score
+		expected float64
+	}{
+		{
+			name:     "stored base points are returned as is",
+			score:    Score{Points: 15, BasePoints: 5, StreakMultiplier: 1.5, TimeCoefficient: 2.0},
+			expected: 5,
+		},
+		{
+			name:     "legacy score divides out coefficients",
+			score:    Score{Points: 7.5, StreakMultiplier: 1.0, TimeCoefficient: 1.5},
+			expected: 5,
+		},
+		{
+			name:     "zero points stay zero",
+			score:    Score{Points: 0, StreakMultiplier: 1.0, TimeCoefficient: 2.0},
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.score.GetBasePoints(); result != tt.expected {
+				t.Errorf("GetBasePoints() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestScoreApplyMultipliers(t *testing.T) {
+	score := Score{TimeCoefficient: 1.25}
+	score.ApplyMultipliers(4, 1.5)
+
+	if score.Points != 7.5 {
+		t.Errorf("Points = %v, want 7.5", score.Points)
+	}
+	if score.BasePoints != 4 || score.StreakMultiplier != 1.5 {
+		t.Errorf("breakdown not stored: base=%v, streak=%v", score.BasePoints, score.StreakMultiplier)
+	}
+}
+
+func TestScoreValidatePointsAllowsNegative(t *testing.T) {
+	score := Score{Points: -3}
+	if err := score.ValidatePoints(); err != nil {
+		t.Errorf("ValidatePoints() unexpected error for risky penalty: %v", err)
+	}
+}
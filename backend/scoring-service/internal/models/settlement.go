@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// SettlementEvent is a read-only view of an event row owned by the prediction service
+type SettlementEvent struct {
+	ID         uint      `json:"id"`
+	Status     string    `json:"status"`
+	EventDate  time.Time `json:"event_date"`
+	HomeTeam   string    `json:"home_team"`
+	AwayTeam   string    `json:"away_team"`
+	ResultData string    `json:"result_data"`
+}
+
+// IsCompleted checks if the event has finished and can be settled
+func (e *SettlementEvent) IsCompleted() bool {
+	return e.Status == "completed"
+}
+
+// SettlementPrediction is a read-only view of a prediction row owned by the prediction service
+type SettlementPrediction struct {
+	ID             uint      `json:"id"`
+	ContestID      uint      `json:"contest_id"`
+	UserID         uint      `json:"user_id"`
+	EventID        uint      `json:"event_id"`
+	PredictionData string    `json:"prediction_data"`
+	SubmittedAt    time.Time `json:"submitted_at"`
+}
+
+// SettlementPropType is a read-only view of a prop type row owned by the prediction service
+type SettlementPropType struct {
+	ID            uint    `json:"id"`
+	SportType     string  `json:"sport_type"`
+	Slug          string  `json:"slug"`
+	PointsCorrect float64 `json:"points_correct"`
+	Evaluation    string  `json:"evaluation"`
+}
+
+// SettlementRulesVersion is a read-only view of a contest rules version owned by the contest service
+type SettlementRulesVersion struct {
+	Version       uint      `json:"version"`
+	Rules         string    `json:"rules"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
+// Label identifies the version and fingerprints its rules for a score's breakdown
+func (v *SettlementRulesVersion) Label() string {
+	return fmt.Sprintf("v%d:%s", v.Version, scoring.RulesVersion(v.Rules))
+}
+
+// SettlementContestRules is a contest's rules history. Rules are the contest's current
+// rules, which score its predictions when no versions were recorded.
+type SettlementContestRules struct {
+	Rules     string                    `json:"rules"`
+	SportType string                    `json:"sport_type"`
+	Basis     string                    `json:"rules_basis"`
+	Versions  []*SettlementRulesVersion `json:"versions"` // oldest version first
+}
+
+// VersionFor returns the rules version that scores a prediction: the latest one in force
+// when it was submitted or, when the contest's rules basis is kickoff, when the match
+// started. Earlier predictions get the first version, and nil means no versions exist.
+func (c *SettlementContestRules) VersionFor(submittedAt, eventDate time.Time) *SettlementRulesVersion {
+	if len(c.Versions) == 0 {
+		return nil
+	}
+	at := submittedAt
+	if c.Basis == scoring.RulesBasisKickoff {
+		at = eventDate
+	}
+
+	inForce := c.Versions[0]
+	for _, version := range c.Versions[1:] {
+		if version.EffectiveFrom.After(at) {
+			break
+		}
+		inForce = version
+	}
+	return inForce
+}
+
+// RulesFor returns the rules that score a prediction and the label recorded with its score:
+// the version in force for it, or the contest's current rules when no versions were recorded
+func (c *SettlementContestRules) RulesFor(submittedAt, eventDate time.Time) (string, string) {
+	if version := c.VersionFor(submittedAt, eventDate); version != nil {
+		return version.Rules, version.Label()
+	}
+	return c.Rules, scoring.RulesVersion(c.Rules)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+func TestSettlementContestRulesVersionFor(t *testing.T) {
+	created := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	changed := created.Add(72 * time.Hour)
+	kickoff := changed.Add(24 * time.Hour)
+
+	versions := []*SettlementRulesVersion{
+		{Version: 1, Rules: `{"type":"standard"}`, EffectiveFrom: created},
+		{Version: 2, Rules: `{"type":"standard","scoring":{"exact_score":5}}`, EffectiveFrom: changed},
+	}
+
+	tests := []struct {
+		name      string
+		basis     string
+		submitted time.Time
+		want      uint
+	}{
+		{"submitted under version 1", scoring.RulesBasisSubmission, changed.Add(-time.Minute), 1},
+		{"submitted as version 2 took effect", scoring.RulesBasisSubmission, changed, 2},
+		{"submitted before the contest existed", "", created.Add(-time.Hour), 1},
+		{"kickoff after the change", scoring.RulesBasisKickoff, changed.Add(-time.Minute), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := &SettlementContestRules{Basis: tt.basis, Versions: versions}
+			version := rules.VersionFor(tt.submitted, kickoff)
+			if version == nil || version.Version != tt.want {
+				t.Fatalf("VersionFor() = %+v, want version %d", version, tt.want)
+			}
+		})
+	}
+
+	unversioned := &SettlementContestRules{Rules: `{"type":"standard"}`}
+	if version := unversioned.VersionFor(created, kickoff); version != nil {
+		t.Errorf("VersionFor() without versions = %+v, want nil", version)
+	}
+}
+
+func TestSettlementRulesVersionLabel(t *testing.T) {
+	version := &SettlementRulesVersion{Version: 3, Rules: `{"type":"standard"}`}
+	want := "v3:" + scoring.RulesVersion(version.Rules)
+	if got := version.Label(); got != want {
+		t.Errorf("Label() = %q, want %q", got, want)
+	}
+}
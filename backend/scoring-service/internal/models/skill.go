@@ -0,0 +1,129 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Skill rating parameters. New predictors move faster until their rating has settled.
+const (
+	DefaultSkillRating = 1500.0
+	provisionalEvents  = 20   // Events rated with the provisional K-factor
+	provisionalK       = 40.0 // K-factor while provisional
+	establishedK       = 20.0 // K-factor afterwards
+)
+
+// SkillRating is a predictor's Elo-style rating across all contests
+type SkillRating struct {
+	UserID    uint      `gorm:"primaryKey;autoIncrement:false" json:"user_id"`
+	Rating    float64   `gorm:"not null;default:1500" json:"rating"`
+	Events    uint      `gorm:"not null;default:0" json:"events"` // Settled events the rating was updated for
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// K returns how far one event can move the rating
+func (r *SkillRating) K() float64 {
+	if r.Events < provisionalEvents {
+		return provisionalK
+	}
+	return establishedK
+}
+
+// SkillRatingChange records how a settled event moved a predictor's rating
+type SkillRatingChange struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	UserID         uint      `gorm:"not null;uniqueIndex:idx_skill_rating_change_event;index:idx_skill_rating_change_user" json:"user_id"`
+	EventID        uint      `gorm:"not null;uniqueIndex:idx_skill_rating_change_event" json:"event_id"`
+	PreviousRating float64   `gorm:"not null" json:"previous_rating"`
+	Rating         float64   `gorm:"not null" json:"rating"`
+	Opponents      uint      `gorm:"not null;default:0" json:"opponents"` // Predictors compared with on the event
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// FieldResult is the base points one prediction earned on an event within a contest
+type FieldResult struct {
+	UserID    uint
+	ContestID uint
+	Points    float64
+}
+
+// RateEvent compares each prediction on an event with the rest of its contest's field: a win
+// against a rival scores 1, a draw 0.5, and the expected score follows the Elo curve of the two
+// ratings. Beating a field that mostly missed therefore gains more than matching a field that
+// all got it right. Predictors in several contests get the average of their contests' changes.
+// ratings is updated in place and users missing from it start at the default rating.
+func RateEvent(eventID uint, results []FieldResult, ratings map[uint]*SkillRating) []*SkillRatingChange {
+	fields := make(map[uint][]FieldResult)
+	var contests []uint
+	for _, result := range results {
+		if _, ok := fields[result.ContestID]; !ok {
+			contests = append(contests, result.ContestID)
+		}
+		fields[result.ContestID] = append(fields[result.ContestID], result)
+	}
+
+	rating := func(userID uint) *SkillRating {
+		if ratings[userID] == nil {
+			ratings[userID] = &SkillRating{UserID: userID, Rating: DefaultSkillRating}
+		}
+		return ratings[userID]
+	}
+
+	// Every change is computed from the ratings before the event
+	deltas := make(map[uint]float64)
+	fieldCounts := make(map[uint]int)
+	opponents := make(map[uint]uint)
+	var users []uint
+	for _, contestID := range contests {
+		field := fields[contestID]
+		if len(field) < 2 {
+			continue
+		}
+		for _, own := range field {
+			player := rating(own.UserID)
+			var surplus float64
+			for _, rival := range field {
+				if rival.UserID == own.UserID {
+					continue
+				}
+				expected := 1 / (1 + math.Pow(10, (rating(rival.UserID).Rating-player.Rating)/400))
+				surplus += outcome(own.Points, rival.Points) - expected
+			}
+
+			if fieldCounts[own.UserID] == 0 {
+				users = append(users, own.UserID)
+			}
+			deltas[own.UserID] += player.K() * surplus / float64(len(field)-1)
+			fieldCounts[own.UserID]++
+			opponents[own.UserID] += uint(len(field) - 1)
+		}
+	}
+
+	changes := make([]*SkillRatingChange, len(users))
+	for i, userID := range users {
+		previous := ratings[userID].Rating
+		changes[i] = &SkillRatingChange{
+			UserID:         userID,
+			EventID:        eventID,
+			PreviousRating: previous,
+			Rating:         previous + deltas[userID]/float64(fieldCounts[userID]),
+			Opponents:      opponents[userID],
+		}
+	}
+	for _, change := range changes {
+		ratings[change.UserID].Rating = change.Rating
+		ratings[change.UserID].Events++
+	}
+	return changes
+}
+
+// outcome scores a head-to-head between two predictions on the same event
+func outcome(points, rivalPoints float64) float64 {
+	switch {
+	case points > rivalPoints:
+		return 1
+	case points < rivalPoints:
+		return 0
+	}
+	return 0.5
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRateEvent(t *testing.T) {
+	ratings := map[uint]*SkillRating{
+		1: {UserID: 1, Rating: 1500, Events: 30},
+		2: {UserID: 2, Rating: 1700, Events: 30},
+	}
+	// Contest 1: user 1 beats the stronger user 2, user 3 is new. Contest 2: user 1 alone.
+	results := []FieldResult{
+		{UserID: 1, ContestID: 1, Points: 3},
+		{UserID: 2, ContestID: 1, Points: 0},
+		{UserID: 3, ContestID: 1, Points: 3},
+		{UserID: 1, ContestID: 2, Points: 3},
+	}
+
+	changes := RateEvent(7, results, ratings)
+	if len(changes) != 3 {
+		t.Fatalf("got %d changes, want 3", len(changes))
+	}
+
+	expected := func(rating, rival float64) float64 {
+		return 1 / (1 + math.Pow(10, (rival-rating)/400))
+	}
+	want := map[uint]float64{
+		1: 1500 + establishedK*((1-expected(1500, 1700))+(0.5-0.5))/2,
+		2: 1700 + establishedK*((0-expected(1700, 1500))+(0-expected(1700, 1500)))/2,
+		3: 1500 + provisionalK*((0.5-0.5)+(1-expected(1500, 1700)))/2,
+	}
+	for _, change := range changes {
+		if change.EventID != 7 || change.Opponents != 2 {
+			t.Errorf("user %d change = %+v, want event 7 with 2 opponents", change.UserID, change)
+		}
+		if math.Abs(change.Rating-want[change.UserID]) > 1e-9 {
+			t.Errorf("user %d rating = %v, want %v", change.UserID, change.Rating, want[change.UserID])
+		}
+		if ratings[change.UserID].Rating != change.Rating {
+			t.Errorf("user %d rating not updated in place", change.UserID)
+		}
+	}
+
+	// The upset moves both ratings
+	if gain, loss := ratings[1].Rating-1500, 1700-ratings[2].Rating; gain <= 0 || loss <= 0 {
+		t.Errorf("gain = %v, loss = %v, want both positive", gain, loss)
+	}
+	if ratings[3].Events != 1 || ratings[1].Events != 31 {
+		t.Errorf("events = %d and %d, want 1 and 31", ratings[3].Events, ratings[1].Events)
+	}
+
+	if changes := RateEvent(8, []FieldResult{{UserID: 1, ContestID: 2, Points: 3}}, ratings); len(changes) != 0 {
+		t.Errorf("a field of one produced %d changes", len(changes))
+	}
+}
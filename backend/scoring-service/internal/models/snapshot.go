@@ -0,0 +1,59 @@
+package models
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LeaderboardRound is a snapshot of a contest's standings taken at the end of a round or matchday
+type LeaderboardRound struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	ContestID uint      `gorm:"not null;uniqueIndex:idx_leaderboard_round;uniqueIndex:idx_leaderboard_round_label" json:"contest_id"`
+	Round     uint      `gorm:"not null;uniqueIndex:idx_leaderboard_round" json:"round"`
+	Label     string    `gorm:"size:100;not null;uniqueIndex:idx_leaderboard_round_label" json:"label"` // Matchday date or the caller's label
+	TakenAt   time.Time `gorm:"not null" json:"taken_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate is a GORM hook that runs before creating a round
+func (r *LeaderboardRound) BeforeCreate(tx *gorm.DB) error {
+	if r.ContestID == 0 {
+		return errors.New("contest ID cannot be empty")
+	}
+	if r.Round == 0 {
+		return errors.New("round cannot be zero")
+	}
+	if r.TakenAt.IsZero() {
+		r.TakenAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// LeaderboardSnapshot is a participant's rank and total when a round's snapshot was taken
+type LeaderboardSnapshot struct {
+	ID          uint    `gorm:"primaryKey" json:"id"`
+	ContestID   uint    `gorm:"not null;uniqueIndex:idx_leaderboard_snapshot_user" json:"contest_id"`
+	Round       uint    `gorm:"not null;uniqueIndex:idx_leaderboard_snapshot_user" json:"round"`
+	UserID      uint    `gorm:"not null;uniqueIndex:idx_leaderboard_snapshot_user" json:"user_id"`
+	Rank        uint    `gorm:"not null;default:0" json:"rank"`
+	TotalPoints float64 `gorm:"not null;default:0" json:"total_points"`
+}
+
+// RankChange returns how many places a participant climbed since an earlier snapshot, negative
+// when they dropped. Participants without an earlier snapshot have not moved.
+func (s *LeaderboardSnapshot) RankChange(rank uint) int32 {
+	if s == nil || s.Rank == 0 || rank == 0 {
+		return 0
+	}
+	return int32(s.Rank) - int32(rank)
+}
+
+// PointsSince returns the points a participant earned since an earlier snapshot
+func (s *LeaderboardSnapshot) PointsSince(totalPoints float64) float64 {
+	if s == nil {
+		return totalPoints
+	}
+	return totalPoints - s.TotalPoints
+}
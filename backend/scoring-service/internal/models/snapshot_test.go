@@ -0,0 +1,26 @@
+package models
+
+import "testing"
+
+func TestLeaderboardSnapshotMovement(t *testing.T) {
+	previous := &LeaderboardSnapshot{Rank: 5, TotalPoints: 12}
+
+	if got := previous.RankChange(2); got != 3 {
+		t.Errorf("RankChange(2) = %d, want 3", got)
+	}
+	if got := previous.RankChange(7); got != -2 {
+		t.Errorf("RankChange(7) = %d, want -2", got)
+	}
+	if got := previous.PointsSince(19.5); got != 7.5 {
+		t.Errorf("PointsSince(19.5) = %v, want 7.5", got)
+	}
+
+	// Participants who joined after the previous round have not moved and earned everything this round
+	var joined *LeaderboardSnapshot
+	if got := joined.RankChange(4); got != 0 {
+		t.Errorf("RankChange() without previous round = %d, want 0", got)
+	}
+	if got := joined.PointsSince(6); got != 6 {
+		t.Errorf("PointsSince() without previous round = %v, want 6", got)
+	}
+}
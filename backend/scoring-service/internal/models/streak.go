@@ -3,6 +3,7 @@ package models
 import (
 	"errors"
 
+	"github.com/sports-prediction-contests/shared/scoring"
 	"gorm.io/gorm"
 )
 
@@ -15,6 +16,7 @@ type UserStreak struct {
 	MaxStreak             uint  `gorm:"not null;default:0" json:"max_streak"`
 	LastPredictionID      *uint `json:"last_prediction_id"`
 	LastPredictionCorrect *bool `json:"last_prediction_correct"`
+	FreezesUsed           uint  `gorm:"not null;default:0" json:"freezes_used"`
 }
 
 // ValidateUserID checks if the user ID is valid
@@ -50,19 +52,29 @@ func (s *UserStreak) BeforeUpdate(tx *gorm.DB) error {
 	return nil
 }
 
-// GetMultiplier returns the point multiplier based on current streak
+// GetMultiplier returns the point multiplier based on current streak and the default ladder
 func (s *UserStreak) GetMultiplier() float64 {
+	return s.MultiplierFor(nil)
+}
+
+// MultiplierFor returns the point multiplier of the current streak under a contest's streak rules
+func (s *UserStreak) MultiplierFor(rules *scoring.StreakRules) float64 {
+	return rules.Multiplier(s.CurrentStreak)
+}
+
+// RecordResult extends the streak on a hit and resets it on a miss, unless the contest offers
+// streak freezes and some are left, in which case the miss uses one and the streak holds
+func (s *UserStreak) RecordResult(predictionID uint, hit bool, rules *scoring.StreakRules) {
 	switch {
-	case s.CurrentStreak >= 10:
-		return 2.0
-	case s.CurrentStreak >= 7:
-		return 1.75
-	case s.CurrentStreak >= 5:
-		return 1.5
-	case s.CurrentStreak >= 3:
-		return 1.25
+	case hit:
+		s.IncrementStreak(predictionID)
+	case s.CurrentStreak > 0 && s.FreezesUsed < rules.FreezeCount():
+		s.FreezesUsed++
+		s.LastPredictionID = &predictionID
+		correct := false
+		s.LastPredictionCorrect = &correct
 	default:
-		return 1.0
+		s.ResetStreak(predictionID)
 	}
 }
 
@@ -84,3 +96,12 @@ func (s *UserStreak) ResetStreak(predictionID uint) {
 	correct := false
 	s.LastPredictionCorrect = &correct
 }
+
+// Clear wipes the streak state so it can be rebuilt by replaying scores in order
+func (s *UserStreak) Clear() {
+	s.CurrentStreak = 0
+	s.MaxStreak = 0
+	s.LastPredictionID = nil
+	s.LastPredictionCorrect = nil
+	s.FreezesUsed = 0
+}
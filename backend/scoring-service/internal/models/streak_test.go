@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+func TestUserStreakReplay(t *testing.T) {
+	streak := UserStreak{CurrentStreak: 4, MaxStreak: 9}
+	streak.Clear()
+
+	if streak.CurrentStreak != 0 || streak.MaxStreak != 0 || streak.LastPredictionID != nil {
+		t.Fatalf("Clear() left state behind: %+v", streak)
+	}
+
+	// Replaying correct, correct, wrong, correct must not depend on earlier history
+	outcomes := []bool{true, true, false, true}
+	for i, correct := range outcomes {
+		if correct {
+			streak.IncrementStreak(uint(i + 1))
+		} else {
+			streak.ResetStreak(uint(i + 1))
+		}
+	}
+
+	if streak.CurrentStreak != 1 {
+		t.Errorf("CurrentStreak = %d, want 1", streak.CurrentStreak)
+	}
+	if streak.MaxStreak != 2 {
+		t.Errorf("MaxStreak = %d, want 2", streak.MaxStreak)
+	}
+	if streak.LastPredictionID == nil || *streak.LastPredictionID != 4 {
+		t.Errorf("LastPredictionID = %v, want 4", streak.LastPredictionID)
+	}
+}
+
+func TestUserStreakFreezes(t *testing.T) {
+	rules := &scoring.StreakRules{Freezes: 1, Ladder: []scoring.StreakStep{{Length: 2, Multiplier: 1.5}}}
+	var streak UserStreak
+
+	streak.RecordResult(1, true, rules)
+	streak.RecordResult(2, true, rules)
+	streak.RecordResult(3, false, rules) // uses the freeze
+	if streak.CurrentStreak != 2 || streak.FreezesUsed != 1 {
+		t.Fatalf("after frozen miss: streak %d, freezes used %d, want 2 and 1", streak.CurrentStreak, streak.FreezesUsed)
+	}
+	if got := streak.MultiplierFor(rules); got != 1.5 {
+		t.Errorf("MultiplierFor() = %v, want 1.5", got)
+	}
+
+	streak.RecordResult(4, false, rules) // no freezes left
+	if streak.CurrentStreak != 0 {
+		t.Errorf("CurrentStreak = %d, want 0", streak.CurrentStreak)
+	}
+	if streak.MaxStreak != 2 {
+		t.Errorf("MaxStreak = %d, want 2", streak.MaxStreak)
+	}
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// AchievementRepositoryInterface defines the contract for achievement repository
+type AchievementRepositoryInterface interface {
+	GetProgress(ctx context.Context, userID uint) (models.AchievementProgress, error)
+	ListByUser(ctx context.Context, userID uint) ([]*models.UserAchievement, error)
+	Unlock(ctx context.Context, achievement *models.UserAchievement) (bool, error)
+}
+
+// AchievementRepository implements AchievementRepositoryInterface
+type AchievementRepository struct {
+	db *gorm.DB
+}
+
+// NewAchievementRepository creates a new achievement repository instance
+func NewAchievementRepository(db *gorm.DB) AchievementRepositoryInterface {
+	return &AchievementRepository{db: db}
+}
+
+// GetProgress counts a user's scored predictions, exact scores, best streak, contest wins,
+// perfect matchdays and challenge wins across all contests
+func (r *AchievementRepository) GetProgress(ctx context.Context, userID uint) (models.AchievementProgress, error) {
+	var progress models.AchievementProgress
+	db := r.db.WithContext(ctx)
+
+	var scores struct {
+		Predictions int64
+		ExactScores int64
+	}
+	if err := db.Table("scores").
+		Select("COUNT(*) as predictions, COALESCE(SUM(CASE WHEN rule_matched = 'exact_score' THEN 1 ELSE 0 END), 0) as exact_scores").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Scan(&scores).Error; err != nil {
+		return progress, err
+	}
+	progress.Predictions = scores.Predictions
+	progress.ExactScores = scores.ExactScores
+
+	if err := db.Table("user_streaks").
+		Select("COALESCE(MAX(max_streak), 0)").
+		Where("user_id = ? AND deleted_at IS NULL", userID).
+		Scan(&progress.MaxStreak).Error; err != nil {
+		return progress, err
+	}
+
+	// A contest is won with the user ranked first once it has ended, or once every one of its
+	// matches is completed and scored, which usually happens before its end date
+	if err := db.Table("leaderboards lb").
+		Joins("JOIN contests c ON c.id = lb.contest_id").
+		Where("lb.user_id = ? AND lb.rank = 1 AND c.status <> ? AND c.deleted_at IS NULL", userID, "cancelled").
+		Where(`(c.end_date < NOW() OR c.status = ? OR (
+			EXISTS (SELECT 1 FROM contest_events ce WHERE ce.contest_id = c.id)
+			AND NOT EXISTS (
+				SELECT 1 FROM contest_events ce LEFT JOIN events e ON e.id = ce.event_id AND e.deleted_at IS NULL
+				WHERE ce.contest_id = c.id AND (e.id IS NULL OR e.status <> ?)
+			)
+			AND NOT EXISTS (
+				SELECT 1 FROM predictions p WHERE p.contest_id = c.id AND p.status = ? AND p.deleted_at IS NULL
+			)
+		))`, "completed", "completed", "pending").
+		Count(&progress.ContestsWon).Error; err != nil {
+		return progress, err
+	}
+
+	// Unscored predictions have no points, so a matchday is only perfect once all of it is settled
+	rounds := db.Table("predictions p").
+		Select("p.contest_id, DATE(e.event_date) as matchday").
+		Joins("JOIN events e ON e.id = p.event_id").
+		Joins("LEFT JOIN scores s ON s.prediction_id = p.id AND s.deleted_at IS NULL").
+		Where("p.user_id = ? AND p.status <> ? AND p.deleted_at IS NULL", userID, "cancelled").
+		Group("p.contest_id, DATE(e.event_date)").
+		Having("COUNT(*) >= ? AND COUNT(*) = SUM(CASE WHEN s.points > 0 THEN 1 ELSE 0 END)", models.PerfectRoundMinPredictions)
+	if err := db.Table("(?) as rounds", rounds).Count(&progress.PerfectRounds).Error; err != nil {
+		return progress, err
+	}
+
+	if err := db.Table("challenges").
+		Where("winner_id = ? AND status = ? AND deleted_at IS NULL", userID, "completed").
+		Count(&progress.ChallengesWon).Error; err != nil {
+		return progress, err
+	}
+
+	return progress, nil
+}
+
+// ListByUser retrieves the badges a user has unlocked, in unlock order
+func (r *AchievementRepository) ListByUser(ctx context.Context, userID uint) ([]*models.UserAchievement, error) {
+	var achievements []*models.UserAchievement
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("unlocked_at ASC, id ASC").Find(&achievements).Error; err != nil {
+		return nil, err
+	}
+	return achievements, nil
+}
+
+// Unlock records a badge. It reports false when the user already had the badge, so
+// concurrent evaluations notify once.
+func (r *AchievementRepository) Unlock(ctx context.Context, achievement *models.UserAchievement) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(achievement)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
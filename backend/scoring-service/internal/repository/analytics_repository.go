@@ -17,6 +17,7 @@ type AnalyticsRepositoryInterface interface {
 	GetAccuracyByType(ctx context.Context, userID uint, since time.Time) ([]models.PredictionTypeAccuracy, error)
 	GetAccuracyTrends(ctx context.Context, userID uint, since time.Time, groupBy string) ([]models.AccuracyTrend, error)
 	GetPlatformStats(ctx context.Context, since time.Time) (*models.PlatformStats, error)
+	GetContestTotals(ctx context.Context, filter models.GlobalLeaderboardFilter) ([]models.ContestTotal, error)
 }
 
 // AnalyticsRepository implements AnalyticsRepositoryInterface
@@ -285,3 +286,43 @@ func (r *AnalyticsRepository) GetPlatformStats(ctx context.Context, since time.T
 		TotalPredictions:           result.TotalPredictions,
 	}, nil
 }
+
+// GetContestTotals retrieves every user's points and scored predictions per contest, limited to
+// the scores the filter selects. Leagues are reached through matches like GetAccuracyByLeague.
+func (r *AnalyticsRepository) GetContestTotals(ctx context.Context, filter models.GlobalLeaderboardFilter) ([]models.ContestTotal, error) {
+	query := r.db.WithContext(ctx).Table("scores s").
+		Select("s.user_id, s.contest_id, COALESCE(SUM(s.points), 0) as points, COUNT(*) as predictions").
+		Where("s.deleted_at IS NULL")
+
+	if filter.SportType != "" || filter.LeagueID != 0 || filter.Season != "" {
+		query = query.
+			Joins("JOIN predictions p ON s.prediction_id = p.id").
+			Joins("JOIN events e ON p.event_id = e.id")
+	}
+	if filter.SportType != "" {
+		query = query.Where("e.sport_type = ?", filter.SportType)
+	}
+	if filter.LeagueID != 0 || filter.Season != "" {
+		query = query.
+			Joins("JOIN matches m ON m.id = e.id").
+			Joins("JOIN leagues l ON m.league_id = l.id")
+		if filter.LeagueID != 0 {
+			query = query.Where("l.id = ?", filter.LeagueID)
+		}
+		if filter.Season != "" {
+			query = query.Where("l.season = ?", filter.Season)
+		}
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("s.scored_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("s.scored_at < ?", filter.To)
+	}
+
+	var totals []models.ContestTotal
+	if err := query.Group("s.user_id, s.contest_id").Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditRepositoryInterface defines the contract for score audit repository
+type AuditRepositoryInterface interface {
+	Create(ctx context.Context, audit *models.ScoreAudit) error
+	ListByEvent(ctx context.Context, eventID uint) ([]*models.ScoreAudit, error)
+	ListByContestAndUser(ctx context.Context, contestID, userID uint) ([]*models.ScoreAudit, error)
+}
+
+// AuditRepository implements AuditRepositoryInterface
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit repository instance
+func NewAuditRepository(db *gorm.DB) AuditRepositoryInterface {
+	return &AuditRepository{db: db}
+}
+
+// Create creates a new audit entry
+func (r *AuditRepository) Create(ctx context.Context, audit *models.ScoreAudit) error {
+	return r.db.WithContext(ctx).Create(audit).Error
+}
+
+// ListByEvent retrieves all audit entries for an event, newest first
+func (r *AuditRepository) ListByEvent(ctx context.Context, eventID uint) ([]*models.ScoreAudit, error) {
+	var audits []*models.ScoreAudit
+	if err := r.db.WithContext(ctx).Where("event_id = ?", eventID).
+		Order("created_at DESC, id DESC").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
+
+// ListByContestAndUser retrieves all audit entries for a user in a contest, newest first
+func (r *AuditRepository) ListByContestAndUser(ctx context.Context, contestID, userID uint) ([]*models.ScoreAudit, error) {
+	var audits []*models.ScoreAudit
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND user_id = ?", contestID, userID).
+		Order("created_at DESC, id DESC").Find(&audits).Error; err != nil {
+		return nil, err
+	}
+	return audits, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/sports-prediction-contests/scoring-service/internal/cache"
 	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/scoring"
 	"gorm.io/gorm"
 )
 
@@ -17,10 +18,12 @@ type LeaderboardRepositoryInterface interface {
 	Delete(ctx context.Context, id uint) error
 	GetByContestAndUser(ctx context.Context, contestID, userID uint) (*models.Leaderboard, error)
 	ListByContest(ctx context.Context, contestID uint, limit, offset int) ([]*models.Leaderboard, int64, error)
-	UpdateRankings(ctx context.Context, contestID uint) error
+	UpdateRankings(ctx context.Context, contestID uint, rules *scoring.LeaderboardRules) error
 	UpsertUserScore(ctx context.Context, contestID, userID uint, totalPoints float64) error
 	GetContestLeaderboard(ctx context.Context, contestID uint, limit int) ([]*models.Leaderboard, error)
-	RecalculateRanks(ctx context.Context, contestID uint) error
+	GetAroundUser(ctx context.Context, contestID, userID uint, neighbors int) ([]*models.Leaderboard, int64, error)
+	RecalculateRanks(ctx context.Context, contestID uint, rules *scoring.LeaderboardRules) error
+	Subscribe(ctx context.Context, contestID uint) (*cache.LeaderboardSubscription, error)
 }
 
 // LeaderboardRepository implements LeaderboardRepositoryInterface
@@ -123,7 +126,7 @@ func (r *LeaderboardRepository) ListByContest(ctx context.Context, contestID uin
 	}
 
 	// Get paginated results ordered by rank
-	if err := query.Order("rank ASC").Limit(limit).Offset(offset).Find(&leaderboards).Error; err != nil {
+	if err := query.Order("rank ASC, user_id ASC").Limit(limit).Offset(offset).Find(&leaderboards).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -131,47 +134,15 @@ func (r *LeaderboardRepository) ListByContest(ctx context.Context, contestID uin
 }
 
 // UpdateRankings recalculates and updates rankings for all users in a contest
-func (r *LeaderboardRepository) UpdateRankings(ctx context.Context, contestID uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Get all leaderboard entries for the contest ordered by total points (descending)
-		var leaderboards []*models.Leaderboard
-		if err := tx.Where("contest_id = ?", contestID).
-			Order("total_points DESC, updated_at ASC").
-			Find(&leaderboards).Error; err != nil {
-			return err
-		}
-
-		// Update ranks with tie-breaking logic
-		currentRank := uint(1)
-		var previousPoints float64 = -1
-		var actualRank uint = 1
-
-		for i, leaderboard := range leaderboards {
-			if leaderboard.TotalPoints != previousPoints {
-				currentRank = uint(i + 1)
-			}
-
-			leaderboard.Rank = currentRank
-			previousPoints = leaderboard.TotalPoints
-			actualRank++
-
-			if err := tx.Save(leaderboard).Error; err != nil {
-				return err
-			}
-
-			// Update cache
-			if r.cache != nil {
-				_ = r.cache.SetLeaderboardScore(ctx, contestID, leaderboard.UserID, leaderboard.TotalPoints)
-			}
-		}
-
-		return nil
-	})
+func (r *LeaderboardRepository) UpdateRankings(ctx context.Context, contestID uint, rules *scoring.LeaderboardRules) error {
+	return r.RecalculateRanks(ctx, contestID, rules)
 }
 
-// UpsertUserScore creates or updates a user's leaderboard entry
+// UpsertUserScore creates or updates a user's leaderboard entry and notifies leaderboard
+// watchers when the total changed
 func (r *LeaderboardRepository) UpsertUserScore(ctx context.Context, contestID, userID uint, totalPoints float64) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var delta cache.LeaderboardDelta
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var leaderboard models.Leaderboard
 
 		// Try to find existing entry
@@ -191,12 +162,21 @@ func (r *LeaderboardRepository) UpsertUserScore(ctx context.Context, contestID,
 			if err := tx.Create(&leaderboard).Error; err != nil {
 				return err
 			}
+			delta = cache.LeaderboardDelta{UserID: userID, TotalPoints: totalPoints, PointsDelta: totalPoints}
 		} else {
 			// Update existing entry
+			previousPoints := leaderboard.TotalPoints
 			leaderboard.TotalPoints = totalPoints
 			if err := tx.Save(&leaderboard).Error; err != nil {
 				return err
 			}
+			delta = cache.LeaderboardDelta{
+				UserID:       userID,
+				Rank:         leaderboard.Rank,
+				PreviousRank: leaderboard.Rank,
+				TotalPoints:  totalPoints,
+				PointsDelta:  totalPoints - previousPoints,
+			}
 		}
 
 		// Update cache
@@ -206,6 +186,14 @@ func (r *LeaderboardRepository) UpsertUserScore(ctx context.Context, contestID,
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	if r.cache != nil && delta.PointsDelta != 0 {
+		_ = r.cache.PublishLeaderboardChanges(ctx, contestID, []cache.LeaderboardDelta{delta})
+	}
+	return nil
 }
 
 // GetContestLeaderboard retrieves the top N entries from a contest leaderboard
@@ -231,53 +219,260 @@ func (r *LeaderboardRepository) GetContestLeaderboard(ctx context.Context, conte
 	// Fallback to database
 	var leaderboards []*models.Leaderboard
 	if err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).
-		Order("rank ASC").Limit(limit).Find(&leaderboards).Error; err != nil {
+		Order("rank ASC, user_id ASC").Limit(limit).Find(&leaderboards).Error; err != nil {
 		return nil, err
 	}
 
-	// Warm up cache
-	if r.cache != nil {
-		for _, lb := range leaderboards {
-			_ = r.cache.SetLeaderboardScore(ctx, contestID, lb.UserID, lb.TotalPoints)
+	// Warm up cache, only with the whole leaderboard so cached pages are never cut short
+	if r.cache != nil && len(leaderboards) < limit {
+		entries := make([]cache.LeaderboardEntry, len(leaderboards))
+		for i, lb := range leaderboards {
+			entries[i] = cache.LeaderboardEntry{UserID: lb.UserID, TotalPoints: lb.TotalPoints, Rank: lb.Rank}
 		}
+		_ = r.cache.SetLeaderboardRanking(ctx, contestID, entries)
 	}
 
 	return leaderboards, nil
 }
 
-// RecalculateRanks recalculates ranks for a contest based on current scores
-func (r *LeaderboardRepository) RecalculateRanks(ctx context.Context, contestID uint) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Get all leaderboard entries for the contest ordered by total points (descending)
-		var leaderboards []*models.Leaderboard
-		if err := tx.Where("contest_id = ?", contestID).
-			Order("total_points DESC, updated_at ASC").
-			Find(&leaderboards).Error; err != nil {
-			return err
+// GetAroundUser retrieves a user's leaderboard entry with up to neighbors entries either side,
+// and the number of participants. It reads the cached ranking and falls back to the database
+// when the cache is unavailable or does not hold the user.
+func (r *LeaderboardRepository) GetAroundUser(ctx context.Context, contestID, userID uint, neighbors int) ([]*models.Leaderboard, int64, error) {
+	if r.cache != nil {
+		cacheEntries, total, err := r.cache.GetLeaderboardAroundUser(ctx, contestID, userID, int64(neighbors))
+		if err == nil && len(cacheEntries) > 0 {
+			leaderboards := make([]*models.Leaderboard, len(cacheEntries))
+			for i, entry := range cacheEntries {
+				leaderboards[i] = &models.Leaderboard{
+					ContestID:   contestID,
+					UserID:      entry.UserID,
+					TotalPoints: entry.TotalPoints,
+					Rank:        entry.Rank,
+				}
+			}
+			return leaderboards, total, nil
 		}
+	}
 
-		// Update ranks with tie-breaking logic
-		currentRank := uint(1)
-		var previousPoints float64 = -1
+	// Fallback to database
+	user, err := r.GetByContestAndUser(ctx, contestID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		for i, leaderboard := range leaderboards {
-			if leaderboard.TotalPoints != previousPoints {
-				currentRank = uint(i + 1)
-			}
+	query := r.db.WithContext(ctx).Model(&models.Leaderboard{}).Where("contest_id = ?", contestID)
+	var total, position int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Session(&gorm.Session{}).
+		Where("(rank < ? OR (rank = ? AND user_id < ?))", user.Rank, user.Rank, user.UserID).
+		Count(&position).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var leaderboards []*models.Leaderboard
+	offset := max(0, position-int64(neighbors))
+	if err := query.Session(&gorm.Session{}).Order("rank ASC, user_id ASC").
+		Offset(int(offset)).Limit(2*neighbors + 1).Find(&leaderboards).Error; err != nil {
+		return nil, 0, err
+	}
+	return leaderboards, total, nil
+}
 
-			leaderboard.Rank = currentRank
-			previousPoints = leaderboard.TotalPoints
+// RecalculateRanks recalculates ranks for a contest based on current scores and the contest's
+// tie-breakers, then stores the same ranking in the cache and publishes the rank changes
+func (r *LeaderboardRepository) RecalculateRanks(ctx context.Context, contestID uint, rules *scoring.LeaderboardRules) error {
+	var entries []cache.LeaderboardEntry
+	var deltas []cache.LeaderboardDelta
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var leaderboards []*models.Leaderboard
+		if err := tx.Where("contest_id = ?", contestID).Find(&leaderboards).Error; err != nil {
+			return err
+		}
 
-			if err := tx.Save(leaderboard).Error; err != nil {
-				return err
-			}
+		standings, err := r.standings(tx, contestID, leaderboards, rules)
+		if err != nil {
+			return err
+		}
+		ranks := rules.Rank(standings)
+
+		byUser := make(map[uint]*models.Leaderboard, len(leaderboards))
+		for _, leaderboard := range leaderboards {
+			byUser[leaderboard.UserID] = leaderboard
+		}
 
-			// Update cache
-			if r.cache != nil {
-				_ = r.cache.SetLeaderboardScore(ctx, contestID, leaderboard.UserID, leaderboard.TotalPoints)
+		entries = make([]cache.LeaderboardEntry, len(standings))
+		for i, standing := range standings {
+			leaderboard := byUser[standing.UserID]
+			if leaderboard.Rank != ranks[i] {
+				deltas = append(deltas, cache.LeaderboardDelta{
+					UserID:       standing.UserID,
+					Rank:         ranks[i],
+					PreviousRank: leaderboard.Rank,
+					TotalPoints:  standing.TotalPoints,
+				})
+				if err := tx.Model(leaderboard).UpdateColumn("rank", ranks[i]).Error; err != nil {
+					return err
+				}
 			}
+			entries[i] = cache.LeaderboardEntry{UserID: standing.UserID, TotalPoints: standing.TotalPoints, Rank: ranks[i]}
 		}
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	// Update cache and notify watchers of the participants who moved
+	if r.cache != nil {
+		_ = r.cache.SetLeaderboardRanking(ctx, contestID, entries)
+		_ = r.cache.PublishLeaderboardChanges(ctx, contestID, deltas)
+	}
+
+	return nil
+}
+
+// Subscribe starts receiving a contest's leaderboard changes, published through Redis so that
+// watchers of any instance see changes made by every instance
+func (r *LeaderboardRepository) Subscribe(ctx context.Context, contestID uint) (*cache.LeaderboardSubscription, error) {
+	if r.cache == nil {
+		return nil, errors.New("leaderboard updates require Redis")
+	}
+	return r.cache.SubscribeLeaderboard(ctx, contestID)
+}
+
+// standings collects the totals of a contest's leaderboard with the statistics its tie-breakers compare
+func (r *LeaderboardRepository) standings(tx *gorm.DB, contestID uint, leaderboards []*models.Leaderboard, rules *scoring.LeaderboardRules) ([]scoring.Standing, error) {
+	var exact, outcomes, streaks, predicted map[uint]int64
+	var leads map[uint]float64
+	var err error
+
+	if rules.Uses(scoring.TieBreakExactScores) {
+		if exact, err = countScoresByUser(tx, contestID, []string{"exact_score"}); err != nil {
+			return nil, err
+		}
+	}
+	if rules.Uses(scoring.TieBreakCorrectOutcomes) {
+		if outcomes, err = countScoresByUser(tx, contestID, scoring.CorrectOutcomeRules()); err != nil {
+			return nil, err
+		}
+	}
+	if rules.Uses(scoring.TieBreakMaxStreak) {
+		if streaks, err = maxStreaksByUser(tx, contestID); err != nil {
+			return nil, err
+		}
+	}
+	if rules.Uses(scoring.TieBreakEarliestSubmission) {
+		if leads, err = avgLeadHoursByUser(tx, contestID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Everyone is measured against the contest's matches, or the most predicted by anyone
+	// when the contest has no fixed list of matches
+	var matches int64
+	if rules.Uses(scoring.TieBreakFewestMissed) {
+		if predicted, err = countPredictedByUser(tx, contestID); err != nil {
+			return nil, err
+		}
+		if err := tx.Table("contest_events").Where("contest_id = ?", contestID).Count(&matches).Error; err != nil {
+			return nil, err
+		}
+		for _, count := range predicted {
+			matches = max(matches, count)
+		}
+	}
+
+	standings := make([]scoring.Standing, len(leaderboards))
+	for i, leaderboard := range leaderboards {
+		userID := leaderboard.UserID
+		standings[i] = scoring.Standing{
+			UserID:          userID,
+			TotalPoints:     leaderboard.TotalPoints,
+			ExactScores:     exact[userID],
+			CorrectOutcomes: outcomes[userID],
+			MaxStreak:       uint(streaks[userID]),
+			AvgLeadHours:    leads[userID],
+			Missed:          matches - predicted[userID],
+		}
+	}
+	return standings, nil
+}
+
+// countScoresByUser counts each user's scores in a contest that matched one of the given rules
+func countScoresByUser(tx *gorm.DB, contestID uint, rules []string) (map[uint]int64, error) {
+	var rows []userCount
+	if err := tx.Model(&models.Score{}).
+		Select("user_id, COUNT(*) as count").
+		Where("contest_id = ? AND rule_matched IN ?", contestID, rules).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return countsByUser(rows), nil
+}
+
+// maxStreaksByUser returns each user's best streak in a contest
+func maxStreaksByUser(tx *gorm.DB, contestID uint) (map[uint]int64, error) {
+	var rows []userCount
+	if err := tx.Model(&models.UserStreak{}).
+		Select("user_id, max_streak as count").
+		Where("contest_id = ?", contestID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return countsByUser(rows), nil
+}
+
+// countPredictedByUser counts the matches each user predicted in a contest
+func countPredictedByUser(tx *gorm.DB, contestID uint) (map[uint]int64, error) {
+	var rows []userCount
+	if err := tx.Table("predictions").
+		Select("user_id, COUNT(DISTINCT event_id) as count").
+		Where("contest_id = ? AND status <> ? AND deleted_at IS NULL", contestID, "cancelled").
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return countsByUser(rows), nil
+}
+
+// avgLeadHoursByUser returns how many hours before kickoff each user predicted on average
+func avgLeadHoursByUser(tx *gorm.DB, contestID uint) (map[uint]float64, error) {
+	var rows []struct {
+		UserID uint
+		Hours  float64
+	}
+	if err := tx.Table("predictions p").
+		Select("p.user_id, AVG(EXTRACT(EPOCH FROM (e.event_date - p.submitted_at))) / 3600 as hours").
+		Joins("JOIN events e ON e.id = p.event_id").
+		Where("p.contest_id = ? AND p.status <> ? AND p.deleted_at IS NULL", contestID, "cancelled").
+		Group("p.user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	leads := make(map[uint]float64, len(rows))
+	for _, row := range rows {
+		leads[row.UserID] = row.Hours
+	}
+	return leads, nil
+}
+
+// userCount is a per-user aggregate row
+type userCount struct {
+	UserID uint
+	Count  int64
+}
+
+// countsByUser turns per-user aggregate rows into a map
+func countsByUser(rows []userCount) map[uint]int64 {
+	counts := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts
 }
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PoolSettleFunc splits a draw's pool given the series jackpot and returns what to store
+type PoolSettleFunc func(jackpot float64) (*models.PoolDraw, []*models.PoolPayout)
+
+// PoolRepositoryInterface defines the contract for totalizator pool repository
+type PoolRepositoryInterface interface {
+	GetDraw(ctx context.Context, contestID uint) (*models.PoolDraw, error)
+	ListPayouts(ctx context.Context, contestID uint) ([]*models.PoolPayout, error)
+	GetPayout(ctx context.Context, contestID, userID uint) (*models.PoolPayout, error)
+	GetJackpot(ctx context.Context, series string) (float64, error)
+	SettleDraw(ctx context.Context, series string, settle PoolSettleFunc) (*models.PoolDraw, []*models.PoolPayout, error)
+	ResettleDraw(ctx context.Context, draw *models.PoolDraw, payouts []*models.PoolPayout) error
+}
+
+// PoolRepository implements PoolRepositoryInterface
+type PoolRepository struct {
+	db *gorm.DB
+}
+
+// NewPoolRepository creates a new pool repository instance
+func NewPoolRepository(db *gorm.DB) PoolRepositoryInterface {
+	return &PoolRepository{db: db}
+}
+
+// GetDraw retrieves the settlement of a contest's draw, or nil if it is not settled yet
+func (r *PoolRepository) GetDraw(ctx context.Context, contestID uint) (*models.PoolDraw, error) {
+	var draw models.PoolDraw
+	if err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).First(&draw).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &draw, nil
+}
+
+// ListPayouts retrieves all payouts of a settled draw, largest first
+func (r *PoolRepository) ListPayouts(ctx context.Context, contestID uint) ([]*models.PoolPayout, error) {
+	var payouts []*models.PoolPayout
+	if err := r.db.WithContext(ctx).Where("contest_id = ?", contestID).
+		Order("amount DESC, user_id ASC").Find(&payouts).Error; err != nil {
+		return nil, err
+	}
+	return payouts, nil
+}
+
+// GetPayout retrieves a user's payout from a settled draw, or nil if the user won nothing
+func (r *PoolRepository) GetPayout(ctx context.Context, contestID, userID uint) (*models.PoolPayout, error) {
+	var payout models.PoolPayout
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND user_id = ?", contestID, userID).First(&payout).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &payout, nil
+}
+
+// GetJackpot retrieves the jackpot currently waiting in a series
+func (r *PoolRepository) GetJackpot(ctx context.Context, series string) (float64, error) {
+	var jackpot models.PoolJackpot
+	if err := r.db.WithContext(ctx).Where("series = ?", series).First(&jackpot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return jackpot.Amount, nil
+}
+
+// SettleDraw stores a draw settlement and replaces the series jackpot with its rollover.
+// The jackpot row is locked for the duration so two draws of a series never share a jackpot,
+// and the unique contest index makes a second settlement of the same draw fail.
+func (r *PoolRepository) SettleDraw(ctx context.Context, series string, settle PoolSettleFunc) (*models.PoolDraw, []*models.PoolPayout, error) {
+	var draw *models.PoolDraw
+	var payouts []*models.PoolPayout
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		jackpot := models.PoolJackpot{Series: series}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&jackpot).Error; err != nil {
+			return err
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("series = ?", series).First(&jackpot).Error; err != nil {
+			return err
+		}
+
+		draw, payouts = settle(jackpot.Amount)
+		if err := tx.Create(draw).Error; err != nil {
+			return err
+		}
+		if len(payouts) > 0 {
+			if err := tx.Create(&payouts).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.PoolJackpot{}).Where("series = ?", series).
+			Updates(map[string]interface{}{"amount": draw.RolloverOut, "updated_at": gorm.Expr("NOW()")}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return draw, payouts, nil
+}
+
+// ResettleDraw replaces a settled draw's payouts and rollover after a result correction. The
+// series jackpot is moved by the change in rollover, so money a correction frees or claims is
+// carried by whichever draw of the series is next. A changed rollover is refused with
+// models.ErrRolloverClaimed once a later draw of the series was settled.
+func (r *PoolRepository) ResettleDraw(ctx context.Context, draw *models.PoolDraw, payouts []*models.PoolPayout) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var jackpot models.PoolJackpot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("series = ?", draw.Series).First(&jackpot).Error; err != nil {
+			return err
+		}
+		var previous models.PoolDraw
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("contest_id = ?", draw.ContestID).First(&previous).Error; err != nil {
+			return err
+		}
+
+		var later int64
+		if err := tx.Model(&models.PoolDraw{}).
+			Where("series = ? AND settled_at > ? AND id <> ?", previous.Series, previous.SettledAt, previous.ID).
+			Count(&later).Error; err != nil {
+			return err
+		}
+		amount, err := previous.ResettleJackpot(jackpot.Amount, draw.RolloverOut, later > 0)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("contest_id = ?", draw.ContestID).Delete(&models.PoolPayout{}).Error; err != nil {
+			return err
+		}
+		if len(payouts) > 0 {
+			if err := tx.Create(&payouts).Error; err != nil {
+				return err
+			}
+		}
+		// The draw keeps its settlement time so later draws of the series stay later
+		if err := tx.Model(&models.PoolDraw{}).Where("id = ?", previous.ID).
+			Update("rollover_out", draw.RolloverOut).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.PoolJackpot{}).Where("series = ?", draw.Series).
+			Updates(map[string]interface{}{"amount": amount, "updated_at": gorm.Expr("NOW()")}).Error
+	})
+}
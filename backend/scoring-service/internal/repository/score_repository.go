@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/sports-prediction-contests/scoring-service/internal/models"
 	"gorm.io/gorm"
@@ -20,6 +21,12 @@ type ScoreRepositoryInterface interface {
 	BatchCreate(ctx context.Context, scores []*models.Score) error
 	GetTotalPointsByContestAndUser(ctx context.Context, contestID, userID uint) (float64, error)
 	ListByContest(ctx context.Context, contestID uint) ([]*models.Score, error)
+	ListChainByContestAndUser(ctx context.Context, contestID, userID uint) ([]*models.ScoreHistory, error)
+	ListByContestAndUserInEventOrder(ctx context.Context, contestID, userID uint) ([]*models.Score, error)
+	CountRuleMatchesByUser(ctx context.Context, contestID uint, rule string) (map[uint]int, error)
+	ListHistoryByContest(ctx context.Context, contestID uint, asOf time.Time) ([]*models.ScoreHistory, error)
+	ListByContestAndUserInScoredOrder(ctx context.Context, contestID, userID uint) ([]*models.Score, error)
+	ListByEvent(ctx context.Context, eventID uint) ([]*models.Score, error)
 }
 
 // ScoreRepository implements ScoreRepositoryInterface
@@ -154,3 +161,100 @@ func (r *ScoreRepository) ListByContest(ctx context.Context, contestID uint) ([]
 	}
 	return scores, nil
 }
+
+// streakChainOrder orders scores the way streaks are built: by event kickoff, then prediction
+// ID. Scores without a matching prediction or event fall back to scoring time.
+const streakChainOrder = "COALESCE(e.event_date, scores.scored_at) ASC, scores.prediction_id ASC"
+
+// ListChainByContestAndUser retrieves a user's scores in a contest in streak chain order, with
+// the times that select each score's rules version
+func (r *ScoreRepository) ListChainByContestAndUser(ctx context.Context, contestID, userID uint) ([]*models.ScoreHistory, error) {
+	var scores []*models.ScoreHistory
+	if err := r.db.WithContext(ctx).Model(&models.Score{}).
+		Select("scores.*, p.submitted_at, e.event_date").
+		Joins("LEFT JOIN predictions p ON p.id = scores.prediction_id").
+		Joins("LEFT JOIN events e ON e.id = p.event_id").
+		Where("scores.contest_id = ? AND scores.user_id = ?", contestID, userID).
+		Order(streakChainOrder).
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// ListByContestAndUserInEventOrder retrieves a user's scores in a contest in event date order,
+// which is the round order survivor picks are replayed in
+func (r *ScoreRepository) ListByContestAndUserInEventOrder(ctx context.Context, contestID, userID uint) ([]*models.Score, error) {
+	var scores []*models.Score
+	if err := r.db.WithContext(ctx).Model(&models.Score{}).
+		Select("scores.*").
+		Joins("LEFT JOIN predictions p ON p.id = scores.prediction_id").
+		Joins("LEFT JOIN events e ON e.id = p.event_id").
+		Where("scores.contest_id = ? AND scores.user_id = ?", contestID, userID).
+		Order("COALESCE(e.event_date, scores.scored_at) ASC, scores.id ASC").
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// CountRuleMatchesByUser counts, per user, the scores of a contest produced by the given rule
+func (r *ScoreRepository) CountRuleMatchesByUser(ctx context.Context, contestID uint, rule string) (map[uint]int, error) {
+	var rows []struct {
+		UserID uint
+		Count  int
+	}
+	if err := r.db.WithContext(ctx).Model(&models.Score{}).
+		Select("user_id, COUNT(*) as count").
+		Where("contest_id = ? AND rule_matched = ?", contestID, rule).
+		Group("user_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		counts[row.UserID] = row.Count
+	}
+	return counts, nil
+}
+
+// ListHistoryByContest retrieves a contest's scores credited up to asOf, with the times of their
+// predictions, in streak chain order
+func (r *ScoreRepository) ListHistoryByContest(ctx context.Context, contestID uint, asOf time.Time) ([]*models.ScoreHistory, error) {
+	var history []*models.ScoreHistory
+	if err := r.db.WithContext(ctx).Model(&models.Score{}).
+		Select("scores.*, p.submitted_at, e.event_date").
+		Joins("LEFT JOIN predictions p ON p.id = scores.prediction_id").
+		Joins("LEFT JOIN events e ON e.id = p.event_id").
+		Where("scores.contest_id = ? AND scores.scored_at <= ?", contestID, asOf).
+		Order(streakChainOrder).
+		Find(&history).Error; err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// ListByContestAndUserInScoredOrder retrieves a user's scores in a contest in the order they were credited
+func (r *ScoreRepository) ListByContestAndUserInScoredOrder(ctx context.Context, contestID, userID uint) ([]*models.Score, error) {
+	var scores []*models.Score
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND user_id = ?", contestID, userID).
+		Order("scored_at ASC, id ASC").Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
+
+// ListByEvent retrieves the scores of every prediction on an event, across all contests
+func (r *ScoreRepository) ListByEvent(ctx context.Context, eventID uint) ([]*models.Score, error) {
+	var scores []*models.Score
+	if err := r.db.WithContext(ctx).Model(&models.Score{}).
+		Select("scores.*").
+		Joins("JOIN predictions p ON p.id = scores.prediction_id").
+		Where("p.event_id = ?", eventID).
+		Order("scores.contest_id ASC, scores.id ASC").
+		Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	return scores, nil
+}
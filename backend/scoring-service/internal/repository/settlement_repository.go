@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"gorm.io/gorm"
+)
+
+// SettlementRepositoryInterface defines the contract for reading settlement inputs.
+// Events, predictions and contests are owned by other services but live in the
+// shared database, so they are queried directly by table name.
+type SettlementRepositoryInterface interface {
+	GetEvent(ctx context.Context, eventID uint) (*models.SettlementEvent, error)
+	ListEvents(ctx context.Context, eventIDs []uint) ([]*models.SettlementEvent, error)
+	ListPendingPredictions(ctx context.Context, eventID uint) ([]*models.SettlementPrediction, error)
+	ListScoredPredictions(ctx context.Context, eventID uint) ([]*models.SettlementPrediction, error)
+	ListContestPredictions(ctx context.Context, contestID uint) ([]*models.SettlementPrediction, error)
+	CountPendingPredictions(ctx context.Context, contestID uint) (int64, error)
+	ListContestEventIDs(ctx context.Context, contestID uint) ([]uint, error)
+	GetContestRules(ctx context.Context, contestID uint) (string, error)
+	GetContestRulesHistory(ctx context.Context, contestID uint) (*models.SettlementContestRules, error)
+	GetRiskyOutcomes(ctx context.Context, eventID uint) (map[string]bool, error)
+	ListPropTypes(ctx context.Context, ids []uint, slugs []string) ([]*models.SettlementPropType, error)
+	IsContestOrganizer(ctx context.Context, contestID, userID uint) (bool, error)
+	MarkPredictionScored(ctx context.Context, predictionID uint) error
+	UpdateParticipantSurvival(ctx context.Context, contestID, userID uint, livesLost int, eliminated bool) error
+}
+
+// SettlementRepository implements SettlementRepositoryInterface
+type SettlementRepository struct {
+	db *gorm.DB
+}
+
+// NewSettlementRepository creates a new settlement repository instance
+func NewSettlementRepository(db *gorm.DB) SettlementRepositoryInterface {
+	return &SettlementRepository{db: db}
+}
+
+// GetEvent retrieves the status and result of an event
+func (r *SettlementRepository) GetEvent(ctx context.Context, eventID uint) (*models.SettlementEvent, error) {
+	var event models.SettlementEvent
+	err := r.db.WithContext(ctx).Table("events").
+		Select("id, status, event_date, home_team, away_team, COALESCE(result_data::text, '') as result_data").
+		Where("id = ? AND deleted_at IS NULL", eventID).
+		Take(&event).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("event not found")
+		}
+		return nil, err
+	}
+	return &event, nil
+}
+
+// ListEvents retrieves the status and result of several events
+func (r *SettlementRepository) ListEvents(ctx context.Context, eventIDs []uint) ([]*models.SettlementEvent, error) {
+	var events []*models.SettlementEvent
+	if len(eventIDs) == 0 {
+		return events, nil
+	}
+	if err := r.db.WithContext(ctx).Table("events").
+		Select("id, status, event_date, home_team, away_team, COALESCE(result_data::text, '') as result_data").
+		Where("id IN ? AND deleted_at IS NULL", eventIDs).
+		Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ListPendingPredictions retrieves all pending predictions for an event across all contests,
+// ordered by submission time so streaks are applied deterministically
+func (r *SettlementRepository) ListPendingPredictions(ctx context.Context, eventID uint) ([]*models.SettlementPrediction, error) {
+	var predictions []*models.SettlementPrediction
+	if err := r.db.WithContext(ctx).Table("predictions").
+		Select("id, contest_id, user_id, event_id, prediction_data::text as prediction_data, submitted_at").
+		Where("event_id = ? AND status = ? AND deleted_at IS NULL", eventID, "pending").
+		Order("submitted_at ASC, id ASC").
+		Find(&predictions).Error; err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// ListScoredPredictions retrieves all already scored predictions for an event across all contests
+func (r *SettlementRepository) ListScoredPredictions(ctx context.Context, eventID uint) ([]*models.SettlementPrediction, error) {
+	var predictions []*models.SettlementPrediction
+	if err := r.db.WithContext(ctx).Table("predictions").
+		Select("id, contest_id, user_id, event_id, prediction_data::text as prediction_data, submitted_at").
+		Where("event_id = ? AND status = ? AND deleted_at IS NULL", eventID, "scored").
+		Order("submitted_at ASC, id ASC").
+		Find(&predictions).Error; err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// ListContestPredictions retrieves every active prediction of a contest
+func (r *SettlementRepository) ListContestPredictions(ctx context.Context, contestID uint) ([]*models.SettlementPrediction, error) {
+	var predictions []*models.SettlementPrediction
+	if err := r.db.WithContext(ctx).Table("predictions").
+		Select("id, contest_id, user_id, event_id, prediction_data::text as prediction_data, submitted_at").
+		Where("contest_id = ? AND status <> ? AND deleted_at IS NULL", contestID, "cancelled").
+		Order("user_id ASC, id ASC").
+		Find(&predictions).Error; err != nil {
+		return nil, err
+	}
+	return predictions, nil
+}
+
+// CountPendingPredictions counts a contest's predictions that are still waiting to be scored
+func (r *SettlementRepository) CountPendingPredictions(ctx context.Context, contestID uint) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("predictions").
+		Where("contest_id = ? AND status = ? AND deleted_at IS NULL", contestID, "pending").
+		Count(&count).Error
+	return count, err
+}
+
+// ListContestEventIDs retrieves the events selected for a contest
+func (r *SettlementRepository) ListContestEventIDs(ctx context.Context, contestID uint) ([]uint, error) {
+	var eventIDs []uint
+	if err := r.db.WithContext(ctx).Table("contest_events").
+		Where("contest_id = ?", contestID).
+		Order("event_id ASC").
+		Pluck("event_id", &eventIDs).Error; err != nil {
+		return nil, err
+	}
+	return eventIDs, nil
+}
+
+// GetContestRules retrieves the raw JSON rules of a contest, selecting the scoring profile
+// of the contest's sport type when the rules do not name a sport
+func (r *SettlementRepository) GetContestRules(ctx context.Context, contestID uint) (string, error) {
+	var row struct {
+		Rules     string
+		SportType string
+	}
+	if err := r.db.WithContext(ctx).Table("contests").
+		Select("COALESCE(rules, '') AS rules, COALESCE(sport_type, '') AS sport_type").
+		Where("id = ?", contestID).
+		Scan(&row).Error; err != nil {
+		return "", err
+	}
+	return scoring.RulesWithSport(row.Rules, row.SportType), nil
+}
+
+// GetContestRulesHistory retrieves a contest's rules versions and rules basis, with each
+// version's rules selecting the scoring profile of the contest's sport type like GetContestRules
+func (r *SettlementRepository) GetContestRulesHistory(ctx context.Context, contestID uint) (*models.SettlementContestRules, error) {
+	var row struct {
+		Rules      string
+		SportType  string
+		RulesBasis string
+	}
+	if err := r.db.WithContext(ctx).Table("contests").
+		Select("COALESCE(rules, '') AS rules, COALESCE(sport_type, '') AS sport_type, COALESCE(rules_basis, '') AS rules_basis").
+		Where("id = ?", contestID).
+		Scan(&row).Error; err != nil {
+		return nil, err
+	}
+
+	var versions []*models.SettlementRulesVersion
+	if err := r.db.WithContext(ctx).Table("contest_rules_versions").
+		Select("version, COALESCE(rules, '') AS rules, effective_from").
+		Where("contest_id = ?", contestID).
+		Order("version ASC").
+		Scan(&versions).Error; err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		version.Rules = scoring.RulesWithSport(version.Rules, row.SportType)
+	}
+
+	return &models.SettlementContestRules{
+		Rules:     scoring.RulesWithSport(row.Rules, row.SportType),
+		SportType: row.SportType,
+		Basis:     row.RulesBasis,
+		Versions:  versions,
+	}, nil
+}
+
+// GetRiskyOutcomes retrieves recorded risky event outcomes for a match keyed by event type slug
+func (r *SettlementRepository) GetRiskyOutcomes(ctx context.Context, eventID uint) (map[string]bool, error) {
+	var rows []struct {
+		Slug    string
+		Outcome bool
+	}
+	if err := r.db.WithContext(ctx).Table("match_risky_events mre").
+		Select("ret.slug as slug, mre.outcome as outcome").
+		Joins("JOIN risky_event_types ret ON ret.id = mre.risky_event_type_id").
+		Where("mre.event_id = ? AND mre.outcome IS NOT NULL", eventID).
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	outcomes := make(map[string]bool, len(rows))
+	for _, row := range rows {
+		outcomes[row.Slug] = row.Outcome
+	}
+	return outcomes, nil
+}
+
+// ListPropTypes retrieves the prop types predicted by ID, or by slug for picks without one
+func (r *SettlementRepository) ListPropTypes(ctx context.Context, ids []uint, slugs []string) ([]*models.SettlementPropType, error) {
+	var propTypes []*models.SettlementPropType
+	if len(ids) == 0 && len(slugs) == 0 {
+		return propTypes, nil
+	}
+	if err := r.db.WithContext(ctx).Table("prop_types").
+		Select("id, sport_type, slug, points_correct, COALESCE(evaluation, '') as evaluation").
+		Where("deleted_at IS NULL AND (id IN ? OR slug IN ?)", ids, slugs).
+		Order("id ASC").
+		Scan(&propTypes).Error; err != nil {
+		return nil, err
+	}
+	return propTypes, nil
+}
+
+// IsContestOrganizer checks whether a user created a contest or is one of its active admins
+func (r *SettlementRepository) IsContestOrganizer(ctx context.Context, contestID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table("contests c").
+		Where("c.id = ? AND c.deleted_at IS NULL", contestID).
+		Where("c.creator_id = ? OR EXISTS (?)", userID,
+			r.db.Table("participants p").Select("1").
+				Where("p.contest_id = c.id AND p.user_id = ? AND p.role = ? AND p.status = ? AND p.deleted_at IS NULL", userID, "admin", "active")).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// MarkPredictionScored flips a prediction status to scored
+func (r *SettlementRepository) MarkPredictionScored(ctx context.Context, predictionID uint) error {
+	return r.db.WithContext(ctx).Table("predictions").
+		Where("id = ?", predictionID).
+		Updates(map[string]interface{}{"status": "scored", "updated_at": gorm.Expr("NOW()")}).Error
+}
+
+// UpdateParticipantSurvival stores a survivor participant's lost lives and elimination.
+// A correction can revive an eliminated participant; other statuses such as banned are kept.
+func (r *SettlementRepository) UpdateParticipantSurvival(ctx context.Context, contestID, userID uint, livesLost int, eliminated bool) error {
+	status := gorm.Expr("CASE WHEN status = 'eliminated' THEN 'active' ELSE status END")
+	if eliminated {
+		status = gorm.Expr("CASE WHEN status = 'active' THEN 'eliminated' ELSE status END")
+	}
+	return r.db.WithContext(ctx).Table("participants").
+		Where("contest_id = ? AND user_id = ? AND deleted_at IS NULL", contestID, userID).
+		Updates(map[string]interface{}{"lives_lost": livesLost, "status": status, "updated_at": gorm.Expr("NOW()")}).Error
+}
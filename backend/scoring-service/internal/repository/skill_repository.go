@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SkillRateFunc computes an event's rating changes from the predictors' current ratings,
+// updating the ratings in place
+type SkillRateFunc func(ratings map[uint]*models.SkillRating) []*models.SkillRatingChange
+
+// SkillRepositoryInterface defines the contract for predictors' skill ratings
+type SkillRepositoryInterface interface {
+	GetRatings(ctx context.Context, userIDs []uint) (map[uint]*models.SkillRating, error)
+	ApplyEventRatings(ctx context.Context, eventID uint, userIDs []uint, rate SkillRateFunc) (int, error)
+	ListHistory(ctx context.Context, userID uint, since time.Time) ([]*models.SkillRatingChange, error)
+}
+
+// SkillRepository implements SkillRepositoryInterface
+type SkillRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillRepository creates a new skill rating repository instance
+func NewSkillRepository(db *gorm.DB) SkillRepositoryInterface {
+	return &SkillRepository{db: db}
+}
+
+// GetRatings retrieves the ratings of the given users, keyed by user. Unrated users are absent.
+func (r *SkillRepository) GetRatings(ctx context.Context, userIDs []uint) (map[uint]*models.SkillRating, error) {
+	ratings := make(map[uint]*models.SkillRating, len(userIDs))
+	if len(userIDs) == 0 {
+		return ratings, nil
+	}
+	var rows []*models.SkillRating
+	if err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		ratings[row.UserID] = row
+	}
+	return ratings, nil
+}
+
+// ApplyEventRatings rates an event's predictors and stores their new ratings together with
+// their history, unless the event has already been rated. The predictors' rating rows are
+// locked while rate runs, so events settling concurrently never overwrite each other's
+// changes. It returns how many predictors were rated.
+func (r *SkillRepository) ApplyEventRatings(ctx context.Context, eventID uint, userIDs []uint, rate SkillRateFunc) (int, error) {
+	var rated int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.SkillRatingChange{}).Where("event_id = ?", eventID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 || len(userIDs) == 0 {
+			return nil
+		}
+
+		// Unrated predictors get a default row first so every rating can be locked
+		defaults := make([]*models.SkillRating, len(userIDs))
+		for i, userID := range userIDs {
+			defaults[i] = &models.SkillRating{UserID: userID, Rating: models.DefaultSkillRating}
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).CreateInBatches(defaults, 500).Error; err != nil {
+			return err
+		}
+		var rows []*models.SkillRating
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id IN ?", userIDs).Order("user_id ASC").Find(&rows).Error; err != nil {
+			return err
+		}
+		ratings := make(map[uint]*models.SkillRating, len(rows))
+		for _, row := range rows {
+			ratings[row.UserID] = row
+		}
+
+		changes := rate(ratings)
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(changes, 500).Error; err != nil {
+			return err
+		}
+		now := time.Now()
+		for _, change := range changes {
+			rating := ratings[change.UserID]
+			if err := tx.Model(rating).Updates(map[string]interface{}{
+				"rating":     rating.Rating,
+				"events":     rating.Events,
+				"updated_at": now,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		rated = len(changes)
+		return nil
+	})
+	return rated, err
+}
+
+// ListHistory retrieves a user's rating changes since the given time, oldest first
+func (r *SkillRepository) ListHistory(ctx context.Context, userID uint, since time.Time) ([]*models.SkillRatingChange, error) {
+	var changes []*models.SkillRatingChange
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at ASC, id ASC").Find(&changes).Error; err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
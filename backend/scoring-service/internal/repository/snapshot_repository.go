@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"gorm.io/gorm"
+)
+
+// SnapshotRepositoryInterface defines the contract for leaderboard round snapshots
+type SnapshotRepositoryInterface interface {
+	TakeSnapshot(ctx context.Context, contestID uint, label string, takenAt time.Time, leaderboards []*models.Leaderboard) (*models.LeaderboardRound, error)
+	GetRound(ctx context.Context, contestID, round uint) (*models.LeaderboardRound, error)
+	GetRoundByLabel(ctx context.Context, contestID uint, label string) (*models.LeaderboardRound, error)
+	LatestRound(ctx context.Context, contestID uint) (uint, error)
+	ListSnapshots(ctx context.Context, contestID, round uint) ([]*models.LeaderboardSnapshot, error)
+	GetUserSnapshot(ctx context.Context, contestID, round, userID uint) (*models.LeaderboardSnapshot, error)
+}
+
+// SnapshotRepository implements SnapshotRepositoryInterface
+type SnapshotRepository struct {
+	db *gorm.DB
+}
+
+// NewSnapshotRepository creates a new snapshot repository instance
+func NewSnapshotRepository(db *gorm.DB) SnapshotRepositoryInterface {
+	return &SnapshotRepository{db: db}
+}
+
+// TakeSnapshot stores the given standings as the contest's next round. An empty label names
+// the round by its number.
+func (r *SnapshotRepository) TakeSnapshot(ctx context.Context, contestID uint, label string, takenAt time.Time, leaderboards []*models.Leaderboard) (*models.LeaderboardRound, error) {
+	var round *models.LeaderboardRound
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest uint
+		if err := tx.Model(&models.LeaderboardRound{}).
+			Select("COALESCE(MAX(round), 0)").
+			Where("contest_id = ?", contestID).
+			Scan(&latest).Error; err != nil {
+			return err
+		}
+
+		round = &models.LeaderboardRound{
+			ContestID: contestID,
+			Round:     latest + 1,
+			Label:     label,
+			TakenAt:   takenAt,
+		}
+		if round.Label == "" {
+			round.Label = fmt.Sprintf("Round %d", round.Round)
+		}
+		if err := tx.Create(round).Error; err != nil {
+			return err
+		}
+
+		if len(leaderboards) == 0 {
+			return nil
+		}
+		snapshots := make([]*models.LeaderboardSnapshot, len(leaderboards))
+		for i, leaderboard := range leaderboards {
+			snapshots[i] = &models.LeaderboardSnapshot{
+				ContestID:   contestID,
+				Round:       round.Round,
+				UserID:      leaderboard.UserID,
+				Rank:        leaderboard.Rank,
+				TotalPoints: leaderboard.TotalPoints,
+			}
+		}
+		return tx.CreateInBatches(snapshots, 500).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return round, nil
+}
+
+// GetRound retrieves a contest's round, the latest one for round 0, or nil if there is none
+func (r *SnapshotRepository) GetRound(ctx context.Context, contestID, round uint) (*models.LeaderboardRound, error) {
+	query := r.db.WithContext(ctx).Where("contest_id = ?", contestID)
+	if round > 0 {
+		query = query.Where("round = ?", round)
+	}
+
+	var found models.LeaderboardRound
+	if err := query.Order("round DESC").First(&found).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
+// GetRoundByLabel retrieves the round snapshotted under a label, or nil if there is none
+func (r *SnapshotRepository) GetRoundByLabel(ctx context.Context, contestID uint, label string) (*models.LeaderboardRound, error) {
+	var found models.LeaderboardRound
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND label = ?", contestID, label).First(&found).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &found, nil
+}
+
+// LatestRound returns the number of a contest's latest round, 0 before the first snapshot
+func (r *SnapshotRepository) LatestRound(ctx context.Context, contestID uint) (uint, error) {
+	var latest uint
+	err := r.db.WithContext(ctx).Model(&models.LeaderboardRound{}).
+		Select("COALESCE(MAX(round), 0)").
+		Where("contest_id = ?", contestID).
+		Scan(&latest).Error
+	return latest, err
+}
+
+// ListSnapshots retrieves the standings of a contest's round ordered by rank
+func (r *SnapshotRepository) ListSnapshots(ctx context.Context, contestID, round uint) ([]*models.LeaderboardSnapshot, error) {
+	var snapshots []*models.LeaderboardSnapshot
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND round = ?", contestID, round).
+		Order("rank ASC, user_id ASC").Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetUserSnapshot retrieves a user's standing in a round, or nil if they were not on the leaderboard yet
+func (r *SnapshotRepository) GetUserSnapshot(ctx context.Context, contestID, round, userID uint) (*models.LeaderboardSnapshot, error) {
+	var snapshot models.LeaderboardSnapshot
+	if err := r.db.WithContext(ctx).Where("contest_id = ? AND round = ? AND user_id = ?", contestID, round, userID).
+		First(&snapshot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &snapshot, nil
+}
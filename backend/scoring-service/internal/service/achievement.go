@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	notificationpb "github.com/sports-prediction-contests/shared/proto/notification"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ListAchievements returns the badge catalog
+func (s *ScoringService) ListAchievements(ctx context.Context, req *pb.ListAchievementsRequest) (*pb.ListAchievementsResponse, error) {
+	achievements := make([]*pb.Achievement, len(models.Achievements))
+	for i, achievement := range models.Achievements {
+		achievements[i] = achievementToProto(achievement)
+	}
+
+	return &pb.ListAchievementsResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Achievements retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Achievements: achievements,
+	}, nil
+}
+
+// GetUserAchievements returns a user's progress towards every badge. It only reads: a badge
+// earned by something that did not trigger an evaluation, such as a contest ending, shows full
+// progress and is unlocked at the user's next evaluation.
+func (s *ScoringService) GetUserAchievements(ctx context.Context, req *pb.GetUserAchievementsRequest) (*pb.GetUserAchievementsResponse, error) {
+	if req.UserId == 0 {
+		return &pb.GetUserAchievementsResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "User ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	progress, err := s.achievementRepo.GetProgress(ctx, uint(req.UserId))
+	var unlocked []*models.UserAchievement
+	if err == nil {
+		unlocked, err = s.achievementRepo.ListByUser(ctx, uint(req.UserId))
+	}
+	if err != nil {
+		log.Printf("[ERROR] Failed to get achievements of user %d: %v", req.UserId, err)
+		return &pb.GetUserAchievementsResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve achievements",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	unlockedAt := make(map[string]time.Time, len(unlocked))
+	for _, achievement := range unlocked {
+		unlockedAt[achievement.Code] = achievement.UnlockedAt
+	}
+
+	achievements := make([]*pb.UserAchievement, len(models.Achievements))
+	for i, achievement := range models.Achievements {
+		achievements[i] = &pb.UserAchievement{
+			Achievement: achievementToProto(achievement),
+			Progress:    uint32(achievement.Progress(progress)),
+		}
+		if at, ok := unlockedAt[achievement.Code]; ok {
+			achievements[i].Unlocked = true
+			achievements[i].UnlockedAt = timestamppb.New(at)
+			achievements[i].Progress = uint32(achievement.Threshold)
+		}
+	}
+
+	return &pb.GetUserAchievementsResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Achievements retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Achievements:  achievements,
+		UnlockedCount: uint32(len(unlocked)),
+	}, nil
+}
+
+// EvaluateAchievements checks users' badges after an event outside scoring, such as a
+// completed challenge, and unlocks the ones they have earned
+func (s *ScoringService) EvaluateAchievements(ctx context.Context, req *pb.EvaluateAchievementsRequest) (*pb.EvaluateAchievementsResponse, error) {
+	if len(req.UserIds) == 0 {
+		return &pb.EvaluateAchievementsResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "At least one user ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	var unlocked []*pb.UserAchievement
+	var failed int
+	for _, userID := range req.UserIds {
+		_, earned, err := s.evaluateAchievements(ctx, uint(userID))
+		if err != nil {
+			log.Printf("[ERROR] Failed to evaluate achievements of user %d: %v", userID, err)
+			failed++
+			continue
+		}
+		for _, achievement := range earned {
+			unlocked = append(unlocked, &pb.UserAchievement{
+				Achievement: achievementToProto(achievement),
+				Unlocked:    true,
+				UnlockedAt:  timestamppb.Now(),
+				Progress:    uint32(achievement.Threshold),
+			})
+		}
+	}
+
+	if failed > 0 {
+		return &pb.EvaluateAchievementsResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   fmt.Sprintf("Failed to evaluate achievements of %d users", failed),
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+			Unlocked: unlocked,
+		}, nil
+	}
+
+	return &pb.EvaluateAchievementsResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   fmt.Sprintf("Unlocked %d achievements", len(unlocked)),
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Unlocked: unlocked,
+	}, nil
+}
+
+// evaluateAchievements unlocks the badges a user has earned but not yet received, notifying
+// them of each through the notification service, and returns the user's progress with the badges this call unlocked
+func (s *ScoringService) evaluateAchievements(ctx context.Context, userID uint) (models.AchievementProgress, []models.Achievement, error) {
+	progress, err := s.achievementRepo.GetProgress(ctx, userID)
+	if err != nil {
+		return progress, nil, err
+	}
+	existing, err := s.achievementRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return progress, nil, err
+	}
+	have := make(map[string]bool, len(existing))
+	for _, achievement := range existing {
+		have[achievement.Code] = true
+	}
+
+	var unlocked []models.Achievement
+	for _, achievement := range models.NewlyEarned(progress, have) {
+		ok, err := s.achievementRepo.Unlock(ctx,
+			&models.UserAchievement{UserID: userID, Code: achievement.Code, UnlockedAt: time.Now()})
+		if err != nil {
+			return progress, unlocked, err
+		}
+		if !ok {
+			continue
+		}
+		log.Printf("[INFO] User %d unlocked achievement %s", userID, achievement.Code)
+		unlocked = append(unlocked, achievement)
+
+		// The badge stays unlocked even if the notification cannot be delivered
+		data, _ := json.Marshal(map[string]interface{}{
+			"achievement": achievement.Code,
+		})
+		if err := s.notifier.SendInApp(ctx, userID, notificationpb.NotificationType_ACHIEVEMENT_UNLOCKED,
+			fmt.Sprintf("Achievement unlocked: %s", achievement.Name), achievement.Description+".", string(data)); err != nil {
+			log.Printf("[WARN] Failed to notify user %d of achievement %s: %v", userID, achievement.Code, err)
+		}
+	}
+	return progress, unlocked, nil
+}
+
+// unlockAchievements evaluates the badges of users whose scores changed, logging failures
+// so they never fail the scoring that triggered them
+func (s *ScoringService) unlockAchievements(ctx context.Context, userIDs map[uint]bool) {
+	for userID := range userIDs {
+		if _, _, err := s.evaluateAchievements(ctx, userID); err != nil {
+			log.Printf("[WARN] Failed to evaluate achievements of user %d: %v", userID, err)
+		}
+	}
+}
+
+// addFinishedContestLeaders adds the leaders of a contest whose matches are all completed and
+// scored to the users whose badges are evaluated, since a winner may have had no pick on the
+// last match
+func (s *ScoringService) addFinishedContestLeaders(ctx context.Context, contestID uint, userIDs map[uint]bool) {
+	if err := s.checkContestFinished(ctx, contestID); err != nil {
+		if !errors.Is(err, errDrawNotFinished) {
+			log.Printf("[WARN] Failed to check whether contest %d is finished: %v", contestID, err)
+		}
+		return
+	}
+
+	leaders, err := s.leaderboardRepo.GetContestLeaderboard(ctx, contestID, 10)
+	if err != nil {
+		log.Printf("[WARN] Failed to load leaders of contest %d: %v", contestID, err)
+		return
+	}
+	for _, entry := range leaders {
+		if entry.Rank == 1 {
+			userIDs[entry.UserID] = true
+		}
+	}
+}
+
+// achievementToProto converts a catalog badge to protobuf
+func achievementToProto(achievement models.Achievement) *pb.Achievement {
+	return &pb.Achievement{
+		Code:        achievement.Code,
+		Name:        achievement.Name,
+		Description: achievement.Description,
+		Threshold:   uint32(achievement.Threshold),
+	}
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// bracketRemainingPoints returns the most points each participant of a bracket contest can
+// still earn, keyed by user. It returns nil for other contest types.
+func (s *LeaderboardService) bracketRemainingPoints(ctx context.Context, contestID uint) (map[uint]float64, error) {
+	rulesJSON, err := s.settlementRepo.GetContestRules(ctx, contestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contest rules: %w", err)
+	}
+	rules := scoring.ParseRulesOrDefault(rulesJSON)
+	if rules.Type != scoring.ContestTypeBracket {
+		return nil, nil
+	}
+
+	events, err := s.settlementRepo.ListEvents(ctx, rules.Bracket.EventIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bracket events: %w", err)
+	}
+
+	results := make(map[string]scoring.BracketSlotResult)
+	for _, event := range events {
+		if !event.IsCompleted() || event.ResultData == "" {
+			continue
+		}
+		result, err := scoring.ParseResult(event.ResultData)
+		if err != nil {
+			continue
+		}
+		if result.HomeTeam == "" && result.AwayTeam == "" {
+			result.HomeTeam, result.AwayTeam = event.HomeTeam, event.AwayTeam
+		}
+		slot, ok := rules.Bracket.SlotByEvent(event.ID)
+		if !ok || result.WinningTeam() == "" {
+			continue
+		}
+		results[slot.ID] = scoring.BracketSlotResult{Winner: result.WinningTeam(), Loser: result.LosingTeam()}
+	}
+
+	predictions, err := s.settlementRepo.ListContestPredictions(ctx, contestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bracket picks: %w", err)
+	}
+
+	picks := make(map[uint]map[string]string)
+	for _, prediction := range predictions {
+		pick, err := scoring.ParsePrediction(prediction.PredictionData)
+		if err != nil || pick.Slot == "" {
+			continue
+		}
+		if picks[prediction.UserID] == nil {
+			picks[prediction.UserID] = make(map[string]string)
+		}
+		picks[prediction.UserID][pick.Slot] = pick.Team
+	}
+
+	remaining := make(map[uint]float64, len(picks))
+	for userID, userPicks := range picks {
+		remaining[userID] = rules.Bracket.MaxRemainingPoints(userPicks, results)
+	}
+	return remaining, nil
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// ruleManual marks scores whose base points were supplied by the caller
+	ruleManual = "manual"
+	// ruleUnscorable marks predictions that could not be evaluated and were scored zero
+	ruleUnscorable = "unscorable"
+	// ruleVoid marks props predictions whose every pick was voided or pushed
+	ruleVoid = models.RuleVoid
+)
+
+// GetScoreBreakdown explains how the score of a prediction was calculated
+func (s *ScoringService) GetScoreBreakdown(ctx context.Context, req *pb.GetScoreBreakdownRequest) (*pb.GetScoreBreakdownResponse, error) {
+	if req.PredictionId == 0 {
+		return &pb.GetScoreBreakdownResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Prediction ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	score, err := s.scoreRepo.GetByPrediction(ctx, uint(req.PredictionId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get score for prediction %d: %v", req.PredictionId, err)
+		return &pb.GetScoreBreakdownResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Score not found for prediction",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.GetScoreBreakdownResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Score breakdown retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Breakdown: s.breakdownToProto(score),
+	}, nil
+}
+
+// breakdownToProto converts a Score model to a score breakdown message
+func (s *ScoringService) breakdownToProto(score *models.Score) *pb.ScoreBreakdown {
+	return &pb.ScoreBreakdown{
+		ScoreId:          uint32(score.ID),
+		PredictionId:     uint32(score.PredictionID),
+		ContestId:        uint32(score.ContestID),
+		UserId:           uint32(score.UserID),
+		BasePoints:       score.GetBasePoints(),
+		RuleMatched:      score.RuleMatched,
+		StreakMultiplier: score.StreakMultiplier,
+		TimeCoefficient:  score.TimeCoefficient,
+		TimeTier:         score.TimeTier,
+		FinalPoints:      score.Points,
+		RulesVersion:     score.RulesVersion,
+		Details:          score.Details,
+		ScoredAt:         timestamppb.New(score.ScoredAt),
+	}
+}
+
+// ruleFromDetails extracts the scoring rule that produced the points from calculator details
+func ruleFromDetails(details map[string]interface{}) string {
+	if _, ok := details["error"]; ok {
+		return ruleUnscorable
+	}
+	if matchType, ok := details["match_type"].(string); ok {
+		return matchType
+	}
+	if _, ok := details["event_results"]; ok {
+		return "risky"
+	}
+	if _, ok := details["props_results"]; ok {
+		voided, _ := details["void_props"].(int)
+		if total, ok := details["total_props"].(int); ok && total > 0 && voided == total {
+			return ruleVoid
+		}
+		return "props"
+	}
+	return ""
+}
+
+// detailsToJSON serializes calculator details for storage, dropping them if they cannot be encoded
+func detailsToJSON(details map[string]interface{}) string {
+	if len(details) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(details)
+	if err != nil {
+		log.Printf("[WARN] Failed to encode score details: %v", err)
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/auth"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// contestUser identifies a user's standing in one contest
+type contestUser struct {
+	contestID uint
+	userID    uint
+}
+
+// RescoreEvent re-evaluates every scored prediction of an event against its current result,
+// replays the streak history of each affected user in chain order, splits settled pool draws
+// again, refreshes leaderboard totals and ranks, and records an audit entry for every user
+// whose total changed. Re-running with an unchanged result is a no-op.
+func (s *ScoringService) RescoreEvent(ctx context.Context, req *pb.RescoreEventRequest) (*pb.RescoreEventResponse, error) {
+	if req.EventId == 0 {
+		return &pb.RescoreEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	event, err := s.settlementRepo.GetEvent(ctx, uint(req.EventId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get event %d for rescoring: %v", req.EventId, err)
+		return &pb.RescoreEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event not found",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if !event.IsCompleted() || event.ResultData == "" {
+		return &pb.RescoreEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event must be completed with result data before rescoring",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	resultData, err := s.buildSettlementResult(ctx, event)
+	if err != nil {
+		log.Printf("[ERROR] Failed to prepare result for event %d: %v", event.ID, err)
+		return &pb.RescoreEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Invalid event result data",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	predictions, err := s.settlementRepo.ListScoredPredictions(ctx, event.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to list scored predictions for event %d: %v", event.ID, err)
+		return &pb.RescoreEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to load scored predictions",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	// Capture totals before anything changes so the audit reflects the whole correction
+	affected := make([]contestUser, 0)
+	previousTotals := make(map[contestUser]float64)
+	for _, prediction := range predictions {
+		key := contestUser{contestID: prediction.ContestID, userID: prediction.UserID}
+		if _, seen := previousTotals[key]; seen {
+			continue
+		}
+		total, err := s.userTotal(ctx, key.contestID, key.userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get total points for user %d in contest %d: %v", key.userID, key.contestID, err)
+			return &pb.RescoreEventResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Failed to load current totals",
+					Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+		previousTotals[key] = total
+		affected = append(affected, key)
+	}
+
+	var rescored, failed uint32
+	rulesByContest := make(map[uint]*models.SettlementContestRules)
+	for _, prediction := range predictions {
+		changed, err := s.reversePredictionScore(ctx, prediction, event.EventDate, resultData, rulesByContest)
+		if err != nil {
+			log.Printf("[ERROR] Failed to rescore prediction %d: %v", prediction.ID, err)
+			failed++
+			continue
+		}
+		if changed {
+			rescored++
+		}
+	}
+
+	correctedBy, _ := auth.GetUserIDFromContext(ctx)
+	auditEntries := make([]*pb.ScoreAuditEntry, 0)
+	touchedContests := make(map[uint]bool)
+
+	// Every user with a score on this event is replayed, so an interrupted run heals on retry
+	replayed := make([]contestUser, 0, len(affected))
+	contests := make(map[uint]bool)
+	for _, key := range affected {
+		if err := s.rebuildUserChain(ctx, key.contestID, key.userID); err != nil {
+			log.Printf("[ERROR] Failed to replay streak for user %d in contest %d: %v", key.userID, key.contestID, err)
+			failed++
+			continue
+		}
+		replayed = append(replayed, key)
+		contests[key.contestID] = true
+	}
+
+	// Settled pool draws are split again so payouts follow the corrected picks
+	for contestID := range contests {
+		if err := s.resettleDraw(ctx, contestID); err != nil {
+			log.Printf("[ERROR] Failed to resettle draw for contest %d: %v", contestID, err)
+			failed++
+		}
+	}
+
+	for _, key := range replayed {
+		newTotal, err := s.userTotal(ctx, key.contestID, key.userID)
+		if err != nil {
+			log.Printf("[ERROR] Failed to get total points for user %d in contest %d: %v", key.userID, key.contestID, err)
+			failed++
+			continue
+		}
+
+		previousTotal := previousTotals[key]
+		if newTotal == previousTotal {
+			continue
+		}
+		touchedContests[key.contestID] = true
+
+		audit := &models.ScoreAudit{
+			EventID:        event.ID,
+			ContestID:      key.contestID,
+			UserID:         key.userID,
+			PreviousPoints: previousTotal,
+			NewPoints:      newTotal,
+			Reason:         req.Reason,
+			PreviousResult: req.PreviousResultData,
+			NewResult:      event.ResultData,
+			CorrectedBy:    correctedBy,
+		}
+		if err := s.auditRepo.Create(ctx, audit); err != nil {
+			log.Printf("[WARN] Failed to write score audit for user %d in contest %d: %v", key.userID, key.contestID, err)
+		}
+		auditEntries = append(auditEntries, s.auditToProto(audit))
+	}
+
+	for contestID := range touchedContests {
+		if err := s.recalculateRanks(ctx, contestID); err != nil {
+			log.Printf("[WARN] Failed to recalculate ranks for contest %d: %v", contestID, err)
+		}
+	}
+
+	log.Printf("[INFO] Event %d rescored: rescored=%d, users_changed=%d, failed=%d", event.ID, rescored, len(auditEntries), failed)
+
+	return &pb.RescoreEventResponse{
+		Response: &common.Response{
+			Success:   failed == 0,
+			Message:   fmt.Sprintf("Rescored %d predictions, %d user totals changed (%d failed)", rescored, len(auditEntries), failed),
+			Code:      settlementCode(failed),
+			Timestamp: timestamppb.Now(),
+		},
+		RescoredCount: rescored,
+		AuditEntries:  auditEntries,
+	}, nil
+}
+
+// reversePredictionScore recomputes base points and the breakdown for an already scored
+// prediction and stores them if they changed, reporting whether the base points changed.
+// Streak multipliers are fixed up afterwards by rebuildUserChain.
+func (s *ScoringService) reversePredictionScore(ctx context.Context, prediction *models.SettlementPrediction, eventDate time.Time, resultData string, rulesByContest map[uint]*models.SettlementContestRules) (bool, error) {
+	score, err := s.scoreRepo.GetByPrediction(ctx, prediction.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get score: %w", err)
+	}
+
+	calc, err := s.calculateBasePoints(ctx, prediction, eventDate, resultData, rulesByContest)
+	if err != nil {
+		return false, err
+	}
+
+	// The breakdown is refreshed even when points are unchanged, e.g. a different rule
+	// yielding the same points, but only a points change counts as rescored
+	details := detailsToJSON(calc.Details)
+	pointsChanged := calc.Points != score.GetBasePoints()
+	if !pointsChanged && calc.RuleMatched == score.RuleMatched &&
+		calc.RulesVersion == score.RulesVersion && details == score.Details {
+		return false, nil
+	}
+
+	score.RuleMatched = calc.RuleMatched
+	score.RulesVersion = calc.RulesVersion
+	score.Details = details
+	score.ApplyMultipliers(calc.Points, score.StreakMultiplier)
+	if err := s.scoreRepo.Update(ctx, score); err != nil {
+		return false, fmt.Errorf("failed to update score: %w", err)
+	}
+	return pointsChanged, nil
+}
+
+// auditToProto converts a ScoreAudit model to protobuf message
+func (s *ScoringService) auditToProto(audit *models.ScoreAudit) *pb.ScoreAuditEntry {
+	return &pb.ScoreAuditEntry{
+		Id:             uint32(audit.ID),
+		EventId:        uint32(audit.EventID),
+		ContestId:      uint32(audit.ContestID),
+		UserId:         uint32(audit.UserID),
+		PreviousPoints: audit.PreviousPoints,
+		NewPoints:      audit.NewPoints,
+		Reason:         audit.Reason,
+		CorrectedBy:    uint32(audit.CorrectedBy),
+		CreatedAt:      timestamppb.New(audit.CreatedAt),
+	}
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/scoring-service/internal/repository"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Fake repositories keep state in memory; methods a test does not need are left to the
+// embedded interface and panic if called
+
+type fakeScoreRepository struct {
+	repository.ScoreRepositoryInterface
+	scores    map[uint]*models.Score
+	eventDate map[uint]time.Time // by prediction ID
+	nextID    uint
+}
+
+func (r *fakeScoreRepository) Create(ctx context.Context, score *models.Score) error {
+	for _, existing := range r.scores {
+		if existing.PredictionID == score.PredictionID {
+			return errors.New("duplicate prediction")
+		}
+	}
+	r.nextID++
+	score.ID = r.nextID
+	stored := *score
+	r.scores[score.ID] = &stored
+	return nil
+}
+
+func (r *fakeScoreRepository) GetByID(ctx context.Context, id uint) (*models.Score, error) {
+	if score, ok := r.scores[id]; ok {
+		found := *score
+		return &found, nil
+	}
+	return nil, errors.New("score not found")
+}
+
+func (r *fakeScoreRepository) GetByPrediction(ctx context.Context, predictionID uint) (*models.Score, error) {
+	for _, score := range r.scores {
+		if score.PredictionID == predictionID {
+			found := *score
+			return &found, nil
+		}
+	}
+	return nil, errors.New("score not found")
+}
+
+func (r *fakeScoreRepository) Update(ctx context.Context, score *models.Score) error {
+	stored := *score
+	r.scores[score.ID] = &stored
+	return nil
+}
+
+func (r *fakeScoreRepository) ListChainByContestAndUser(ctx context.Context, contestID, userID uint) ([]*models.ScoreHistory, error) {
+	var chain []*models.ScoreHistory
+	for _, score := range r.scores {
+		if score.ContestID == contestID && score.UserID == userID {
+			eventDate := r.eventDate[score.PredictionID]
+			chain = append(chain, &models.ScoreHistory{Score: *score, SubmittedAt: &eventDate, EventDate: &eventDate})
+		}
+	}
+	sort.Slice(chain, func(i, j int) bool {
+		di, dj := r.eventDate[chain[i].PredictionID], r.eventDate[chain[j].PredictionID]
+		if !di.Equal(dj) {
+			return di.Before(dj)
+		}
+		return chain[i].PredictionID < chain[j].PredictionID
+	})
+	return chain, nil
+}
+
+func (r *fakeScoreRepository) GetTotalPointsByContestAndUser(ctx context.Context, contestID, userID uint) (float64, error) {
+	var total float64
+	for _, score := range r.scores {
+		if score.ContestID == contestID && score.UserID == userID {
+			total += score.Points
+		}
+	}
+	return total, nil
+}
+
+type fakeStreakRepository struct {
+	repository.StreakRepositoryInterface
+	streaks map[[2]uint]models.UserStreak
+}
+
+func (r *fakeStreakRepository) GetOrCreate(ctx context.Context, contestID, userID uint) (*models.UserStreak, error) {
+	streak, ok := r.streaks[[2]uint{contestID, userID}]
+	if !ok {
+		streak = models.UserStreak{ContestID: contestID, UserID: userID}
+	}
+	return &streak, nil
+}
+
+func (r *fakeStreakRepository) Update(ctx context.Context, streak *models.UserStreak) error {
+	r.streaks[[2]uint{streak.ContestID, streak.UserID}] = *streak
+	return nil
+}
+
+type fakeLeaderboardRepository struct {
+	repository.LeaderboardRepositoryInterface
+	totals map[[2]uint]float64
+}
+
+func (r *fakeLeaderboardRepository) UpsertUserScore(ctx context.Context, contestID, userID uint, totalPoints float64) error {
+	r.totals[[2]uint{contestID, userID}] = totalPoints
+	return nil
+}
+
+func (r *fakeLeaderboardRepository) RecalculateRanks(ctx context.Context, contestID uint, rules *scoring.LeaderboardRules) error {
+	return nil
+}
+
+type fakeAuditRepository struct {
+	repository.AuditRepositoryInterface
+	audits []*models.ScoreAudit
+}
+
+func (r *fakeAuditRepository) Create(ctx context.Context, audit *models.ScoreAudit) error {
+	r.audits = append(r.audits, audit)
+	return nil
+}
+
+// fakeAchievementRepository reports no progress, so no badges are unlocked
+type fakeAchievementRepository struct {
+	repository.AchievementRepositoryInterface
+}
+
+func (r *fakeAchievementRepository) GetProgress(ctx context.Context, userID uint) (models.AchievementProgress, error) {
+	return models.AchievementProgress{}, nil
+}
+
+func (r *fakeAchievementRepository) ListByUser(ctx context.Context, userID uint) ([]*models.UserAchievement, error) {
+	return nil, nil
+}
+
+type fakeSettlementRepository struct {
+	repository.SettlementRepositoryInterface
+	events      map[uint]*models.SettlementEvent
+	predictions []*models.SettlementPrediction
+	rules       string
+	versions    []*models.SettlementRulesVersion
+}
+
+func (r *fakeSettlementRepository) GetEvent(ctx context.Context, eventID uint) (*models.SettlementEvent, error) {
+	if event, ok := r.events[eventID]; ok {
+		return event, nil
+	}
+	return nil, errors.New("event not found")
+}
+
+func (r *fakeSettlementRepository) GetRiskyOutcomes(ctx context.Context, eventID uint) (map[string]bool, error) {
+	return nil, nil
+}
+
+func (r *fakeSettlementRepository) ListScoredPredictions(ctx context.Context, eventID uint) ([]*models.SettlementPrediction, error) {
+	var predictions []*models.SettlementPrediction
+	for _, prediction := range r.predictions {
+		if prediction.EventID == eventID {
+			predictions = append(predictions, prediction)
+		}
+	}
+	return predictions, nil
+}
+
+func (r *fakeSettlementRepository) GetContestRules(ctx context.Context, contestID uint) (string, error) {
+	return r.rules, nil
+}
+
+func (r *fakeSettlementRepository) GetContestRulesHistory(ctx context.Context, contestID uint) (*models.SettlementContestRules, error) {
+	return &models.SettlementContestRules{Rules: r.rules, Versions: r.versions}, nil
+}
+
+// newCorrectionFixture builds a contest whose single participant predicted three matches,
+// hitting the first and last kickoff and missing the middle one
+func newCorrectionFixture() (*ScoringService, *fakeScoreRepository, *fakeSettlementRepository, *fakeAuditRepository) {
+	kickoff := time.Date(2026, 3, 1, 15, 0, 0, 0, time.UTC)
+	settlement := &fakeSettlementRepository{
+		events: map[uint]*models.SettlementEvent{
+			1: {ID: 1, Status: "completed", EventDate: kickoff, ResultData: `{"home_score":2,"away_score":1}`},
+			2: {ID: 2, Status: "completed", EventDate: kickoff.Add(24 * time.Hour), ResultData: `{"home_score":0,"away_score":3}`},
+			3: {ID: 3, Status: "completed", EventDate: kickoff.Add(48 * time.Hour), ResultData: `{"home_score":1,"away_score":1}`},
+		},
+		predictions: []*models.SettlementPrediction{
+			{ID: 11, ContestID: 1, UserID: 7, EventID: 1, PredictionData: `{"type":"exact_score","home_score":2,"away_score":1}`, SubmittedAt: kickoff.Add(-2 * time.Hour)},
+			{ID: 12, ContestID: 1, UserID: 7, EventID: 2, PredictionData: `{"type":"exact_score","home_score":2,"away_score":0}`, SubmittedAt: kickoff.Add(-time.Hour)},
+			{ID: 13, ContestID: 1, UserID: 7, EventID: 3, PredictionData: `{"type":"exact_score","home_score":1,"away_score":1}`, SubmittedAt: kickoff.Add(-30 * time.Minute)},
+		},
+		rules: `{"type":"standard","streak":{"ladder":[{"length":2,"multiplier":1.5}]}}`,
+	}
+	scores := &fakeScoreRepository{scores: make(map[uint]*models.Score), eventDate: make(map[uint]time.Time)}
+	for _, prediction := range settlement.predictions {
+		scores.eventDate[prediction.ID] = settlement.events[prediction.EventID].EventDate
+	}
+	audits := &fakeAuditRepository{}
+
+	s := NewScoringService(scores, &fakeLeaderboardRepository{totals: make(map[[2]uint]float64)},
+		&fakeStreakRepository{streaks: make(map[[2]uint]models.UserStreak)}, nil, settlement, audits, nil, nil, nil, nil, nil)
+	return s, scores, settlement, audits
+}
+
+func TestSettlementFollowsKickoffOrder(t *testing.T) {
+	ctx := context.Background()
+	s, scores, settlement, _ := newCorrectionFixture()
+
+	// The first match is settled last, e.g. after a delayed result feed
+	rulesByContest := make(map[uint]*models.SettlementContestRules)
+	for _, i := range []int{1, 2, 0} {
+		prediction := settlement.predictions[i]
+		event := settlement.events[prediction.EventID]
+		if _, _, err := s.settlePrediction(ctx, prediction, event, event.ResultData, rulesByContest); err != nil {
+			t.Fatalf("settle prediction %d: %v", prediction.ID, err)
+		}
+	}
+
+	// In kickoff order the miss in the middle breaks the streak, so nothing reaches the ladder
+	for _, score := range scores.scores {
+		if score.StreakMultiplier != 1.0 {
+			t.Errorf("prediction %d: expected streak multiplier 1.0, got %.2f", score.PredictionID, score.StreakMultiplier)
+		}
+	}
+}
+
+func TestNoOpRescoreLeavesOtherScoresUnchanged(t *testing.T) {
+	ctx := context.Background()
+	s, scores, settlement, audits := newCorrectionFixture()
+
+	rulesByContest := make(map[uint]*models.SettlementContestRules)
+	for _, i := range []int{1, 2, 0} {
+		prediction := settlement.predictions[i]
+		event := settlement.events[prediction.EventID]
+		if _, _, err := s.settlePrediction(ctx, prediction, event, event.ResultData, rulesByContest); err != nil {
+			t.Fatalf("settle prediction %d: %v", prediction.ID, err)
+		}
+	}
+
+	before := make(map[uint]models.Score, len(scores.scores))
+	for id, score := range scores.scores {
+		before[id] = *score
+	}
+
+	resp, err := s.RescoreEvent(ctx, &pb.RescoreEventRequest{EventId: 3, Reason: "feed replay"})
+	if err != nil || !resp.Response.Success {
+		t.Fatalf("rescore event: %v %v", err, resp.GetResponse().GetMessage())
+	}
+	if resp.RescoredCount != 0 {
+		t.Errorf("expected no rescored predictions, got %d", resp.RescoredCount)
+	}
+	if len(audits.audits) != 0 {
+		t.Errorf("expected no audit entries, got %d", len(audits.audits))
+	}
+
+	for id, score := range scores.scores {
+		previous := before[id]
+		if score.Points != previous.Points || score.BasePoints != previous.BasePoints ||
+			score.StreakMultiplier != previous.StreakMultiplier || score.TimeCoefficient != previous.TimeCoefficient {
+			t.Errorf("prediction %d changed: %.2f (x%.2f) -> %.2f (x%.2f)", score.PredictionID,
+				previous.Points, previous.StreakMultiplier, score.Points, score.StreakMultiplier)
+		}
+	}
+}
+
+func TestRescoreReplaysChainWithRulesVersionOfEachScore(t *testing.T) {
+	ctx := context.Background()
+	s, scores, settlement, _ := newCorrectionFixture()
+
+	rulesByContest := make(map[uint]*models.SettlementContestRules)
+	for _, prediction := range settlement.predictions {
+		event := settlement.events[prediction.EventID]
+		if _, _, err := s.settlePrediction(ctx, prediction, event, event.ResultData, rulesByContest); err != nil {
+			t.Fatalf("settle prediction %d: %v", prediction.ID, err)
+		}
+	}
+
+	// A later version with a steeper ladder must not reprice scores made under the first one
+	kickoff := settlement.events[1].EventDate
+	settlement.versions = []*models.SettlementRulesVersion{
+		{Version: 1, Rules: settlement.rules, EffectiveFrom: kickoff.Add(-72 * time.Hour)},
+		{Version: 2, Rules: `{"type":"standard","streak":{"ladder":[{"length":2,"multiplier":3}]}}`, EffectiveFrom: kickoff.Add(72 * time.Hour)},
+	}
+	settlement.rules = settlement.versions[1].Rules
+
+	miss, err := scores.GetByPrediction(ctx, 12)
+	if err != nil {
+		t.Fatalf("get score: %v", err)
+	}
+	if _, err := s.rescore(ctx, miss, 5, time.Time{}, time.Time{}); err != nil {
+		t.Fatalf("rescore: %v", err)
+	}
+
+	for _, score := range scores.scores {
+		want := 1.5
+		if score.PredictionID == 11 {
+			want = 1.0
+		}
+		if score.StreakMultiplier != want {
+			t.Errorf("prediction %d: expected streak multiplier %.2f, got %.2f", score.PredictionID, want, score.StreakMultiplier)
+		}
+	}
+}
+
+func TestCreateScoreUsesRulesVersionOfPrediction(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "user_id", uint(1))
+	s, scores, settlement, _ := newCorrectionFixture()
+
+	// The prediction was submitted under a version without the early bonus; the current
+	// rules would pay it double
+	kickoff := settlement.events[1].EventDate
+	settlement.versions = []*models.SettlementRulesVersion{
+		{Version: 1, Rules: `{"type":"standard","time_coefficient":{"disabled":true}}`, EffectiveFrom: kickoff.Add(-30 * 24 * time.Hour)},
+		{Version: 2, Rules: `{"type":"standard"}`, EffectiveFrom: kickoff.Add(-24 * time.Hour)},
+	}
+	settlement.rules = settlement.versions[1].Rules
+	scores.eventDate[99] = kickoff
+	s.achievementRepo = &fakeAchievementRepository{}
+
+	resp, err := s.CreateScore(ctx, &pb.CreateScoreRequest{
+		UserId:       8,
+		ContestId:    1,
+		PredictionId: 99,
+		Points:       3,
+		SubmittedAt:  timestamppb.New(kickoff.Add(-8 * 24 * time.Hour)),
+		EventDate:    timestamppb.New(kickoff),
+	})
+	if err != nil || !resp.Response.Success {
+		t.Fatalf("create score: %v %v", err, resp.GetResponse().GetMessage())
+	}
+
+	if resp.Score.TimeCoefficient != 1.0 {
+		t.Errorf("expected time coefficient 1.00 under version 1, got %.2f", resp.Score.TimeCoefficient)
+	}
+	if want := settlement.versions[0].Label(); scores.scores[1].RulesVersion != want {
+		t.Errorf("expected rules version %s, got %s", want, scores.scores[1].RulesVersion)
+	}
+}
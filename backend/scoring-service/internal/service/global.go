@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetGlobalLeaderboard ranks users across contests, optionally for one sport, league, season
+// or calendar month, with contest totals normalized so large and small contests compare
+func (s *ScoringService) GetGlobalLeaderboard(ctx context.Context, req *pb.GetGlobalLeaderboardRequest) (*pb.GetGlobalLeaderboardResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 50 // Default limit
+	}
+
+	filter := models.GlobalLeaderboardFilter{
+		SportType: req.SportType,
+		LeagueID:  uint(req.LeagueId),
+		Season:    req.Season,
+	}
+	var err error
+	if req.Month != "" {
+		filter.From, filter.To, err = models.MonthRange(req.Month)
+	}
+	if err == nil {
+		err = models.ValidateNormalization(req.Normalization)
+	}
+	if err != nil {
+		return &pb.GetGlobalLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   err.Error(),
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	totals, err := s.analyticsRepo.GetContestTotals(ctx, filter)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get contest totals: %v", err)
+		return &pb.GetGlobalLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve global leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	var ratings map[uint]float64
+	if req.Normalization == models.NormalizeRating {
+		if ratings, err = s.userRatings(ctx, totals); err != nil {
+			log.Printf("[ERROR] Failed to get skill ratings: %v", err)
+			return &pb.GetGlobalLeaderboardResponse{
+				Response: &common.Response{
+					Success:   false,
+					Message:   "Failed to retrieve global leaderboard",
+					Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+					Timestamp: timestamppb.Now(),
+				},
+			}, nil
+		}
+	}
+
+	standings := models.RankGlobal(totals, req.Normalization, int64(req.MinPredictions), ratings)
+	entries := make([]*pb.GlobalLeaderboardEntry, 0, min(limit, len(standings)))
+	for _, standing := range standings[:min(limit, len(standings))] {
+		entries = append(entries, &pb.GlobalLeaderboardEntry{
+			Rank:        uint32(standing.Rank),
+			UserId:      uint32(standing.UserID),
+			Score:       standing.Score,
+			TotalPoints: standing.TotalPoints,
+			Predictions: uint32(standing.Predictions),
+			Contests:    uint32(standing.Contests),
+		})
+	}
+
+	return &pb.GetGlobalLeaderboardResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Global leaderboard retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Entries:           entries,
+		TotalParticipants: uint32(len(standings)),
+	}, nil
+}
+
+// userRatings returns the skill ratings of the users in totals, keyed by user
+func (s *ScoringService) userRatings(ctx context.Context, totals []models.ContestTotal) (map[uint]float64, error) {
+	var userIDs []uint
+	seen := make(map[uint]bool)
+	for _, total := range totals {
+		if !seen[total.UserID] {
+			seen[total.UserID] = true
+			userIDs = append(userIDs, total.UserID)
+		}
+	}
+
+	rated, err := s.skillRepo.GetRatings(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	ratings := make(map[uint]float64, len(rated))
+	for userID, rating := range rated {
+		ratings[userID] = rating.Rating
+	}
+	return ratings, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// leaderboardAsOf rebuilds a contest's standings from the scores credited up to asOf, ranked
+// with the contest's tie-breakers
+func (s *LeaderboardService) leaderboardAsOf(ctx context.Context, contestID uint, asOf time.Time, limit int) (*pb.GetLeaderboardResponse, error) {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load rules for contest %d: %v", contestID, err)
+		return &pb.GetLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	// Survivor and pool contests rank by rounds survived and winnings rather than points
+	if rules.Type == scoring.ContestTypeSurvivor || (rules.Type == scoring.ContestTypeTotalizator && rules.Totalizator.Pool != nil) {
+		return &pb.GetLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Point-in-time standings are only available for contests ranked by points",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	history, err := s.scoreRepo.ListHistoryByContest(ctx, contestID, asOf)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load score history of contest %d: %v", contestID, err)
+		return &pb.GetLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	// Each score is replayed under the rules version that scored it
+	contestRules, err := s.settlementRepo.GetContestRulesHistory(ctx, contestID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load rules history of contest %d: %v", contestID, err)
+		return &pb.GetLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+	parsed := make(map[string]*scoring.ContestRules)
+	replayed := models.ReplayScores(history, func(entry *models.ScoreHistory) *scoring.ContestRules {
+		return chainRules(contestRules, entry, parsed)
+	})
+	entries := replayedEntries(replayed, rules, asOf, limit)
+
+	return &pb.GetLeaderboardResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Leaderboard retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Leaderboard: &pb.Leaderboard{
+			ContestId: uint32(contestID),
+			Entries:   entries,
+			UpdatedAt: timestamppb.New(asOf),
+		},
+	}, nil
+}
+
+// replayedEntries ranks replayed standings with the contest's tie-breakers and returns up to
+// limit leaderboard entries in rank order
+func replayedEntries(replayed []*models.HistoricalStanding, rules *scoring.ContestRules, updatedAt time.Time, limit int) []*pb.LeaderboardEntry {
+	// Everyone is measured against the most predictions scored by anyone at the time
+	var matches int64
+	byUser := make(map[uint]*models.HistoricalStanding, len(replayed))
+	for _, standing := range replayed {
+		matches = max(matches, standing.Scored)
+		byUser[standing.UserID] = standing
+	}
+	standings := make([]scoring.Standing, len(replayed))
+	for i, standing := range replayed {
+		standings[i] = standing.Standing(matches)
+	}
+	ranks := rules.Leaderboard.Rank(standings)
+
+	streakRules := rules.Streak
+	if !rules.AppliesMultipliers() {
+		streakRules = &scoring.StreakRules{Disabled: true}
+	}
+
+	entries := make([]*pb.LeaderboardEntry, 0, min(limit, len(standings)))
+	for i, standing := range standings {
+		if i == limit {
+			break
+		}
+		streak := byUser[standing.UserID].Streak
+		entries = append(entries, &pb.LeaderboardEntry{
+			UserId:        uint32(standing.UserID),
+			TotalPoints:   standing.TotalPoints,
+			Rank:          uint32(ranks[i]),
+			UpdatedAt:     timestamppb.New(updatedAt),
+			CurrentStreak: uint32(streak.CurrentStreak),
+			MaxStreak:     uint32(streak.MaxStreak),
+			Multiplier:    streak.MultiplierFor(streakRules),
+		})
+	}
+	return entries
+}
+
+// GetUserPointsTimeline returns a user's cumulative points in a contest after each scored prediction
+func (s *LeaderboardService) GetUserPointsTimeline(ctx context.Context, req *pb.GetUserPointsTimelineRequest) (*pb.GetUserPointsTimelineResponse, error) {
+	if req.ContestId == 0 || req.UserId == 0 {
+		return &pb.GetUserPointsTimelineResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Contest ID and user ID are required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	scores, err := s.scoreRepo.ListByContestAndUserInScoredOrder(ctx, uint(req.ContestId), uint(req.UserId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get points timeline: %v", err)
+		return &pb.GetUserPointsTimelineResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve points timeline",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	points := make([]*pb.PointsTimelinePoint, len(scores))
+	var total float64
+	for i, score := range scores {
+		total += score.Points
+		points[i] = &pb.PointsTimelinePoint{
+			ScoredAt:     timestamppb.New(score.ScoredAt),
+			PredictionId: uint32(score.PredictionID),
+			Points:       score.Points,
+			TotalPoints:  total,
+		}
+	}
+
+	return &pb.GetUserPointsTimelineResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Points timeline retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Points: points,
+	}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sports-prediction-contests/scoring-service/internal/models"
 	"github.com/sports-prediction-contests/scoring-service/internal/repository"
 	"github.com/sports-prediction-contests/shared/auth"
+	"github.com/sports-prediction-contests/shared/scoring"
 	pb "github.com/sports-prediction-contests/shared/proto/scoring"
 	"github.com/sports-prediction-contests/shared/proto/common"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -18,14 +19,18 @@ type LeaderboardService struct {
 	leaderboardRepo repository.LeaderboardRepositoryInterface
 	scoreRepo       repository.ScoreRepositoryInterface
 	streakRepo      repository.StreakRepositoryInterface
+	settlementRepo  repository.SettlementRepositoryInterface
+	snapshotRepo    repository.SnapshotRepositoryInterface
 }
 
 // NewLeaderboardService creates a new LeaderboardService instance
-func NewLeaderboardService(leaderboardRepo repository.LeaderboardRepositoryInterface, scoreRepo repository.ScoreRepositoryInterface, streakRepo repository.StreakRepositoryInterface) *LeaderboardService {
+func NewLeaderboardService(leaderboardRepo repository.LeaderboardRepositoryInterface, scoreRepo repository.ScoreRepositoryInterface, streakRepo repository.StreakRepositoryInterface, settlementRepo repository.SettlementRepositoryInterface, snapshotRepo repository.SnapshotRepositoryInterface) *LeaderboardService {
 	return &LeaderboardService{
 		leaderboardRepo: leaderboardRepo,
 		scoreRepo:       scoreRepo,
 		streakRepo:      streakRepo,
+		settlementRepo:  settlementRepo,
+		snapshotRepo:    snapshotRepo,
 	}
 }
 
@@ -36,6 +41,10 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, req *pb.GetLead
 		limit = 50 // Default limit
 	}
 
+	if req.AsOf != nil {
+		return s.leaderboardAsOf(ctx, uint(req.ContestId), req.AsOf.AsTime(), limit)
+	}
+
 	// Get leaderboard entries
 	leaderboards, err := s.leaderboardRepo.GetContestLeaderboard(ctx, uint(uint(req.ContestId)), limit)
 	if err != nil {
@@ -50,36 +59,7 @@ func (s *LeaderboardService) GetLeaderboard(ctx context.Context, req *pb.GetLead
 		}, nil
 	}
 
-	// Convert to proto with streak data
-	entries := make([]*pb.LeaderboardEntry, len(leaderboards))
-	
-	// Batch fetch all streaks in one query to avoid N+1
-	userIDs := make([]uint, len(leaderboards))
-	for i, lb := range leaderboards {
-		userIDs[i] = lb.UserID
-	}
-	streaks, _ := s.streakRepo.GetByContestAndUsers(ctx, uint(uint(req.ContestId)), userIDs)
-	streakMap := make(map[uint]*models.UserStreak)
-	for _, streak := range streaks {
-		streakMap[streak.UserID] = streak
-	}
-	
-	for i, lb := range leaderboards {
-		entry := &pb.LeaderboardEntry{
-			UserId:      uint32(lb.UserID),
-			UserName:    "", // TODO: Fetch user name from user service
-			TotalPoints: lb.TotalPoints,
-			Rank:        uint32(lb.Rank),
-			UpdatedAt:   timestamppb.New(lb.UpdatedAt),
-		}
-		// Use pre-fetched streak data
-		if streak, ok := streakMap[lb.UserID]; ok {
-			entry.CurrentStreak = uint32(streak.CurrentStreak)
-			entry.MaxStreak = uint32(streak.MaxStreak)
-			entry.Multiplier = streak.GetMultiplier()
-		}
-		entries[i] = entry
-	}
+	entries := s.leaderboardEntries(ctx, uint(req.ContestId), leaderboards)
 
 	leaderboard := &pb.Leaderboard{
 		ContestId: req.ContestId,
@@ -114,6 +94,16 @@ func (s *LeaderboardService) GetUserRank(ctx context.Context, req *pb.GetUserRan
 		}, nil
 	}
 
+	// Movement is measured from the latest round snapshot
+	var snapshot *models.LeaderboardSnapshot
+	if latest, err := s.snapshotRepo.LatestRound(ctx, leaderboard.ContestID); err != nil {
+		log.Printf("[WARN] Failed to load latest round of contest %d: %v", leaderboard.ContestID, err)
+	} else if latest > 0 {
+		if snapshot, err = s.snapshotRepo.GetUserSnapshot(ctx, leaderboard.ContestID, latest, leaderboard.UserID); err != nil {
+			log.Printf("[WARN] Failed to load round %d standing of user %d: %v", latest, leaderboard.UserID, err)
+		}
+	}
+
 	return &pb.GetUserRankResponse{
 		Response: &common.Response{
 			Success:   true,
@@ -123,9 +113,103 @@ func (s *LeaderboardService) GetUserRank(ctx context.Context, req *pb.GetUserRan
 		},
 		Rank:        uint32(leaderboard.Rank),
 		TotalPoints: leaderboard.TotalPoints,
+		RankChange:  snapshot.RankChange(leaderboard.Rank),
+		RoundPoints: snapshot.PointsSince(leaderboard.TotalPoints),
+	}, nil
+}
+
+// GetLeaderboardAroundUser retrieves the entries either side of a user with their percentile,
+// so participants far from the top can see who they are competing with
+func (s *LeaderboardService) GetLeaderboardAroundUser(ctx context.Context, req *pb.GetLeaderboardAroundUserRequest) (*pb.GetLeaderboardAroundUserResponse, error) {
+	neighbors := int(req.Neighbors)
+	if neighbors <= 0 || neighbors > 50 {
+		neighbors = 5 // Default window
+	}
+
+	leaderboards, total, err := s.leaderboardRepo.GetAroundUser(ctx, uint(req.ContestId), uint(req.UserId), neighbors)
+	if err != nil {
+		log.Printf("[ERROR] Failed to get leaderboard around user: %v", err)
+		return &pb.GetLeaderboardAroundUserResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "User not found in leaderboard",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	var rank uint
+	for _, lb := range leaderboards {
+		if lb.UserID == uint(req.UserId) {
+			rank = lb.Rank
+		}
+	}
+
+	return &pb.GetLeaderboardAroundUserResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Leaderboard retrieved successfully",
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Entries:           s.leaderboardEntries(ctx, uint(req.ContestId), leaderboards),
+		Rank:              uint32(rank),
+		TotalParticipants: uint32(total),
+		Percentile:        scoring.Percentile(rank, total),
 	}, nil
 }
 
+// leaderboardEntries converts leaderboard rows to proto entries with each participant's streak,
+// movement since the latest round and remaining bracket points
+func (s *LeaderboardService) leaderboardEntries(ctx context.Context, contestID uint, leaderboards []*models.Leaderboard) []*pb.LeaderboardEntry {
+	entries := make([]*pb.LeaderboardEntry, len(leaderboards))
+
+	// Batch fetch all streaks in one query to avoid N+1
+	userIDs := make([]uint, len(leaderboards))
+	for i, lb := range leaderboards {
+		userIDs[i] = lb.UserID
+	}
+	streaks, _ := s.streakRepo.GetByContestAndUsers(ctx, contestID, userIDs)
+	streakMap := make(map[uint]*models.UserStreak)
+	for _, streak := range streaks {
+		streakMap[streak.UserID] = streak
+	}
+	streakRules := s.streakRules(ctx, contestID)
+
+	// Movement is measured from the latest round snapshot
+	snapshots, err := s.latestSnapshots(ctx, contestID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load latest round of contest %d: %v", contestID, err)
+	}
+
+	remainingPoints, err := s.bracketRemainingPoints(ctx, contestID)
+	if err != nil {
+		log.Printf("[WARN] Failed to calculate remaining bracket points for contest %d: %v", contestID, err)
+	}
+
+	for i, lb := range leaderboards {
+		entry := &pb.LeaderboardEntry{
+			UserId:      uint32(lb.UserID),
+			UserName:    "", // TODO: Fetch user name from user service
+			TotalPoints: lb.TotalPoints,
+			Rank:        uint32(lb.Rank),
+			UpdatedAt:   timestamppb.New(lb.UpdatedAt),
+		}
+		// Use pre-fetched streak data
+		if streak, ok := streakMap[lb.UserID]; ok {
+			entry.CurrentStreak = uint32(streak.CurrentStreak)
+			entry.MaxStreak = uint32(streak.MaxStreak)
+			entry.Multiplier = streak.MultiplierFor(streakRules)
+		}
+		entry.MaxRemainingPoints = remainingPoints[lb.UserID]
+		entry.RankChange = snapshots[lb.UserID].RankChange(lb.Rank)
+		entry.RoundPoints = snapshots[lb.UserID].PointsSince(lb.TotalPoints)
+		entries[i] = entry
+	}
+	return entries
+}
+
 // UpdateLeaderboard recalculates and updates the leaderboard for a contest
 func (s *LeaderboardService) UpdateLeaderboard(ctx context.Context, req *pb.UpdateLeaderboardRequest) (*pb.UpdateLeaderboardResponse, error) {
 	// Extract user ID from JWT token for authorization
@@ -156,7 +240,7 @@ func (s *LeaderboardService) UpdateLeaderboard(ctx context.Context, req *pb.Upda
 	}
 
 	// Update rankings
-	if err := s.leaderboardRepo.UpdateRankings(ctx, uint(req.ContestId)); err != nil {
+	if err := s.leaderboardRepo.UpdateRankings(ctx, uint(req.ContestId), s.leaderboardRules(ctx, uint(req.ContestId))); err != nil {
 		log.Printf("[ERROR] Failed to update rankings: %v", err)
 		return &pb.UpdateLeaderboardResponse{
 			Response: &common.Response{
@@ -246,7 +330,7 @@ func (s *LeaderboardService) BatchUpdateUserScores(ctx context.Context, contestI
 	}
 
 	// Recalculate rankings after batch update
-	return s.leaderboardRepo.UpdateRankings(ctx, contestID)
+	return s.leaderboardRepo.UpdateRankings(ctx, contestID, s.leaderboardRules(ctx, contestID))
 }
 
 // GetLeaderboardSize returns the number of participants in a contest leaderboard
@@ -298,6 +382,39 @@ func (s *LeaderboardService) GetUserStreak(ctx context.Context, req *pb.GetUserS
 		},
 		CurrentStreak: uint32(streak.CurrentStreak),
 		MaxStreak:     uint32(streak.MaxStreak),
-		Multiplier:    streak.GetMultiplier(),
+		Multiplier:    streak.MultiplierFor(s.streakRules(ctx, uint(req.ContestId))),
 	}, nil
 }
+
+// contestRules loads and parses a contest's rules. Contests without rules are standard.
+func (s *LeaderboardService) contestRules(ctx context.Context, contestID uint) (*scoring.ContestRules, error) {
+	rulesJSON, err := s.settlementRepo.GetContestRules(ctx, contestID)
+	if err != nil {
+		return nil, err
+	}
+	return scoring.ParseRulesOrDefault(rulesJSON), nil
+}
+
+// streakRules returns a contest's streak rules for reporting multipliers. Contests that do not
+// apply multipliers report 1.0; rules that cannot be loaded fall back to the defaults.
+func (s *LeaderboardService) streakRules(ctx context.Context, contestID uint) *scoring.StreakRules {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load rules for contest %d, using default streak multipliers: %v", contestID, err)
+		return nil
+	}
+	if !rules.AppliesMultipliers() {
+		return &scoring.StreakRules{Disabled: true}
+	}
+	return rules.Streak
+}
+
+// leaderboardRules returns a contest's tie-breakers. Rules that cannot be loaded rank by points alone.
+func (s *LeaderboardService) leaderboardRules(ctx context.Context, contestID uint) *scoring.LeaderboardRules {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load rules for contest %d, ranking by points only: %v", contestID, err)
+		return nil
+	}
+	return rules.Leaderboard
+}
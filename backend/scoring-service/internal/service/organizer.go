@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/sports-prediction-contests/shared/auth"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// authorizeOrganizer checks that the caller organizes a contest, as its creator or one of its
+// admins, returning the failure response to send otherwise. Internal callers act as the user
+// whose ID they forward.
+func (s *ScoringService) authorizeOrganizer(ctx context.Context, contestID uint) *common.Response {
+	userID, ok := auth.GetUserIDFromContext(ctx)
+	if !ok {
+		log.Printf("[ERROR] Failed to get user ID from context")
+		return &common.Response{
+			Success:   false,
+			Message:   "Authentication required",
+			Code:      int32(common.ErrorCode_UNAUTHENTICATED),
+			Timestamp: timestamppb.Now(),
+		}
+	}
+
+	organizer, err := s.settlementRepo.IsContestOrganizer(ctx, contestID, userID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to check organizers of contest %d: %v", contestID, err)
+		return &common.Response{
+			Success:   false,
+			Message:   "Failed to check permissions",
+			Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+			Timestamp: timestamppb.Now(),
+		}
+	}
+	if !organizer {
+		log.Printf("[WARN] User %d is not an organizer of contest %d", userID, contestID)
+		return &common.Response{
+			Success:   false,
+			Message:   "Permission denied",
+			Code:      int32(common.ErrorCode_PERMISSION_DENIED),
+			Timestamp: timestamppb.Now(),
+		}
+	}
+	return nil
+}
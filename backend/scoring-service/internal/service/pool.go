@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	errNotPoolContest  = errors.New("contest is not a totalizator pool draw")
+	errDrawNotFinished = errors.New("draw has unfinished matches or unscored predictions")
+)
+
+// SettleTotalizatorDraw splits a finished totalizator draw's pool among participants by their
+// number of correct picks and carries unclaimed money into the series jackpot. Only the
+// contest's organizers can settle it by hand. Settling an already settled draw returns the
+// stored result.
+func (s *ScoringService) SettleTotalizatorDraw(ctx context.Context, req *pb.SettleTotalizatorDrawRequest) (*pb.SettleTotalizatorDrawResponse, error) {
+	if req.ContestId == 0 {
+		return &pb.SettleTotalizatorDrawResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Contest ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if failure := s.authorizeOrganizer(ctx, uint(req.ContestId)); failure != nil {
+		return &pb.SettleTotalizatorDrawResponse{Response: failure}, nil
+	}
+
+	draw, payouts, replayed, err := s.settleDraw(ctx, uint(req.ContestId))
+	if err != nil {
+		code := common.ErrorCode_INTERNAL_ERROR
+		message := "Failed to settle draw"
+		if errors.Is(err, errNotPoolContest) || errors.Is(err, errDrawNotFinished) {
+			code = common.ErrorCode_INVALID_ARGUMENT
+			message = err.Error()
+		} else {
+			log.Printf("[ERROR] Failed to settle draw for contest %d: %v", req.ContestId, err)
+		}
+		return &pb.SettleTotalizatorDrawResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   message,
+				Code:      int32(code),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	message := "Draw settled successfully"
+	if replayed {
+		message = "Draw already settled"
+	}
+	return &pb.SettleTotalizatorDrawResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   message,
+			Code:      0,
+			Timestamp: timestamppb.Now(),
+		},
+		Draw:     poolDrawToProto(draw, payouts),
+		Replayed: replayed,
+	}, nil
+}
+
+// settleDraw settles a contest's pool draw once all of its matches are scored
+func (s *ScoringService) settleDraw(ctx context.Context, contestID uint) (*models.PoolDraw, []*models.PoolPayout, bool, error) {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if rules.Type != scoring.ContestTypeTotalizator || rules.Totalizator.Pool == nil {
+		return nil, nil, false, errNotPoolContest
+	}
+	pool := rules.Totalizator.Pool
+
+	if draw, err := s.poolRepo.GetDraw(ctx, contestID); err != nil {
+		return nil, nil, false, err
+	} else if draw != nil {
+		payouts, err := s.poolRepo.ListPayouts(ctx, contestID)
+		return draw, payouts, true, err
+	}
+
+	if err := s.checkContestFinished(ctx, contestID); err != nil {
+		return nil, nil, false, err
+	}
+
+	correct, err := s.scoreRepo.CountRuleMatchesByUser(ctx, contestID, scoring.PoolHit)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to count correct picks: %w", err)
+	}
+
+	draw, payouts, err := s.poolRepo.SettleDraw(ctx, pool.Series, func(jackpot float64) (*models.PoolDraw, []*models.PoolPayout) {
+		return splitDraw(contestID, pool, jackpot, correct)
+	})
+	if err != nil {
+		// A concurrent settlement of the same draw wins, return its result
+		if existing, getErr := s.poolRepo.GetDraw(ctx, contestID); getErr == nil && existing != nil {
+			payouts, err := s.poolRepo.ListPayouts(ctx, contestID)
+			return existing, payouts, true, err
+		}
+		return nil, nil, false, err
+	}
+
+	// Totals switch from correct picks to winnings
+	for userID := range correct {
+		if err := s.refreshUserTotal(ctx, contestID, userID); err != nil {
+			log.Printf("[WARN] Failed to refresh total for user %d in contest %d: %v", userID, contestID, err)
+		}
+	}
+	if err := s.recalculateRanks(ctx, contestID); err != nil {
+		log.Printf("[WARN] Failed to recalculate ranks for contest %d: %v", contestID, err)
+	}
+
+	log.Printf("[INFO] Draw for contest %d settled: %d winners, jackpot in %.2f, rollover %.2f",
+		contestID, len(payouts), draw.JackpotIn, draw.RolloverOut)
+	return draw, payouts, false, nil
+}
+
+// resettleDraw splits a settled draw again after a result correction changed its participants'
+// correct picks. The draw keeps the jackpot it was settled with; only its payouts and rollover
+// change. Draws that are not settled yet, or whose split is unchanged, are left alone.
+func (s *ScoringService) resettleDraw(ctx context.Context, contestID uint) error {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		return err
+	}
+	if rules.Type != scoring.ContestTypeTotalizator || rules.Totalizator.Pool == nil {
+		return nil
+	}
+	pool := rules.Totalizator.Pool
+
+	previous, err := s.poolRepo.GetDraw(ctx, contestID)
+	if err != nil || previous == nil {
+		return err
+	}
+	previousPayouts, err := s.poolRepo.ListPayouts(ctx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to load payouts: %w", err)
+	}
+
+	correct, err := s.scoreRepo.CountRuleMatchesByUser(ctx, contestID, scoring.PoolHit)
+	if err != nil {
+		return fmt.Errorf("failed to count correct picks: %w", err)
+	}
+	draw, payouts := splitDraw(contestID, pool, previous.JackpotIn, correct)
+	draw.Series = previous.Series
+	draw.PoolAmount = previous.PoolAmount
+	if sameSplit(previous, previousPayouts, draw, payouts) {
+		return nil
+	}
+
+	if err := s.poolRepo.ResettleDraw(ctx, draw, payouts); err != nil {
+		return err
+	}
+
+	// Winners of the old and the new split both get their totals refreshed
+	users := make(map[uint]bool, len(correct))
+	for userID := range correct {
+		users[userID] = true
+	}
+	for _, payout := range previousPayouts {
+		users[payout.UserID] = true
+	}
+	for userID := range users {
+		if err := s.refreshUserTotal(ctx, contestID, userID); err != nil {
+			log.Printf("[WARN] Failed to refresh total for user %d in contest %d: %v", userID, contestID, err)
+		}
+	}
+	if err := s.recalculateRanks(ctx, contestID); err != nil {
+		log.Printf("[WARN] Failed to recalculate ranks for contest %d: %v", contestID, err)
+	}
+
+	log.Printf("[INFO] Draw for contest %d resettled: %d winners, rollover %.2f -> %.2f",
+		contestID, len(payouts), previous.RolloverOut, draw.RolloverOut)
+	return nil
+}
+
+// splitDraw splits a draw's pool and returns the draw settlement and payouts to store
+func splitDraw(contestID uint, pool *scoring.TotalizatorPool, jackpot float64, correct map[uint]int) (*models.PoolDraw, []*models.PoolPayout) {
+	split := pool.Split(jackpot, correct)
+	payouts := make([]*models.PoolPayout, 0, len(split.Payouts))
+	for userID, amount := range split.Payouts {
+		payouts = append(payouts, &models.PoolPayout{
+			ContestID: contestID,
+			UserID:    userID,
+			Correct:   correct[userID],
+			Amount:    amount,
+		})
+	}
+	return &models.PoolDraw{
+		ContestID:   contestID,
+		Series:      pool.Series,
+		PoolAmount:  pool.Amount,
+		JackpotIn:   jackpot,
+		RolloverOut: split.Rollover,
+		SettledAt:   time.Now().UTC(),
+	}, payouts
+}
+
+// sameSplit reports whether two settlements of a draw pay the same rollover and payouts
+func sameSplit(previous *models.PoolDraw, previousPayouts []*models.PoolPayout, draw *models.PoolDraw, payouts []*models.PoolPayout) bool {
+	if previous.RolloverOut != draw.RolloverOut || len(previousPayouts) != len(payouts) {
+		return false
+	}
+	paid := make(map[uint]models.PoolPayout, len(previousPayouts))
+	for _, payout := range previousPayouts {
+		paid[payout.UserID] = *payout
+	}
+	for _, payout := range payouts {
+		old, ok := paid[payout.UserID]
+		if !ok || old.Amount != payout.Amount || old.Correct != payout.Correct {
+			return false
+		}
+	}
+	return true
+}
+
+// checkContestFinished checks that every match of a contest, such as a draw, is completed and
+// every pick is scored
+func (s *ScoringService) checkContestFinished(ctx context.Context, contestID uint) error {
+	eventIDs, err := s.settlementRepo.ListContestEventIDs(ctx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to load draw events: %w", err)
+	}
+	if len(eventIDs) == 0 {
+		return errDrawNotFinished
+	}
+
+	events, err := s.settlementRepo.ListEvents(ctx, eventIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load draw events: %w", err)
+	}
+	if len(events) != len(eventIDs) {
+		return errDrawNotFinished
+	}
+	for _, event := range events {
+		if !event.IsCompleted() {
+			return errDrawNotFinished
+		}
+	}
+
+	pending, err := s.settlementRepo.CountPendingPredictions(ctx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to count pending predictions: %w", err)
+	}
+	if pending > 0 {
+		return errDrawNotFinished
+	}
+	return nil
+}
+
+// settleFinishedDraw settles a pool draw after one of its matches settles, if it was the last one
+func (s *ScoringService) settleFinishedDraw(ctx context.Context, contestID uint) {
+	_, _, replayed, err := s.settleDraw(ctx, contestID)
+	switch {
+	case errors.Is(err, errNotPoolContest), errors.Is(err, errDrawNotFinished):
+		return
+	case err != nil:
+		log.Printf("[WARN] Failed to settle draw for contest %d: %v", contestID, err)
+	case !replayed:
+		log.Printf("[INFO] Draw for contest %d settled after its last match", contestID)
+	}
+}
+
+// poolWinnings returns a user's winnings from a contest's draw and whether the draw is settled
+func (s *ScoringService) poolWinnings(ctx context.Context, contestID, userID uint) (float64, bool, error) {
+	draw, err := s.poolRepo.GetDraw(ctx, contestID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to load draw: %w", err)
+	}
+	if draw == nil {
+		return 0, false, nil
+	}
+
+	payout, err := s.poolRepo.GetPayout(ctx, contestID, userID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to load payout: %w", err)
+	}
+	if payout == nil {
+		return 0, true, nil
+	}
+	return payout.Amount, true, nil
+}
+
+// poolDrawToProto converts a draw settlement to protobuf message
+func poolDrawToProto(draw *models.PoolDraw, payouts []*models.PoolPayout) *pb.PoolDraw {
+	pbPayouts := make([]*pb.PoolPayout, len(payouts))
+	for i, payout := range payouts {
+		pbPayouts[i] = &pb.PoolPayout{
+			UserId:  uint32(payout.UserID),
+			Correct: uint32(payout.Correct),
+			Amount:  payout.Amount,
+		}
+	}
+	return &pb.PoolDraw{
+		ContestId:   uint32(draw.ContestID),
+		Series:      draw.Series,
+		PoolAmount:  draw.PoolAmount,
+		JackpotIn:   draw.JackpotIn,
+		RolloverOut: draw.RolloverOut,
+		Payouts:     pbPayouts,
+		SettledAt:   timestamppb.New(draw.SettledAt),
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RescorePrediction replaces the base points of an already scored prediction and rebuilds
+// the user's streak chain and leaderboard total instead of appending a new score. Only the
+// contest's organizers may rescore its predictions.
+func (s *ScoringService) RescorePrediction(ctx context.Context, req *pb.RescorePredictionRequest) (*pb.RescorePredictionResponse, error) {
+	if req.PredictionId == 0 {
+		return &pb.RescorePredictionResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Prediction ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	score, err := s.scoreRepo.GetByPrediction(ctx, uint(req.PredictionId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get score for prediction %d: %v", req.PredictionId, err)
+		return &pb.RescorePredictionResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Score not found for prediction",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if failure := s.authorizeOrganizer(ctx, score.ContestID); failure != nil {
+		return &pb.RescorePredictionResponse{Response: failure}, nil
+	}
+
+	var submittedAt, eventDate time.Time
+	if req.SubmittedAt != nil && req.EventDate != nil {
+		submittedAt = req.SubmittedAt.AsTime()
+		eventDate = req.EventDate.AsTime()
+	}
+
+	previousPoints := score.Points
+	updated, err := s.rescore(ctx, score, req.Points, submittedAt, eventDate)
+	if err != nil {
+		log.Printf("[ERROR] Failed to rescore prediction %d: %v", req.PredictionId, err)
+		return &pb.RescorePredictionResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to rescore prediction",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.RescorePredictionResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   fmt.Sprintf("Prediction rescored (%.2f -> %.2f)", previousPoints, updated.Points),
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Score:          s.modelToProto(updated),
+		PreviousPoints: previousPoints,
+	}, nil
+}
+
+// rescore stores new base points on an existing score, then replays the user's streak chain
+// so this and every later score picks up the corrected multipliers
+func (s *ScoringService) rescore(ctx context.Context, score *models.Score, basePoints float64, submittedAt, eventDate time.Time) (*models.Score, error) {
+	if !submittedAt.IsZero() && !eventDate.IsZero() {
+		contestRules, err := s.settlementRepo.GetContestRulesHistory(ctx, score.ContestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load contest rules: %w", err)
+		}
+		rulesJSON, _ := contestRules.RulesFor(submittedAt, eventDate)
+		if rules := scoring.ParseRulesOrDefault(rulesJSON); rules.AppliesMultipliers() {
+			score.TimeCoefficient, score.TimeTier = models.CalculateWithTier(submittedAt, eventDate, rules.TimeCoefficient)
+		}
+	}
+	score.ApplyMultipliers(basePoints, score.StreakMultiplier)
+
+	if err := s.scoreRepo.Update(ctx, score); err != nil {
+		return nil, fmt.Errorf("failed to update score: %w", err)
+	}
+
+	if err := s.rebuildUserChain(ctx, score.ContestID, score.UserID); err != nil {
+		return nil, err
+	}
+
+	if err := s.recalculateRanks(ctx, score.ContestID); err != nil {
+		log.Printf("[WARN] Failed to recalculate ranks for contest %d: %v", score.ContestID, err)
+	}
+
+	return s.scoreRepo.GetByID(ctx, score.ID)
+}
+
+// rebuildUserChain replays a user's scores in streak chain order, recomputing the streak and
+// every streak-dependent multiplier under the rules version that scored each of them, then
+// refreshes the user's leaderboard total
+func (s *ScoringService) rebuildUserChain(ctx context.Context, contestID, userID uint) error {
+	chain, err := s.scoreRepo.ListChainByContestAndUser(ctx, contestID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to load score chain: %w", err)
+	}
+
+	contestRules, err := s.settlementRepo.GetContestRulesHistory(ctx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to load contest rules: %w", err)
+	}
+	parsed := make(map[string]*scoring.ContestRules)
+
+	streak, err := s.streakRepo.GetOrCreate(ctx, contestID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get/create streak: %w", err)
+	}
+	streak.Clear()
+
+	for _, entry := range chain {
+		score := &entry.Score
+		rules := chainRules(contestRules, entry, parsed)
+		fixedPoints := !rules.AppliesMultipliers()
+
+		basePoints := score.GetBasePoints()
+		if score.RuleMatched != ruleVoid {
+			streak.RecordResult(score.PredictionID, rules.Streak.IsHit(score.RuleMatched, basePoints), rules.Streak)
+		}
+
+		previous := *score
+		streakMultiplier := streak.MultiplierFor(rules.Streak)
+		if fixedPoints {
+			streakMultiplier = 1.0
+		}
+		score.ApplyMultipliers(basePoints, streakMultiplier)
+		if score.Points == previous.Points && score.BasePoints == previous.BasePoints &&
+			score.StreakMultiplier == previous.StreakMultiplier {
+			continue
+		}
+
+		if err := s.scoreRepo.Update(ctx, score); err != nil {
+			return fmt.Errorf("failed to update score %d: %w", score.ID, err)
+		}
+	}
+
+	if err := s.streakRepo.Update(ctx, streak); err != nil {
+		return fmt.Errorf("failed to update streak: %w", err)
+	}
+
+	return s.refreshUserTotal(ctx, contestID, userID)
+}
+
+// chainRules returns the parsed rules version in force for a chain entry, caching parsed rules
+// by their JSON. Entries whose prediction is gone resolve by the time they were scored.
+func chainRules(contestRules *models.SettlementContestRules, entry *models.ScoreHistory, parsed map[string]*scoring.ContestRules) *scoring.ContestRules {
+	submittedAt, eventDate := entry.ScoredAt, entry.ScoredAt
+	if entry.SubmittedAt != nil {
+		submittedAt = *entry.SubmittedAt
+	}
+	if entry.EventDate != nil {
+		eventDate = *entry.EventDate
+	}
+
+	rulesJSON, _ := contestRules.RulesFor(submittedAt, eventDate)
+	rules, ok := parsed[rulesJSON]
+	if !ok {
+		rules = scoring.ParseRulesOrDefault(rulesJSON)
+		parsed[rulesJSON] = rules
+	}
+	return rules
+}
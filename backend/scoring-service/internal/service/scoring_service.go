@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
+	"github.com/sports-prediction-contests/scoring-service/internal/clients"
 	"github.com/sports-prediction-contests/scoring-service/internal/models"
 	"github.com/sports-prediction-contests/scoring-service/internal/repository"
 	"github.com/sports-prediction-contests/shared/auth"
+	"github.com/sports-prediction-contests/shared/coefficient"
 	"github.com/sports-prediction-contests/shared/scoring"
 	pb "github.com/sports-prediction-contests/shared/proto/scoring"
 	"github.com/sports-prediction-contests/shared/proto/common"
@@ -23,50 +26,32 @@ type ScoringService struct {
 	leaderboardRepo repository.LeaderboardRepositoryInterface
 	streakRepo      repository.StreakRepositoryInterface
 	analyticsRepo   repository.AnalyticsRepositoryInterface
+	settlementRepo  repository.SettlementRepositoryInterface
+	auditRepo       repository.AuditRepositoryInterface
+	poolRepo        repository.PoolRepositoryInterface
+	snapshotRepo    repository.SnapshotRepositoryInterface
+	skillRepo       repository.SkillRepositoryInterface
+	achievementRepo repository.AchievementRepositoryInterface
+	notifier        *clients.NotificationClient
 }
 
 // NewScoringService creates a new ScoringService instance
-func NewScoringService(scoreRepo repository.ScoreRepositoryInterface, leaderboardRepo repository.LeaderboardRepositoryInterface, streakRepo repository.StreakRepositoryInterface, analyticsRepo repository.AnalyticsRepositoryInterface) *ScoringService {
+func NewScoringService(scoreRepo repository.ScoreRepositoryInterface, leaderboardRepo repository.LeaderboardRepositoryInterface, streakRepo repository.StreakRepositoryInterface, analyticsRepo repository.AnalyticsRepositoryInterface, settlementRepo repository.SettlementRepositoryInterface, auditRepo repository.AuditRepositoryInterface, poolRepo repository.PoolRepositoryInterface, snapshotRepo repository.SnapshotRepositoryInterface, skillRepo repository.SkillRepositoryInterface, achievementRepo repository.AchievementRepositoryInterface, notifier *clients.NotificationClient) *ScoringService {
 	return &ScoringService{
 		scoreRepo:       scoreRepo,
 		leaderboardRepo: leaderboardRepo,
 		streakRepo:      streakRepo,
 		analyticsRepo:   analyticsRepo,
+		settlementRepo:  settlementRepo,
+		auditRepo:       auditRepo,
+		poolRepo:        poolRepo,
+		snapshotRepo:    snapshotRepo,
+		skillRepo:       skillRepo,
+		achievementRepo: achievementRepo,
+		notifier:        notifier,
 	}
 }
 
-// PredictionData represents the structure of prediction data
-type PredictionData struct {
-	Type       string      `json:"type"`        // "exact_score", "winner", "over_under"
-	HomeScore  *int        `json:"home_score"`  // For exact score predictions
-	AwayScore  *int        `json:"away_score"`  // For exact score predictions
-	Winner     *string     `json:"winner"`      // "home", "away", "draw"
-	OverUnder  *string     `json:"over_under"`  // "over", "under"
-	Threshold  *float64    `json:"threshold"`   // For over/under predictions
-	Value      interface{} `json:"value"`       // Generic value for other prediction types
-	Props      []PropPrediction `json:"props,omitempty"` // Props predictions
-}
-
-// PropPrediction represents a single prop prediction
-type PropPrediction struct {
-	PropTypeID  uint    `json:"prop_type_id"`
-	PropSlug    string  `json:"prop_slug"`
-	Line        float64 `json:"line"`
-	Selection   string  `json:"selection"`
-	PlayerID    string  `json:"player_id,omitempty"`
-	PointsValue float64 `json:"points_value"`
-}
-
-// ResultData represents the structure of event result data
-type ResultData struct {
-	HomeScore   int                    `json:"home_score"`
-	AwayScore   int                    `json:"away_score"`
-	Winner      string                 `json:"winner"`
-	TotalGoals  int                    `json:"total_goals"`
-	Stats       map[string]interface{} `json:"stats,omitempty"`
-	PlayerStats map[string]interface{} `json:"player_stats,omitempty"`
-}
-
 // CreateScore creates a new score record
 func (s *ScoringService) CreateScore(ctx context.Context, req *pb.CreateScoreRequest) (*pb.CreateScoreResponse, error) {
 	// Extract user ID from JWT token for authorization
@@ -83,118 +68,198 @@ func (s *ScoringService) CreateScore(ctx context.Context, req *pb.CreateScoreReq
 		}, nil
 	}
 
-	// Get or create user streak
-	streak, err := s.streakRepo.GetOrCreate(ctx, uint(req.ContestId), uint(req.UserId))
+	var submittedAt, eventDate time.Time
+	if req.SubmittedAt != nil && req.EventDate != nil {
+		submittedAt = req.SubmittedAt.AsTime()
+		eventDate = req.EventDate.AsTime()
+	}
+
+	// The time coefficient and streak follow the contest's rules version that scores the
+	// prediction, as in settlement. Scores without times take the version in force now.
+	var coefficients *coefficient.Config
+	var streakRules *scoring.StreakRules
+	var rulesVersion string
+	if history, err := s.settlementRepo.GetContestRulesHistory(ctx, uint(req.ContestId)); err != nil {
+		log.Printf("[WARN] Failed to load rules for contest %d, using default time tiers and streaks: %v", req.ContestId, err)
+	} else {
+		rulesAt, kickoffAt := submittedAt, eventDate
+		if rulesAt.IsZero() {
+			rulesAt, kickoffAt = time.Now(), time.Now()
+		}
+		var rulesJSON string
+		rulesJSON, rulesVersion = history.RulesFor(rulesAt, kickoffAt)
+		rules := scoring.ParseRulesOrDefault(rulesJSON)
+		coefficients = rules.TimeCoefficient
+		streakRules = rules.Streak
+	}
+
+	score, created, err := s.recordScore(ctx, scoreInput{
+		ContestID:    uint(req.ContestId),
+		UserID:       uint(req.UserId),
+		PredictionID: uint(req.PredictionId),
+		BasePoints:   req.Points,
+		SubmittedAt:  submittedAt,
+		EventDate:    eventDate,
+		RuleMatched:  ruleManual,
+		RulesVersion: rulesVersion,
+		Coefficients: coefficients,
+		Streak:       streakRules,
+	})
 	if err != nil {
-		log.Printf("[ERROR] Failed to get/create streak: %v", err)
+		log.Printf("[ERROR] Failed to create score: %v", err)
 		return &pb.CreateScoreResponse{
 			Response: &common.Response{
 				Success:   false,
-				Message:   "Failed to process streak",
+				Message:   "Failed to create score",
 				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
 				Timestamp: timestamppb.Now(),
 			},
 		}, nil
 	}
 
-	// Determine if prediction was correct and update streak
-	basePoints := req.Points
-	// Update streak first, then calculate multiplier based on new streak value
-	isCorrect := basePoints > 0
-	if isCorrect {
-		streak.IncrementStreak(uint(req.PredictionId))
-	} else {
-		streak.ResetStreak(uint(req.PredictionId))
+	if !created {
+		return &pb.CreateScoreResponse{
+			Response: &common.Response{
+				Success:   true,
+				Message:   "Prediction already scored, returning original score",
+				Code:      int32(0),
+				Timestamp: timestamppb.Now(),
+			},
+			Score:    s.modelToProto(score),
+			Replayed: true,
+		}, nil
 	}
 
-	// Multiplier is based on the updated streak value
-	multiplier := streak.GetMultiplier()
+	s.unlockAchievements(ctx, map[uint]bool{score.UserID: true})
 
-	// Calculate time coefficient based on submission time vs event date
-	timeCoefficient := 1.0
-	if req.SubmittedAt != nil && req.EventDate != nil {
-		timeCoefficient = models.CalculateTimeCoefficient(
-			req.SubmittedAt.AsTime(),
-			req.EventDate.AsTime(),
-		)
+	return &pb.CreateScoreResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   fmt.Sprintf("Score created successfully (%.2fx streak, %.2fx time)", score.StreakMultiplier, score.TimeCoefficient),
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Score: s.modelToProto(score),
+	}, nil
+}
+
+// scoreInput carries everything needed to record a score and explain it later
+type scoreInput struct {
+	ContestID    uint
+	UserID       uint
+	PredictionID uint
+	BasePoints   float64
+	SubmittedAt  time.Time
+	EventDate    time.Time
+	RuleMatched  string
+	RulesVersion string
+	Details      map[string]interface{}
+	FixedPoints  bool                // Skip streak and time multipliers
+	Coefficients *coefficient.Config  // Contest's time coefficient tiers, nil for defaults
+	Streak       *scoring.StreakRules // Contest's streak rules, nil for defaults
+}
+
+// recordScore applies streak and time multipliers to base points, persists the score
+// and refreshes the user's leaderboard total. Zero SubmittedAt/EventDate skips the time coefficient.
+// Scores are keyed by prediction: if the prediction is already scored the original score is
+// returned with created=false and neither the streak nor the leaderboard is touched.
+func (s *ScoringService) recordScore(ctx context.Context, in scoreInput) (*models.Score, bool, error) {
+	contestID, userID, predictionID, basePoints := in.ContestID, in.UserID, in.PredictionID, in.BasePoints
+	if existing, err := s.scoreRepo.GetByPrediction(ctx, predictionID); err == nil {
+		return s.replayedScore(existing, contestID, userID)
 	}
 
-	// Apply both multipliers
-	finalPoints := basePoints * multiplier * timeCoefficient
+	// Get or create user streak
+	streak, err := s.streakRepo.GetOrCreate(ctx, contestID, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get/create streak: %w", err)
+	}
 
-	// Update streak in database - fail if this fails to maintain consistency
-	if err := s.streakRepo.Update(ctx, streak); err != nil {
-		log.Printf("[ERROR] Failed to update streak: %v", err)
-		return &pb.CreateScoreResponse{
-			Response: &common.Response{
-				Success:   false,
-				Message:   "Failed to update streak",
-				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
-				Timestamp: timestamppb.Now(),
-			},
-		}, nil
+	// Update streak first, then calculate multiplier based on new streak value. Void
+	// predictions neither extend nor break it.
+	if in.RuleMatched != ruleVoid {
+		streak.RecordResult(predictionID, in.Streak.IsHit(in.RuleMatched, basePoints), in.Streak)
+	}
+
+	// Calculate time coefficient based on submission time vs event date
+	timeCoefficient, timeTier := 1.0, ""
+	if !in.FixedPoints && !in.SubmittedAt.IsZero() && !in.EventDate.IsZero() {
+		timeCoefficient, timeTier = models.CalculateWithTier(in.SubmittedAt, in.EventDate, in.Coefficients)
+	}
+
+	rulesVersion := in.RulesVersion
+	if rulesVersion == "" {
+		rulesVersion = scoring.DefaultRulesVersion
 	}
 
-	// Create score model with multiplied points
 	score := &models.Score{
-		UserID:          uint(req.UserId),
-		ContestID:       uint(req.ContestId),
-		PredictionID:    uint(req.PredictionId),
-		Points:          finalPoints,
+		UserID:          userID,
+		ContestID:       contestID,
+		PredictionID:    predictionID,
 		TimeCoefficient: timeCoefficient,
+		TimeTier:        timeTier,
+		RuleMatched:     in.RuleMatched,
+		RulesVersion:    rulesVersion,
+		Details:         detailsToJSON(in.Details),
+	}
+	// Multiplier is based on the updated streak value
+	streakMultiplier := streak.MultiplierFor(in.Streak)
+	if in.FixedPoints {
+		streakMultiplier = 1.0
 	}
+	score.ApplyMultipliers(basePoints, streakMultiplier)
 
-	// Save to database
+	// The score is written before the streak so a failed write, such as a duplicate
+	// prediction, leaves the streak untouched
 	if err := s.scoreRepo.Create(ctx, score); err != nil {
-		log.Printf("[ERROR] Failed to create score: %v", err)
-		return &pb.CreateScoreResponse{
-			Response: &common.Response{
-				Success:   false,
-				Message:   "Failed to create score",
-				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
-				Timestamp: timestamppb.Now(),
-			},
-		}, nil
+		// A concurrent call may have scored the prediction first
+		if existing, getErr := s.scoreRepo.GetByPrediction(ctx, predictionID); getErr == nil {
+			return s.replayedScore(existing, contestID, userID)
+		}
+		return nil, false, fmt.Errorf("failed to save score: %w", err)
 	}
 
-	// Update leaderboard
-	totalPoints, err := s.scoreRepo.GetTotalPointsByContestAndUser(ctx, uint(req.ContestId), uint(req.UserId))
+	// A score that is not the last of the chain, e.g. for a postponed match settled after
+	// later kickoffs, is placed by replaying the chain instead of extending the streak
+	chain, err := s.scoreRepo.ListChainByContestAndUser(ctx, contestID, userID)
 	if err != nil {
-		log.Printf("[ERROR] Failed to get total points: %v", err)
-		return &pb.CreateScoreResponse{
-			Response: &common.Response{
-				Success:   false,
-				Message:   "Failed to update leaderboard after score creation",
-				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
-				Timestamp: timestamppb.Now(),
-			},
-		}, nil
+		return nil, false, fmt.Errorf("failed to load score chain: %w", err)
 	}
-	
-	if err := s.leaderboardRepo.UpsertUserScore(ctx, uint(req.ContestId), uint(req.UserId), totalPoints); err != nil {
-		log.Printf("[ERROR] Failed to update leaderboard: %v", err)
-		return &pb.CreateScoreResponse{
-			Response: &common.Response{
-				Success:   false,
-				Message:   "Score created but leaderboard update failed",
-				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
-				Timestamp: timestamppb.Now(),
-			},
-		}, nil
+	if len(chain) > 0 && chain[len(chain)-1].ID != score.ID {
+		if err := s.rebuildUserChain(ctx, contestID, userID); err != nil {
+			return nil, false, err
+		}
+		replayed, err := s.scoreRepo.GetByID(ctx, score.ID)
+		if err != nil {
+			return nil, false, err
+		}
+		log.Printf("[INFO] Score created mid-chain: user=%d, contest=%d, base=%.2f, streak=%.2fx, time=%.2fx, final=%.2f",
+			userID, contestID, basePoints, replayed.StreakMultiplier, replayed.TimeCoefficient, replayed.Points)
+		return replayed, true, nil
+	}
+
+	if err := s.streakRepo.Update(ctx, streak); err != nil {
+		return nil, false, fmt.Errorf("failed to update streak: %w", err)
+	}
+
+	if err := s.refreshUserTotal(ctx, contestID, userID); err != nil {
+		return nil, false, err
 	}
 
 	log.Printf("[INFO] Score created: user=%d, contest=%d, base=%.2f, streak=%.2fx, time=%.2fx, final=%.2f",
-		uint(req.UserId), uint(req.ContestId), basePoints, multiplier, timeCoefficient, finalPoints)
+		userID, contestID, basePoints, score.StreakMultiplier, timeCoefficient, score.Points)
 
-	return &pb.CreateScoreResponse{
-		Response: &common.Response{
-			Success:   true,
-			Message:   fmt.Sprintf("Score created successfully (%.2fx streak, %.2fx time)", multiplier, timeCoefficient),
-			Code:      int32(0),
-			Timestamp: timestamppb.Now(),
-		},
-		Score: s.modelToProto(score),
-	}, nil
+	return score, true, nil
+}
+
+// replayedScore returns an existing score for a repeated request, rejecting requests
+// that reuse a prediction ID for a different user or contest
+func (s *ScoringService) replayedScore(existing *models.Score, contestID, userID uint) (*models.Score, bool, error) {
+	if existing.ContestID != contestID || existing.UserID != userID {
+		return nil, false, fmt.Errorf("prediction %d is already scored for user %d in contest %d",
+			existing.PredictionID, existing.UserID, existing.ContestID)
+	}
+	return existing, false, nil
 }
 
 // GetScore retrieves a score by ID
@@ -226,7 +291,7 @@ func (s *ScoringService) GetScore(ctx context.Context, req *pb.GetScoreRequest)
 // CalculateScore calculates points based on prediction accuracy
 func (s *ScoringService) CalculateScore(ctx context.Context, req *pb.CalculateScoreRequest) (*pb.CalculateScoreResponse, error) {
 	// Parse prediction data
-	var predictionData PredictionData
+	var predictionData scoring.PredictionData
 	if err := json.Unmarshal([]byte(req.PredictionData), &predictionData); err != nil {
 		return &pb.CalculateScoreResponse{
 			Response: &common.Response{
@@ -239,7 +304,7 @@ func (s *ScoringService) CalculateScore(ctx context.Context, req *pb.CalculateSc
 	}
 
 	// Parse result data
-	var resultData ResultData
+	var resultData scoring.ResultData
 	if err := json.Unmarshal([]byte(req.ResultData), &resultData); err != nil {
 		return &pb.CalculateScoreResponse{
 			Response: &common.Response{
@@ -252,7 +317,7 @@ func (s *ScoringService) CalculateScore(ctx context.Context, req *pb.CalculateSc
 	}
 
 	// Calculate points based on prediction type
-	points, details := s.calculatePoints(predictionData, resultData)
+	points, details := s.calculatePoints(ctx, predictionData, resultData)
 
 	detailsJSON, _ := json.Marshal(details)
 
@@ -269,7 +334,7 @@ func (s *ScoringService) CalculateScore(ctx context.Context, req *pb.CalculateSc
 }
 
 // calculatePoints implements the scoring algorithm
-func (s *ScoringService) calculatePoints(prediction PredictionData, result ResultData) (float64, map[string]interface{}) {
+func (s *ScoringService) calculatePoints(ctx context.Context, prediction scoring.PredictionData, result scoring.ResultData) (float64, map[string]interface{}) {
 	details := map[string]interface{}{
 		"prediction_type": prediction.Type,
 		"result":          result,
@@ -283,7 +348,7 @@ func (s *ScoringService) calculatePoints(prediction PredictionData, result Resul
 	case "over_under":
 		return s.calculateOverUnderPoints(prediction, result, details)
 	case "props":
-		return s.calculatePropsPoints(prediction, result, details)
+		return s.calculatePropsPoints(ctx, prediction, result, details)
 	default:
 		details["error"] = "Unknown prediction type"
 		return 0, details
@@ -291,158 +356,43 @@ func (s *ScoringService) calculatePoints(prediction PredictionData, result Resul
 }
 
 // calculateExactScorePoints calculates points for exact score predictions (uses default rules)
-func (s *ScoringService) calculateExactScorePoints(prediction PredictionData, result ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
-	// Use default rules for backward compatibility
-	defaultRules := scoring.DefaultStandardRules()
-	return s.calculateExactScorePointsWithRules(prediction, result, details, &defaultRules)
-}
-
-// calculateExactScorePointsWithRules calculates points using custom scoring rules
-func (s *ScoringService) calculateExactScorePointsWithRules(prediction PredictionData, result ResultData, details map[string]interface{}, rules *scoring.StandardScoringRules) (float64, map[string]interface{}) {
-	if prediction.HomeScore == nil || prediction.AwayScore == nil {
-		details["error"] = "Missing score prediction"
-		return 0, details
-	}
-
-	predictedHome := *prediction.HomeScore
-	predictedAway := *prediction.AwayScore
-
-	details["predicted_score"] = fmt.Sprintf("%d-%d", predictedHome, predictedAway)
-	details["actual_score"] = fmt.Sprintf("%d-%d", result.HomeScore, result.AwayScore)
-
-	// Handle "any_other" prediction type
-	if prediction.Type == "any_other" {
-		isOther := result.HomeScore > 4 || result.AwayScore > 4
-		details["result_is_other"] = isOther
-		if isOther {
-			details["match_type"] = "any_other_correct"
-			return rules.AnyOther, details
-		}
-		details["match_type"] = "any_other_incorrect"
-		return 0, details
-	}
-
-	// Exact match
-	if predictedHome == result.HomeScore && predictedAway == result.AwayScore {
-		details["match_type"] = "exact"
-		return rules.ExactScore, details
-	}
-
-	// Correct goal difference
-	predictedDiff := predictedHome - predictedAway
-	actualDiff := result.HomeScore - result.AwayScore
-	if predictedDiff == actualDiff {
-		details["match_type"] = "goal_difference"
-		return rules.GoalDifference, details
-	}
-
-	// Correct winner with possible team goals bonus
-	predictedWinner := s.determineWinner(predictedHome, predictedAway)
-	actualWinner := s.determineWinner(result.HomeScore, result.AwayScore)
-	
-	if predictedWinner == actualWinner {
-		// Check if one team's goals match
-		homeGoalsMatch := predictedHome == result.HomeScore
-		awayGoalsMatch := predictedAway == result.AwayScore
-		
-		if homeGoalsMatch || awayGoalsMatch {
-			details["match_type"] = "outcome_plus_team_goals"
-			details["home_goals_match"] = homeGoalsMatch
-			details["away_goals_match"] = awayGoalsMatch
-			return rules.CorrectOutcome + rules.OutcomePlusTeamGoals, details
-		}
-		
-		details["match_type"] = "correct_outcome"
-		return rules.CorrectOutcome, details
+func (s *ScoringService) calculateExactScorePoints(prediction scoring.PredictionData, result scoring.ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
+	rules, _ := scoring.ParseRules("")
+	calcResult := rules.Score(&prediction, &result)
+	for k, v := range calcResult.Details {
+		details[k] = v
 	}
-
-	details["match_type"] = "none"
-	return 0, details
+	return calcResult.Points, details
 }
 
-// CalculateWithContestRules calculates score using contest-specific rules
-func (s *ScoringService) CalculateWithContestRules(predictionData, resultData, rulesJSON string) (float64, map[string]interface{}) {
-	var prediction PredictionData
-	if err := json.Unmarshal([]byte(predictionData), &prediction); err != nil {
+// CalculateWithContestRules calculates score using contest-specific rules. Props picks are
+// settled by their prop types rather than the contest's points table.
+func (s *ScoringService) CalculateWithContestRules(ctx context.Context, predictionData, resultData, rulesJSON string) (float64, map[string]interface{}) {
+	prediction, err := scoring.ParsePrediction(predictionData)
+	if err != nil {
 		return 0, map[string]interface{}{"error": "Invalid prediction data"}
 	}
 
-	var result ResultData
-	if err := json.Unmarshal([]byte(resultData), &result); err != nil {
+	result, err := scoring.ParseResult(resultData)
+	if err != nil {
 		return 0, map[string]interface{}{"error": "Invalid result data"}
 	}
 
+	if prediction.Type == "props" {
+		return s.calculatePropsPoints(ctx, *prediction, *result, map[string]interface{}{"prediction_type": prediction.Type})
+	}
+
 	rules, err := scoring.ParseRules(rulesJSON)
 	if err != nil {
 		return 0, map[string]interface{}{"error": "Invalid rules: " + err.Error()}
 	}
 
-	details := map[string]interface{}{
-		"prediction_type": prediction.Type,
-		"contest_type":    rules.Type,
-	}
-
-	switch rules.Type {
-	case scoring.ContestTypeStandard:
-		return s.calculateExactScorePointsWithRules(prediction, result, details, rules.Standard)
-	case scoring.ContestTypeRisky:
-		return s.calculateRiskyPoints(prediction, result, details, rules.Risky)
-	default:
-		details["error"] = "Unknown contest type"
-		return 0, details
-	}
-}
-
-// calculateRiskyPoints calculates points for risky predictions
-func (s *ScoringService) calculateRiskyPoints(prediction PredictionData, result ResultData, details map[string]interface{}, rules *scoring.RiskyScoringRules) (float64, map[string]interface{}) {
-	// Risky predictions store selected events in prediction.Props or a custom field
-	// For now, we'll use a simple calculation based on the prediction data
-	
-	// Parse risky selections from prediction data (expected format: {"risky_selections": ["penalty", "red_card"]})
-	type RiskyPredictionData struct {
-		Selections []string `json:"risky_selections"`
-	}
-	
-	// Try to extract risky selections from the Value field
-	selectionsData, ok := prediction.Value.(map[string]interface{})
-	if !ok {
-		details["error"] = "Invalid risky prediction format"
-		return 0, details
-	}
-	
-	selectionsRaw, ok := selectionsData["risky_selections"].([]interface{})
-	if !ok {
-		details["error"] = "Missing risky_selections"
-		return 0, details
-	}
-	
-	selections := make([]string, len(selectionsRaw))
-	for i, v := range selectionsRaw {
-		selections[i], _ = v.(string)
-	}
-	
-	// Get outcomes from result stats
-	outcomes := make(map[string]bool)
-	if result.Stats != nil {
-		for key, val := range result.Stats {
-			if boolVal, ok := val.(bool); ok {
-				outcomes[key] = boolVal
-			}
-		}
-	}
-	
-	calc := scoring.NewCalculator(&scoring.ContestRules{Type: scoring.ContestTypeRisky, Risky: rules})
-	calcResult := calc.CalculateRisky(selections, outcomes)
-	
-	for k, v := range calcResult.Details {
-		details[k] = v
-	}
-	
-	return calcResult.Points, details
+	calcResult := rules.Score(prediction, result)
+	return calcResult.Points, calcResult.Details
 }
 
 // calculateWinnerPoints calculates points for winner predictions
-func (s *ScoringService) calculateWinnerPoints(prediction PredictionData, result ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
+func (s *ScoringService) calculateWinnerPoints(prediction scoring.PredictionData, result scoring.ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
 	if prediction.Winner == nil {
 		details["error"] = "Missing winner prediction"
 		return 0, details
@@ -462,7 +412,7 @@ func (s *ScoringService) calculateWinnerPoints(prediction PredictionData, result
 }
 
 // calculateOverUnderPoints calculates points for over/under predictions
-func (s *ScoringService) calculateOverUnderPoints(prediction PredictionData, result ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
+func (s *ScoringService) calculateOverUnderPoints(prediction scoring.PredictionData, result scoring.ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
 	if prediction.OverUnder == nil || prediction.Threshold == nil {
 		details["error"] = "Missing over/under prediction or threshold"
 		return 0, details
@@ -551,8 +501,10 @@ func (s *ScoringService) modelToProto(score *models.Score) *pb.Score {
 		UserId:          uint32(score.UserID),
 		ContestId:       uint32(score.ContestID),
 		PredictionId:    uint32(score.PredictionID),
-		Points:          score.Points,
-		TimeCoefficient: score.TimeCoefficient,
+		Points:           score.Points,
+		BasePoints:       score.BasePoints,
+		StreakMultiplier: score.StreakMultiplier,
+		TimeCoefficient:  score.TimeCoefficient,
 		ScoredAt:        timestamppb.New(score.ScoredAt),
 		CreatedAt:       timestamppb.New(score.CreatedAt),
 		UpdatedAt:       timestamppb.New(score.UpdatedAt),
@@ -626,6 +578,19 @@ func (s *ScoringService) GetUserAnalytics(ctx context.Context, req *pb.GetUserAn
 		analytics.PlatformComparison = platformStats
 	}
 
+	analytics.SkillRating = models.DefaultSkillRating
+	if ratings, err := s.skillRepo.GetRatings(ctx, []uint{uint(req.UserId)}); err != nil {
+		log.Printf("[WARN] Failed to get skill rating: %v", err)
+	} else if rating, ok := ratings[uint(req.UserId)]; ok {
+		analytics.SkillRating = rating.Rating
+		analytics.RatedEvents = rating.Events
+	}
+	if history, err := s.skillRepo.ListHistory(ctx, uint(req.UserId), since); err != nil {
+		log.Printf("[WARN] Failed to get skill rating history: %v", err)
+	} else {
+		analytics.RatingHistory = history
+	}
+
 	return &pb.GetUserAnalyticsResponse{
 		Response: &common.Response{
 			Success:   true,
@@ -682,6 +647,8 @@ func (s *ScoringService) analyticsToProto(a *models.UserAnalytics) *pb.UserAnaly
 		OverallAccuracy:    a.OverallAccuracy,
 		TotalPoints:        a.TotalPoints,
 		TimeRange:          a.TimeRange,
+		SkillRating:        a.SkillRating,
+		RatedEvents:        uint32(a.RatedEvents),
 	}
 
 	for _, sp := range a.BySport {
@@ -734,6 +701,16 @@ func (s *ScoringService) analyticsToProto(a *models.UserAnalytics) *pb.UserAnaly
 		}
 	}
 
+	for _, change := range a.RatingHistory {
+		proto.RatingHistory = append(proto.RatingHistory, &pb.SkillRatingChange{
+			EventId:        uint32(change.EventID),
+			PreviousRating: change.PreviousRating,
+			Rating:         change.Rating,
+			Opponents:      uint32(change.Opponents),
+			RatedAt:        timestamppb.New(change.CreatedAt),
+		})
+	}
+
 	return proto
 }
 
@@ -783,14 +760,18 @@ func (s *ScoringService) generateCSV(a *pb.UserAnalytics) string {
 	return b.String()
 }
 
-// calculatePropsPoints calculates points for props predictions
-func (s *ScoringService) calculatePropsPoints(prediction PredictionData, result ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
+// calculatePropsPoints calculates points for props predictions. Each pick is settled by its
+// prop type's stored evaluation, or the built-in one for its slug; pushes and voids earn nothing.
+func (s *ScoringService) calculatePropsPoints(ctx context.Context, prediction scoring.PredictionData, result scoring.ResultData, details map[string]interface{}) (float64, map[string]interface{}) {
 	if len(prediction.Props) == 0 {
 		details["error"] = "No props predictions found"
 		return 0, details
 	}
 
+	propTypes := s.loadPropTypes(ctx, prediction.Props)
+
 	var totalPoints float64
+	var voided int
 	propResults := make([]map[string]interface{}, 0)
 
 	for _, prop := range prediction.Props {
@@ -800,11 +781,23 @@ func (s *ScoringService) calculatePropsPoints(prediction PredictionData, result
 			"line":      prop.Line,
 		}
 
-		correct := s.evaluateProp(prop, result)
-		propResult["correct"] = correct
+		propType := propTypes.find(prop)
+		outcome := scoring.PropVoid
+		if evaluation := s.propEvaluation(prop, propType); evaluation != nil {
+			var actual interface{}
+			outcome, actual = evaluation.Evaluate(prop, &result)
+			if actual != nil {
+				propResult["actual"] = actual
+			}
+		}
+		propResult["outcome"] = outcome
+		propResult["correct"] = outcome == scoring.PropWon
 
-		if correct {
+		if outcome == scoring.PropWon {
 			points := prop.PointsValue
+			if points == 0 && propType != nil {
+				points = propType.PointsCorrect
+			}
 			if points == 0 {
 				points = 2
 			}
@@ -813,6 +806,9 @@ func (s *ScoringService) calculatePropsPoints(prediction PredictionData, result
 		} else {
 			propResult["points"] = float64(0)
 		}
+		if outcome == scoring.PropPush || outcome == scoring.PropVoid {
+			voided++
+		}
 
 		propResults = append(propResults, propResult)
 	}
@@ -820,66 +816,75 @@ func (s *ScoringService) calculatePropsPoints(prediction PredictionData, result
 	details["props_results"] = propResults
 	details["total_props"] = len(prediction.Props)
 	details["correct_props"] = s.countCorrectProps(propResults)
+	details["void_props"] = voided
 
 	return totalPoints, details
 }
 
-func (s *ScoringService) evaluateProp(prop PropPrediction, result ResultData) bool {
-	switch prop.PropSlug {
-	case "total-goals-ou":
-		totalGoals := float64(result.TotalGoals)
-		if prop.Selection == "over" {
-			return totalGoals > prop.Line
-		}
-		return totalGoals < prop.Line
-
-	case "total-corners-ou":
-		var corners float64
-		switch v := result.Stats["corners"].(type) {
-		case float64:
-			corners = v
-		case int:
-			corners = float64(v)
-		default:
-			return false
-		}
-		if prop.Selection == "over" {
-			return corners > prop.Line
-		}
-		return corners < prop.Line
+// propTypeIndex finds predicted prop types by ID, or by slug for picks without one
+type propTypeIndex struct {
+	byID   map[uint]*models.SettlementPropType
+	bySlug map[string]*models.SettlementPropType
+}
 
-	case "btts":
-		btts := result.HomeScore > 0 && result.AwayScore > 0
-		if prop.Selection == "yes" {
-			return btts
-		}
-		return !btts
+func (idx propTypeIndex) find(prop scoring.PropPrediction) *models.SettlementPropType {
+	if propType, ok := idx.byID[prop.PropTypeID]; ok {
+		return propType
+	}
+	return idx.bySlug[prop.PropSlug]
+}
 
-	case "first-to-score":
-		if firstScorer, ok := result.Stats["first_to_score"].(string); ok {
-			return firstScorer == prop.Selection
+// loadPropTypes loads the prop types of a prediction's picks. Without them picks still settle
+// by the built-in evaluations.
+func (s *ScoringService) loadPropTypes(ctx context.Context, props []scoring.PropPrediction) propTypeIndex {
+	idx := propTypeIndex{
+		byID:   make(map[uint]*models.SettlementPropType),
+		bySlug: make(map[string]*models.SettlementPropType),
+	}
+
+	var ids []uint
+	var slugs []string
+	for _, prop := range props {
+		if prop.PropTypeID != 0 {
+			ids = append(ids, prop.PropTypeID)
+		} else if prop.PropSlug != "" {
+			slugs = append(slugs, prop.PropSlug)
 		}
-		return false
-
-	case "total-cards-ou":
-		var cards float64
-		switch v := result.Stats["cards"].(type) {
-		case float64:
-			cards = v
-		case int:
-			cards = float64(v)
-		default:
-			return false
+	}
+
+	propTypes, err := s.settlementRepo.ListPropTypes(ctx, ids, slugs)
+	if err != nil {
+		log.Printf("[WARN] Failed to load prop types, using built-in evaluations: %v", err)
+		return idx
+	}
+	for _, propType := range propTypes {
+		idx.byID[propType.ID] = propType
+		if _, ok := idx.bySlug[propType.Slug]; !ok {
+			idx.bySlug[propType.Slug] = propType
 		}
-		if prop.Selection == "over" {
-			return cards > prop.Line
+	}
+	return idx
+}
+
+// propEvaluation returns how a pick settles, or nil when its prop type defines no evaluation
+func (s *ScoringService) propEvaluation(prop scoring.PropPrediction, propType *models.SettlementPropType) *scoring.PropEvaluation {
+	slug := prop.PropSlug
+	if propType != nil {
+		slug = propType.Slug
+		if propType.Evaluation != "" {
+			evaluation, err := scoring.ParsePropEvaluation(propType.Evaluation)
+			if err == nil {
+				return evaluation
+			}
+			log.Printf("[WARN] Invalid evaluation for prop type %d: %v", propType.ID, err)
 		}
-		return cards < prop.Line
+	}
 
-	default:
-		log.Printf("[WARN] Unknown prop slug: %s", prop.PropSlug)
-		return false
+	if evaluation, ok := scoring.BuiltinPropEvaluations[slug]; ok {
+		return &evaluation
 	}
+	log.Printf("[WARN] No evaluation for prop slug %s, voiding pick", slug)
+	return nil
 }
 
 func (s *ScoringService) countCorrectProps(results []map[string]interface{}) int {
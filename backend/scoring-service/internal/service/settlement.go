@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/coefficient"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SettleEvent scores every pending prediction for a completed event across all contests.
+// Predictions that already have a score are only flipped to "scored", so the call is safe to re-run.
+func (s *ScoringService) SettleEvent(ctx context.Context, req *pb.SettleEventRequest) (*pb.SettleEventResponse, error) {
+	if req.EventId == 0 {
+		return &pb.SettleEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	event, err := s.settlementRepo.GetEvent(ctx, uint(req.EventId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get event %d for settlement: %v", req.EventId, err)
+		return &pb.SettleEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event not found",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if !event.IsCompleted() || event.ResultData == "" {
+		return &pb.SettleEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Event must be completed with result data before settlement",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	resultData, err := s.buildSettlementResult(ctx, event)
+	if err != nil {
+		log.Printf("[ERROR] Failed to prepare result for event %d: %v", event.ID, err)
+		return &pb.SettleEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Invalid event result data",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	predictions, err := s.settlementRepo.ListPendingPredictions(ctx, event.ID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to list pending predictions for event %d: %v", event.ID, err)
+		return &pb.SettleEventResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to load pending predictions",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	var settled, skipped, failed uint32
+	protoScores := make([]*pb.Score, 0, len(predictions))
+	rulesByContest := make(map[uint]*models.SettlementContestRules)
+	touchedContests := make(map[uint]bool)
+	touchedUsers := make(map[uint]bool)
+
+	for _, prediction := range predictions {
+		score, created, err := s.settlePrediction(ctx, prediction, event, resultData, rulesByContest)
+		if err != nil {
+			log.Printf("[ERROR] Failed to settle prediction %d: %v", prediction.ID, err)
+			failed++
+			continue
+		}
+
+		if err := s.settlementRepo.MarkPredictionScored(ctx, prediction.ID); err != nil {
+			// The score exists, so a re-run will only retry the status flip
+			log.Printf("[WARN] Failed to mark prediction %d as scored: %v", prediction.ID, err)
+		}
+
+		if created {
+			settled++
+			touchedContests[prediction.ContestID] = true
+			touchedUsers[prediction.UserID] = true
+		} else {
+			skipped++
+		}
+		protoScores = append(protoScores, s.modelToProto(score))
+	}
+
+	for contestID := range touchedContests {
+		if err := s.recalculateRanks(ctx, contestID); err != nil {
+			log.Printf("[WARN] Failed to recalculate ranks for contest %d: %v", contestID, err)
+		}
+		s.settleFinishedDraw(ctx, contestID)
+		s.snapshotFinishedMatchday(ctx, contestID, event)
+		s.addFinishedContestLeaders(ctx, contestID, touchedUsers)
+	}
+	if failed == 0 {
+		s.rateEvent(ctx, event.ID)
+	}
+	s.unlockAchievements(ctx, touchedUsers)
+
+	log.Printf("[INFO] Event %d settled: settled=%d, skipped=%d, failed=%d", event.ID, settled, skipped, failed)
+
+	return &pb.SettleEventResponse{
+		Response: &common.Response{
+			Success:   failed == 0,
+			Message:   fmt.Sprintf("Settled %d predictions (%d already scored, %d failed)", settled, skipped, failed),
+			Code:      settlementCode(failed),
+			Timestamp: timestamppb.Now(),
+		},
+		SettledCount: settled,
+		SkippedCount: skipped,
+		FailedCount:  failed,
+		Scores:       protoScores,
+	}, nil
+}
+
+// settlePrediction scores a single prediction, returning the existing score if it was already settled
+func (s *ScoringService) settlePrediction(ctx context.Context, prediction *models.SettlementPrediction, event *models.SettlementEvent, resultData string, rulesByContest map[uint]*models.SettlementContestRules) (*models.Score, bool, error) {
+	if existing, err := s.scoreRepo.GetByPrediction(ctx, prediction.ID); err == nil {
+		return existing, false, nil
+	}
+
+	calc, err := s.calculateBasePoints(ctx, prediction, event.EventDate, resultData, rulesByContest)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s.recordScore(ctx, scoreInput{
+		ContestID:    prediction.ContestID,
+		UserID:       prediction.UserID,
+		PredictionID: prediction.ID,
+		BasePoints:   calc.Points,
+		SubmittedAt:  prediction.SubmittedAt,
+		EventDate:    event.EventDate,
+		RuleMatched:  calc.RuleMatched,
+		RulesVersion: calc.RulesVersion,
+		Details:      calc.Details,
+		FixedPoints:  calc.FixedPoints,
+		Coefficients: calc.Coefficients,
+		Streak:       calc.Streak,
+	})
+}
+
+// basePointsResult is the outcome of evaluating a prediction against its contest's rules
+type basePointsResult struct {
+	Points       float64
+	RuleMatched  string
+	RulesVersion string
+	Details      map[string]interface{}
+	FixedPoints  bool
+	Coefficients *coefficient.Config
+	Streak       *scoring.StreakRules
+}
+
+// calculateBasePoints evaluates a prediction with the version of its contest's rules in force
+// for it, caching rules per contest
+func (s *ScoringService) calculateBasePoints(ctx context.Context, prediction *models.SettlementPrediction, eventDate time.Time, resultData string, rulesByContest map[uint]*models.SettlementContestRules) (*basePointsResult, error) {
+	contestRules, ok := rulesByContest[prediction.ContestID]
+	if !ok {
+		var err error
+		contestRules, err = s.settlementRepo.GetContestRulesHistory(ctx, prediction.ContestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load contest rules: %w", err)
+		}
+		rulesByContest[prediction.ContestID] = contestRules
+	}
+	rulesJSON, rulesVersion := contestRules.RulesFor(prediction.SubmittedAt, eventDate)
+
+	points, details := s.CalculateWithContestRules(ctx, prediction.PredictionData, resultData, rulesJSON)
+	if errMsg, ok := details["error"]; ok {
+		// Unscorable predictions still settle with zero points so they do not stay pending forever
+		log.Printf("[WARN] Prediction %d scored with error: %v", prediction.ID, errMsg)
+	}
+	rules := scoring.ParseRulesOrDefault(rulesJSON)
+	return &basePointsResult{
+		Points:       points,
+		RuleMatched:  ruleFromDetails(details),
+		RulesVersion: rulesVersion,
+		Details:      details,
+		FixedPoints:  !rules.AppliesMultipliers(),
+		Coefficients: rules.TimeCoefficient,
+		Streak:       rules.Streak,
+	}, nil
+}
+
+// buildSettlementResult merges the event's team names and recorded risky event outcomes
+// into the result so every contest type can be scored from a single result payload
+func (s *ScoringService) buildSettlementResult(ctx context.Context, event *models.SettlementEvent) (string, error) {
+	var result scoring.ResultData
+	if err := json.Unmarshal([]byte(event.ResultData), &result); err != nil {
+		return "", err
+	}
+
+	outcomes, err := s.settlementRepo.GetRiskyOutcomes(ctx, event.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load risky outcomes: %w", err)
+	}
+	if len(outcomes) == 0 && (result.HomeTeam != "" || event.HomeTeam == "") {
+		return event.ResultData, nil
+	}
+
+	// Knockout picks are scored by team name
+	if result.HomeTeam == "" && result.AwayTeam == "" {
+		result.HomeTeam = event.HomeTeam
+		result.AwayTeam = event.AwayTeam
+	}
+
+	if result.Stats == nil && len(outcomes) > 0 {
+		result.Stats = make(map[string]interface{}, len(outcomes))
+	}
+	for slug, occurred := range outcomes {
+		// Explicit values in the result payload win over recorded outcomes
+		if _, exists := result.Stats[slug]; !exists {
+			result.Stats[slug] = occurred
+		}
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// settlementCode maps a settlement run to a response code
+func settlementCode(failed uint32) int32 {
+	if failed > 0 {
+		return int32(common.ErrorCode_INTERNAL_ERROR)
+	}
+	return 0
+}
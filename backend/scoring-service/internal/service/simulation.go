@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"github.com/sports-prediction-contests/shared/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SimulateRules scores a contest's settled predictions with alternative rules and compares the
+// resulting leaderboard with the real one. Everything happens in memory: no score, streak or
+// leaderboard is written.
+func (s *ScoringService) SimulateRules(ctx context.Context, req *pb.SimulateRulesRequest) (*pb.SimulateRulesResponse, error) {
+	if req.ContestId == 0 {
+		return simulationFailure(common.ErrorCode_INVALID_ARGUMENT, "Contest ID is required"), nil
+	}
+	contestID := uint(req.ContestId)
+
+	contestRules, err := s.settlementRepo.GetContestRulesHistory(ctx, contestID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load rules for contest %d: %v", contestID, err)
+		return simulationFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to load contest rules"), nil
+	}
+	current, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load rules for contest %d: %v", contestID, err)
+		return simulationFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to load contest rules"), nil
+	}
+
+	// Alternative rules select the scoring profile of the contest's sport like its own rules
+	rulesJSON := scoring.RulesWithSport(req.Rules, contestRules.SportType)
+	alternative, err := scoring.ParseRules(rulesJSON)
+	if err != nil {
+		return simulationFailure(common.ErrorCode_INVALID_ARGUMENT, "Invalid rules: "+err.Error()), nil
+	}
+
+	// Survivor and pool contests rank by rounds survived and winnings rather than points
+	for _, rules := range []*scoring.ContestRules{current, alternative} {
+		if rules.Type == scoring.ContestTypeSurvivor || (rules.Type == scoring.ContestTypeTotalizator && rules.Totalizator.Pool != nil) {
+			return simulationFailure(common.ErrorCode_INVALID_ARGUMENT, "Rules can only be simulated for contests ranked by points"), nil
+		}
+	}
+
+	now := time.Now()
+	history, err := s.scoreRepo.ListHistoryByContest(ctx, contestID, now)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load score history of contest %d: %v", contestID, err)
+		return simulationFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to load settled predictions"), nil
+	}
+
+	simulated, rescored, err := s.simulateScores(ctx, contestID, history, rulesJSON, alternative)
+	if err != nil {
+		log.Printf("[ERROR] Failed to simulate rules for contest %d: %v", contestID, err)
+		return simulationFailure(common.ErrorCode_INTERNAL_ERROR, "Failed to load settled predictions"), nil
+	}
+
+	parsed := make(map[string]*scoring.ContestRules)
+	currentEntries := replayedEntries(models.ReplayScores(history, func(entry *models.ScoreHistory) *scoring.ContestRules {
+		return chainRules(contestRules, entry, parsed)
+	}), current, now, len(history))
+	simulatedEntries := replayedEntries(models.ReplayScores(simulated, func(*models.ScoreHistory) *scoring.ContestRules {
+		return alternative
+	}), alternative, now, len(history))
+
+	currentByUser := make(map[uint32]*pb.LeaderboardEntry, len(currentEntries))
+	for _, entry := range currentEntries {
+		currentByUser[entry.UserId] = entry
+	}
+	entries := make([]*pb.RulesSimulationEntry, len(simulatedEntries))
+	for i, entry := range simulatedEntries {
+		actual := currentByUser[entry.UserId]
+		entries[i] = &pb.RulesSimulationEntry{
+			UserId:          entry.UserId,
+			CurrentPoints:   actual.TotalPoints,
+			SimulatedPoints: entry.TotalPoints,
+			PointsDelta:     math.Round((entry.TotalPoints-actual.TotalPoints)*100) / 100,
+			CurrentRank:     actual.Rank,
+			SimulatedRank:   entry.Rank,
+			RankDelta:       int32(actual.Rank) - int32(entry.Rank),
+		}
+	}
+
+	kept := uint32(len(history)) - rescored
+	log.Printf("[INFO] Rules simulated for contest %d: rescored=%d, kept=%d", contestID, rescored, kept)
+
+	return &pb.SimulateRulesResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   fmt.Sprintf("Simulated %d settled predictions", rescored),
+			Code:      int32(0),
+			Timestamp: timestamppb.Now(),
+		},
+		Current:              &pb.Leaderboard{ContestId: req.ContestId, Entries: currentEntries, UpdatedAt: timestamppb.New(now)},
+		Simulated:            &pb.Leaderboard{ContestId: req.ContestId, Entries: simulatedEntries, UpdatedAt: timestamppb.New(now)},
+		Entries:              entries,
+		SimulatedPredictions: rescored,
+		KeptPredictions:      kept,
+	}, nil
+}
+
+// simulateScores re-evaluates a contest's score history with alternative rules, replaying
+// each user's streak in chain order so multipliers follow the new points. Manual scores
+// and scores whose prediction or event result is gone keep their base points. It returns the
+// simulated history and how many scores were re-evaluated.
+func (s *ScoringService) simulateScores(ctx context.Context, contestID uint, history []*models.ScoreHistory, rulesJSON string, rules *scoring.ContestRules) ([]*models.ScoreHistory, uint32, error) {
+	predictions, err := s.settlementRepo.ListContestPredictions(ctx, contestID)
+	if err != nil {
+		return nil, 0, err
+	}
+	predictionsByID := make(map[uint]*models.SettlementPrediction, len(predictions))
+	for _, prediction := range predictions {
+		predictionsByID[prediction.ID] = prediction
+	}
+
+	fixedPoints := !rules.AppliesMultipliers()
+	results := make(map[uint]string)
+	streaks := make(map[uint]*models.UserStreak)
+	simulated := make([]*models.ScoreHistory, len(history))
+	var rescored uint32
+
+	for i, entry := range history {
+		score := *entry
+		basePoints := score.GetBasePoints()
+
+		prediction, ok := predictionsByID[score.PredictionID]
+		if ok && score.RuleMatched != ruleManual {
+			resultData, err := s.simulationResult(ctx, prediction.EventID, results)
+			if err != nil {
+				return nil, 0, err
+			}
+			if resultData != "" {
+				points, details := s.CalculateWithContestRules(ctx, prediction.PredictionData, resultData, rulesJSON)
+				basePoints = points
+				score.RuleMatched = ruleFromDetails(details)
+				rescored++
+			}
+		}
+
+		score.TimeCoefficient = 1.0
+		if !fixedPoints && score.SubmittedAt != nil && score.EventDate != nil {
+			score.TimeCoefficient, score.TimeTier = models.CalculateWithTier(*score.SubmittedAt, *score.EventDate, rules.TimeCoefficient)
+		}
+
+		streak, ok := streaks[score.UserID]
+		if !ok {
+			streak = &models.UserStreak{UserID: score.UserID, ContestID: contestID}
+			streaks[score.UserID] = streak
+		}
+		if score.RuleMatched != ruleVoid {
+			streak.RecordResult(score.PredictionID, rules.Streak.IsHit(score.RuleMatched, basePoints), rules.Streak)
+		}
+		streakMultiplier := streak.MultiplierFor(rules.Streak)
+		if fixedPoints {
+			streakMultiplier = 1.0
+		}
+		score.ApplyMultipliers(basePoints, streakMultiplier)
+		simulated[i] = &score
+	}
+	return simulated, rescored, nil
+}
+
+// simulationResult returns an event's settlement result, cached per event. Events that are
+// not completed with a result return an empty result.
+func (s *ScoringService) simulationResult(ctx context.Context, eventID uint, results map[uint]string) (string, error) {
+	if resultData, ok := results[eventID]; ok {
+		return resultData, nil
+	}
+	event, err := s.settlementRepo.GetEvent(ctx, eventID)
+	if err != nil || !event.IsCompleted() || event.ResultData == "" {
+		results[eventID] = ""
+		return "", nil
+	}
+	resultData, err := s.buildSettlementResult(ctx, event)
+	if err != nil {
+		return "", fmt.Errorf("failed to prepare result of event %d: %w", eventID, err)
+	}
+	results[eventID] = resultData
+	return resultData, nil
+}
+
+// simulationFailure builds a failed SimulateRules response
+func simulationFailure(code common.ErrorCode, message string) *pb.SimulateRulesResponse {
+	return &pb.SimulateRulesResponse{
+		Response: &common.Response{
+			Success:   false,
+			Message:   message,
+			Code:      int32(code),
+			Timestamp: timestamppb.Now(),
+		},
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"log"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+)
+
+// rateEvent updates the skill ratings of everyone who predicted a fully settled event. Each
+// prediction is measured against the other predictions of its contest by base points, so time
+// coefficients and streak multipliers do not count towards skill. An event is rated only once.
+func (s *ScoringService) rateEvent(ctx context.Context, eventID uint) {
+	scores, err := s.scoreRepo.ListByEvent(ctx, eventID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load scores for rating event %d: %v", eventID, err)
+		return
+	}
+
+	// Void predictions were never decided, so they neither win nor lose rating
+	results := make([]models.FieldResult, 0, len(scores))
+	var userIDs []uint
+	seen := make(map[uint]bool)
+	for _, score := range scores {
+		if score.RuleMatched == ruleVoid {
+			continue
+		}
+		results = append(results, models.FieldResult{UserID: score.UserID, ContestID: score.ContestID, Points: score.GetBasePoints()})
+		if !seen[score.UserID] {
+			seen[score.UserID] = true
+			userIDs = append(userIDs, score.UserID)
+		}
+	}
+
+	rated, err := s.skillRepo.ApplyEventRatings(ctx, eventID, userIDs, func(ratings map[uint]*models.SkillRating) []*models.SkillRatingChange {
+		return models.RateEvent(eventID, results, ratings)
+	})
+	if err != nil {
+		log.Printf("[WARN] Failed to store skill ratings for event %d: %v", eventID, err)
+		return
+	}
+	if rated > 0 {
+		log.Printf("[INFO] Event %d rated for %d predictors", eventID, rated)
+	}
+}
@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/models"
+	"github.com/sports-prediction-contests/scoring-service/internal/repository"
+	"github.com/sports-prediction-contests/shared/proto/common"
+	notificationpb "github.com/sports-prediction-contests/shared/proto/notification"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// matchdayLayout labels matchday snapshots by the UTC date of their matches
+const matchdayLayout = "2006-01-02"
+
+// CreateLeaderboardSnapshot snapshots a contest's current standings as its next round, e.g. when
+// an organizer closes a round. Snapshotting a label that was already used returns the stored round.
+func (s *ScoringService) CreateLeaderboardSnapshot(ctx context.Context, req *pb.CreateLeaderboardSnapshotRequest) (*pb.CreateLeaderboardSnapshotResponse, error) {
+	if req.ContestId == 0 {
+		return &pb.CreateLeaderboardSnapshotResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Contest ID is required",
+				Code:      int32(common.ErrorCode_INVALID_ARGUMENT),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	if failure := s.authorizeOrganizer(ctx, uint(req.ContestId)); failure != nil {
+		return &pb.CreateLeaderboardSnapshotResponse{Response: failure}, nil
+	}
+
+	round, replayed, err := s.takeSnapshot(ctx, uint(req.ContestId), strings.TrimSpace(req.Label))
+	if err != nil {
+		log.Printf("[ERROR] Failed to snapshot leaderboard of contest %d: %v", req.ContestId, err)
+		return &pb.CreateLeaderboardSnapshotResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to snapshot leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbRound, err := roundToProto(ctx, s.snapshotRepo, round, 100)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load round %d of contest %d: %v", round.Round, req.ContestId, err)
+		return &pb.CreateLeaderboardSnapshotResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to load snapshot",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	message := "Leaderboard snapshot created successfully"
+	if replayed {
+		message = "Leaderboard already snapshotted under this label"
+	}
+	return &pb.CreateLeaderboardSnapshotResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   message,
+			Code:      0,
+			Timestamp: timestamppb.Now(),
+		},
+		Round:    pbRound,
+		Replayed: replayed,
+	}, nil
+}
+
+// takeSnapshot re-ranks a contest and stores its standings as the next round, then tells each
+// participant how the round went. A label that was already snapshotted returns the stored round.
+func (s *ScoringService) takeSnapshot(ctx context.Context, contestID uint, label string) (*models.LeaderboardRound, bool, error) {
+	if label != "" {
+		if existing, err := s.snapshotRepo.GetRoundByLabel(ctx, contestID, label); err != nil {
+			return nil, false, err
+		} else if existing != nil {
+			return existing, true, nil
+		}
+	}
+
+	if err := s.recalculateRanks(ctx, contestID); err != nil {
+		return nil, false, err
+	}
+	leaderboards, _, err := s.leaderboardRepo.ListByContest(ctx, contestID, -1, -1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load leaderboard: %w", err)
+	}
+	previous, err := s.snapshotRepo.GetRound(ctx, contestID, 0)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load previous round: %w", err)
+	}
+
+	round, err := s.snapshotRepo.TakeSnapshot(ctx, contestID, label, time.Now().UTC(), leaderboards)
+	if err != nil {
+		// A concurrent snapshot of the same label wins, return its round
+		if label != "" {
+			if existing, getErr := s.snapshotRepo.GetRoundByLabel(ctx, contestID, label); getErr == nil && existing != nil {
+				return existing, true, nil
+			}
+		}
+		return nil, false, err
+	}
+
+	if err := s.notifyRound(ctx, round, previous, leaderboards); err != nil {
+		log.Printf("[WARN] Failed to notify participants of round %d in contest %d: %v", round.Round, contestID, err)
+	}
+	log.Printf("[INFO] Leaderboard of contest %d snapshotted as round %d (%s)", contestID, round.Round, round.Label)
+	return round, false, nil
+}
+
+// notifyRound sends every participant an in-app notification with their rank and movement
+// through the notification service. Every participant is tried; the last failure is returned.
+func (s *ScoringService) notifyRound(ctx context.Context, round, previous *models.LeaderboardRound, leaderboards []*models.Leaderboard) error {
+	before := make(map[uint]*models.LeaderboardSnapshot)
+	if previous != nil {
+		snapshots, err := s.snapshotRepo.ListSnapshots(ctx, previous.ContestID, previous.Round)
+		if err != nil {
+			return err
+		}
+		for _, snapshot := range snapshots {
+			before[snapshot.UserID] = snapshot
+		}
+	}
+
+	var failed error
+	for _, leaderboard := range leaderboards {
+		change := before[leaderboard.UserID].RankChange(leaderboard.Rank)
+		points := before[leaderboard.UserID].PointsSince(leaderboard.TotalPoints)
+		data, _ := json.Marshal(map[string]interface{}{
+			"contest_id":   round.ContestID,
+			"round":        round.Round,
+			"rank":         leaderboard.Rank,
+			"rank_change":  change,
+			"round_points": points,
+		})
+		err := s.notifier.SendInApp(ctx, leaderboard.UserID, notificationpb.NotificationType_LEADERBOARD_UPDATE,
+			fmt.Sprintf("%s standings", round.Label),
+			fmt.Sprintf("You are #%d with %.1f points%s, %+.1f this round.",
+				leaderboard.Rank, leaderboard.TotalPoints, rankMovement(change), points),
+			string(data))
+		if err != nil {
+			failed = fmt.Errorf("user %d: %w", leaderboard.UserID, err)
+		}
+	}
+	return failed
+}
+
+// rankMovement describes a rank change for notifications
+func rankMovement(change int32) string {
+	switch {
+	case change > 0:
+		return fmt.Sprintf(" (up %d)", change)
+	case change < 0:
+		return fmt.Sprintf(" (down %d)", -change)
+	}
+	return ""
+}
+
+// snapshotFinishedMatchday snapshots a contest's standings once the last of its matches on the
+// settled event's day is settled, unless the contest only takes snapshots on request
+func (s *ScoringService) snapshotFinishedMatchday(ctx context.Context, contestID uint, event *models.SettlementEvent) {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		log.Printf("[WARN] Failed to load rules for contest %d, skipping matchday snapshot: %v", contestID, err)
+		return
+	}
+	if !rules.Leaderboard.SnapshotsMatchdays() {
+		return
+	}
+
+	matchday := event.EventDate.UTC().Format(matchdayLayout)
+	finished, err := s.matchdayFinished(ctx, contestID, matchday)
+	if err != nil {
+		log.Printf("[WARN] Failed to check matchday %s of contest %d: %v", matchday, contestID, err)
+		return
+	}
+	if !finished {
+		return
+	}
+
+	if _, _, err := s.takeSnapshot(ctx, contestID, matchday); err != nil {
+		log.Printf("[WARN] Failed to snapshot matchday %s of contest %d: %v", matchday, contestID, err)
+	}
+}
+
+// matchdayFinished checks that every contest match on a day is completed and has no
+// predictions of the contest left to score
+func (s *ScoringService) matchdayFinished(ctx context.Context, contestID uint, matchday string) (bool, error) {
+	eventIDs, err := s.contestEventIDs(ctx, contestID)
+	if err != nil {
+		return false, err
+	}
+	events, err := s.settlementRepo.ListEvents(ctx, eventIDs)
+	if err != nil {
+		return false, fmt.Errorf("failed to load contest events: %w", err)
+	}
+
+	for _, event := range events {
+		if event.EventDate.UTC().Format(matchdayLayout) != matchday {
+			continue
+		}
+		if !event.IsCompleted() {
+			return false, nil
+		}
+		pending, err := s.settlementRepo.ListPendingPredictions(ctx, event.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to load pending predictions: %w", err)
+		}
+		for _, prediction := range pending {
+			if prediction.ContestID == contestID {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// contestEventIDs returns the events selected for a contest, or for contests without a fixed
+// list of events, every event its participants predicted
+func (s *ScoringService) contestEventIDs(ctx context.Context, contestID uint) ([]uint, error) {
+	eventIDs, err := s.settlementRepo.ListContestEventIDs(ctx, contestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contest events: %w", err)
+	}
+	if len(eventIDs) > 0 {
+		return eventIDs, nil
+	}
+
+	predictions, err := s.settlementRepo.ListContestPredictions(ctx, contestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contest predictions: %w", err)
+	}
+	seen := make(map[uint]bool)
+	for _, prediction := range predictions {
+		if !seen[prediction.EventID] {
+			seen[prediction.EventID] = true
+			eventIDs = append(eventIDs, prediction.EventID)
+		}
+	}
+	return eventIDs, nil
+}
+
+// GetRoundLeaderboard retrieves a contest's standings as snapshotted at the end of a round,
+// with each participant's movement and points since the round before
+func (s *LeaderboardService) GetRoundLeaderboard(ctx context.Context, req *pb.GetRoundLeaderboardRequest) (*pb.GetRoundLeaderboardResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 50 // Default limit
+	}
+
+	round, err := s.snapshotRepo.GetRound(ctx, uint(req.ContestId), uint(req.Round))
+	if err != nil {
+		log.Printf("[ERROR] Failed to get round %d of contest %d: %v", req.Round, req.ContestId, err)
+		return &pb.GetRoundLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve round leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+	if round == nil {
+		return &pb.GetRoundLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Round not found",
+				Code:      int32(common.ErrorCode_NOT_FOUND),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	pbRound, err := roundToProto(ctx, s.snapshotRepo, round, limit)
+	if err != nil {
+		log.Printf("[ERROR] Failed to load round %d of contest %d: %v", round.Round, req.ContestId, err)
+		return &pb.GetRoundLeaderboardResponse{
+			Response: &common.Response{
+				Success:   false,
+				Message:   "Failed to retrieve round leaderboard",
+				Code:      int32(common.ErrorCode_INTERNAL_ERROR),
+				Timestamp: timestamppb.Now(),
+			},
+		}, nil
+	}
+
+	return &pb.GetRoundLeaderboardResponse{
+		Response: &common.Response{
+			Success:   true,
+			Message:   "Round leaderboard retrieved successfully",
+			Code:      0,
+			Timestamp: timestamppb.Now(),
+		},
+		Round: pbRound,
+	}, nil
+}
+
+// latestSnapshots returns each participant's standing in a contest's latest round, empty
+// before the first snapshot
+func (s *LeaderboardService) latestSnapshots(ctx context.Context, contestID uint) (map[uint]*models.LeaderboardSnapshot, error) {
+	latest, err := s.snapshotRepo.LatestRound(ctx, contestID)
+	if err != nil || latest == 0 {
+		return nil, err
+	}
+	snapshots, err := s.snapshotRepo.ListSnapshots(ctx, contestID, latest)
+	if err != nil {
+		return nil, err
+	}
+
+	byUser := make(map[uint]*models.LeaderboardSnapshot, len(snapshots))
+	for _, snapshot := range snapshots {
+		byUser[snapshot.UserID] = snapshot
+	}
+	return byUser, nil
+}
+
+// roundToProto converts a round's top standings to protobuf message, comparing each
+// participant with the round before
+func roundToProto(ctx context.Context, repo repository.SnapshotRepositoryInterface, round *models.LeaderboardRound, limit int) (*pb.LeaderboardRound, error) {
+	snapshots, err := repo.ListSnapshots(ctx, round.ContestID, round.Round)
+	if err != nil {
+		return nil, err
+	}
+	before := make(map[uint]*models.LeaderboardSnapshot)
+	if round.Round > 1 {
+		previous, err := repo.ListSnapshots(ctx, round.ContestID, round.Round-1)
+		if err != nil {
+			return nil, err
+		}
+		for _, snapshot := range previous {
+			before[snapshot.UserID] = snapshot
+		}
+	}
+	latest, err := repo.LatestRound(ctx, round.ContestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(snapshots) > limit {
+		snapshots = snapshots[:limit]
+	}
+	entries := make([]*pb.LeaderboardEntry, len(snapshots))
+	for i, snapshot := range snapshots {
+		entries[i] = &pb.LeaderboardEntry{
+			UserId:      uint32(snapshot.UserID),
+			TotalPoints: snapshot.TotalPoints,
+			Rank:        uint32(snapshot.Rank),
+			UpdatedAt:   timestamppb.New(round.TakenAt),
+			RankChange:  before[snapshot.UserID].RankChange(snapshot.Rank),
+			RoundPoints: before[snapshot.UserID].PointsSince(snapshot.TotalPoints),
+		}
+	}
+
+	return &pb.LeaderboardRound{
+		ContestId:   uint32(round.ContestID),
+		Round:       uint32(round.Round),
+		Label:       round.Label,
+		TakenAt:     timestamppb.New(round.TakenAt),
+		Entries:     entries,
+		LatestRound: uint32(latest),
+	}, nil
+}
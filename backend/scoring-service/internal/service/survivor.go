@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// survivorStanding replays a participant's survivor picks in round order
+func (s *ScoringService) survivorStanding(ctx context.Context, rules *scoring.ContestRules, contestID, userID uint) (scoring.SurvivorStanding, error) {
+	scores, err := s.scoreRepo.ListByContestAndUserInEventOrder(ctx, contestID, userID)
+	if err != nil {
+		return scoring.SurvivorStanding{}, fmt.Errorf("failed to load survivor picks: %w", err)
+	}
+
+	outcomes := make([]string, len(scores))
+	for i, score := range scores {
+		outcomes[i] = score.RuleMatched
+	}
+	return rules.Survivor.Standing(outcomes), nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sports-prediction-contests/shared/scoring"
+)
+
+// contestRules loads and parses a contest's rules. Contests without rules are standard.
+func (s *ScoringService) contestRules(ctx context.Context, contestID uint) (*scoring.ContestRules, error) {
+	rulesJSON, err := s.settlementRepo.GetContestRules(ctx, contestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contest rules: %w", err)
+	}
+	return scoring.ParseRulesOrDefault(rulesJSON), nil
+}
+
+// recalculateRanks ranks a contest's leaderboard with the contest's tie-breakers
+func (s *ScoringService) recalculateRanks(ctx context.Context, contestID uint) error {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		return err
+	}
+	return s.leaderboardRepo.RecalculateRanks(ctx, contestID, rules.Leaderboard)
+}
+
+// userTotal returns a user's leaderboard total
+func (s *ScoringService) userTotal(ctx context.Context, contestID, userID uint) (float64, error) {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		return 0, err
+	}
+	return s.totalForRules(ctx, rules, contestID, userID)
+}
+
+// totalForRules returns a user's leaderboard total for the contest type: rounds survived
+// before elimination for survivor contests, pool winnings once a totalizator draw is settled,
+// otherwise the sum of points
+func (s *ScoringService) totalForRules(ctx context.Context, rules *scoring.ContestRules, contestID, userID uint) (float64, error) {
+	switch {
+	case rules.Type == scoring.ContestTypeSurvivor:
+		standing, err := s.survivorStanding(ctx, rules, contestID, userID)
+		if err != nil {
+			return 0, err
+		}
+		return float64(standing.RoundsSurvived), nil
+
+	case rules.Type == scoring.ContestTypeTotalizator && rules.Totalizator.Pool != nil:
+		winnings, settled, err := s.poolWinnings(ctx, contestID, userID)
+		if err != nil {
+			return 0, err
+		}
+		if settled {
+			return winnings, nil
+		}
+	}
+
+	totalPoints, err := s.scoreRepo.GetTotalPointsByContestAndUser(ctx, contestID, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get total points: %w", err)
+	}
+	return totalPoints, nil
+}
+
+// refreshUserTotal recomputes a user's total points and writes it to the leaderboard.
+// For survivor contests it also stores the participant's lost lives and elimination.
+func (s *ScoringService) refreshUserTotal(ctx context.Context, contestID, userID uint) error {
+	rules, err := s.contestRules(ctx, contestID)
+	if err != nil {
+		return err
+	}
+
+	var totalPoints float64
+	if rules.Type == scoring.ContestTypeSurvivor {
+		standing, err := s.survivorStanding(ctx, rules, contestID, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.settlementRepo.UpdateParticipantSurvival(ctx, contestID, userID, standing.LivesLost, standing.Eliminated); err != nil {
+			return fmt.Errorf("failed to update survivor status: %w", err)
+		}
+		totalPoints = float64(standing.RoundsSurvived)
+	} else {
+		totalPoints, err = s.totalForRules(ctx, rules, contestID, userID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := s.leaderboardRepo.UpsertUserScore(ctx, contestID, userID, totalPoints); err != nil {
+		return fmt.Errorf("failed to update leaderboard: %w", err)
+	}
+	return nil
+}
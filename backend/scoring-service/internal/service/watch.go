@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/sports-prediction-contests/scoring-service/internal/cache"
+	pb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// WatchLeaderboard streams a contest's standing changes until the client disconnects. Clients
+// load the leaderboard first and apply the deltas on top of it.
+func (s *LeaderboardService) WatchLeaderboard(req *pb.WatchLeaderboardRequest, stream grpc.ServerStreamingServer[pb.LeaderboardUpdate]) error {
+	if req.ContestId == 0 {
+		return status.Error(codes.InvalidArgument, "contest ID is required")
+	}
+
+	ctx := stream.Context()
+	subscription, err := s.leaderboardRepo.Subscribe(ctx, uint(req.ContestId))
+	if err != nil {
+		log.Printf("[ERROR] Failed to watch leaderboard of contest %d: %v", req.ContestId, err)
+		return status.Error(codes.Unavailable, "leaderboard updates are unavailable")
+	}
+	defer subscription.Close()
+
+	for {
+		deltas, err := subscription.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+			log.Printf("[ERROR] Leaderboard updates of contest %d stopped: %v", req.ContestId, err)
+			return status.Error(codes.Unavailable, "leaderboard updates were interrupted")
+		}
+
+		if err := stream.Send(leaderboardUpdateToProto(uint(req.ContestId), deltas)); err != nil {
+			return err
+		}
+	}
+}
+
+// leaderboardUpdateToProto converts published standing changes to a protobuf update
+func leaderboardUpdateToProto(contestID uint, deltas []cache.LeaderboardDelta) *pb.LeaderboardUpdate {
+	changes := make([]*pb.LeaderboardDelta, len(deltas))
+	for i, delta := range deltas {
+		changes[i] = &pb.LeaderboardDelta{
+			UserId:       uint32(delta.UserID),
+			Rank:         uint32(delta.Rank),
+			PreviousRank: uint32(delta.PreviousRank),
+			TotalPoints:  delta.TotalPoints,
+			PointsDelta:  delta.PointsDelta,
+		}
+	}
+	return &pb.LeaderboardUpdate{
+		ContestId: uint32(contestID),
+		Changes:   changes,
+		UpdatedAt: timestamppb.Now(),
+	}
+}
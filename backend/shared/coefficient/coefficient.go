@@ -1,6 +1,14 @@
 package coefficient
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// StandardTier is reported when no bonus tier applies
+const StandardTier = "Standard"
 
 // CoefficientResult contains both the multiplier and tier name
 type CoefficientResult struct {
@@ -8,23 +16,116 @@ type CoefficientResult struct {
 	Tier        string
 }
 
+// Tier is a window of time before the event start in which predictions earn a multiplier
+type Tier struct {
+	Name        string  `json:"name"`
+	FromHours   float64 `json:"from_hours"`         // inclusive lower bound, hours before the event
+	ToHours     float64 `json:"to_hours,omitempty"` // exclusive upper bound; 0 means no upper bound
+	Coefficient float64 `json:"coefficient"`
+}
+
+// Config is a contest's time coefficient configuration from its rules JSON
+type Config struct {
+	Disabled bool   `json:"disabled,omitempty"` // every prediction gets 1.0
+	Tiers    []Tier `json:"tiers,omitempty"`    // empty uses DefaultTiers
+}
+
+// DefaultTiers returns the tiers used when a contest does not define its own
+func DefaultTiers() []Tier {
+	return []Tier{
+		{Name: "Early Bird", FromHours: 168, Coefficient: 2.0},                 // 7+ days
+		{Name: "Ahead of Time", FromHours: 72, ToHours: 168, Coefficient: 1.5}, // 3-7 days
+		{Name: "Timely", FromHours: 24, ToHours: 72, Coefficient: 1.25},        // 1-3 days
+		{Name: "Last Minute", FromHours: 12, ToHours: 24, Coefficient: 1.1},    // 12-24 hours
+	}
+}
+
 // Calculate returns point multiplier and tier based on prediction timing
 // Earlier predictions relative to event start earn higher multipliers
 func Calculate(submittedAt, eventDate time.Time) CoefficientResult {
+	return CalculateWithTiers(submittedAt, eventDate, DefaultTiers())
+}
+
+// CalculateWithTiers returns the multiplier of the tier the prediction was submitted in,
+// or 1.0 outside every tier and once the event has started
+func CalculateWithTiers(submittedAt, eventDate time.Time, tiers []Tier) CoefficientResult {
 	hoursUntilEvent := eventDate.Sub(submittedAt).Hours()
+	if hoursUntilEvent < 0 {
+		return CoefficientResult{1.0, StandardTier} // Event already started
+	}
 
+	for _, tier := range tiers {
+		if tier.contains(hoursUntilEvent) {
+			return CoefficientResult{tier.Coefficient, tier.Name}
+		}
+	}
+	return CoefficientResult{1.0, StandardTier}
+}
+
+// Calculate returns the contest's multiplier for a prediction. A nil config uses the defaults.
+func (c *Config) Calculate(submittedAt, eventDate time.Time) CoefficientResult {
+	if c == nil {
+		return Calculate(submittedAt, eventDate)
+	}
+	if c.Disabled {
+		return CoefficientResult{1.0, StandardTier}
+	}
+	return CalculateWithTiers(submittedAt, eventDate, c.ActiveTiers())
+}
+
+// ActiveTiers returns the tiers the contest uses, nil when the bonus is disabled
+func (c *Config) ActiveTiers() []Tier {
 	switch {
-	case hoursUntilEvent < 0:
-		return CoefficientResult{1.0, "Standard"} // Event already started
-	case hoursUntilEvent >= 168: // 7+ days
-		return CoefficientResult{2.0, "Early Bird"}
-	case hoursUntilEvent >= 72: // 3-7 days
-		return CoefficientResult{1.5, "Ahead of Time"}
-	case hoursUntilEvent >= 24: // 1-3 days
-		return CoefficientResult{1.25, "Timely"}
-	case hoursUntilEvent >= 12: // 12-24 hours
-		return CoefficientResult{1.1, "Last Minute"}
-	default:
-		return CoefficientResult{1.0, "Standard"}
+	case c == nil || (!c.Disabled && len(c.Tiers) == 0):
+		return DefaultTiers()
+	case c.Disabled:
+		return nil
+	}
+	return c.Tiers
+}
+
+// Validate checks that tiers are well formed, do not overlap and that earlier tiers never
+// pay less than later ones
+func (c *Config) Validate() error {
+	if c == nil || len(c.Tiers) == 0 {
+		return nil
+	}
+	if len(c.Tiers) > 10 {
+		return errors.New("time coefficient cannot have more than 10 tiers")
 	}
+
+	tiers := append([]Tier(nil), c.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].FromHours > tiers[j].FromHours })
+
+	names := make(map[string]bool, len(tiers))
+	for i, tier := range tiers {
+		if tier.Name == "" || tier.Name == StandardTier || names[tier.Name] {
+			return fmt.Errorf("time coefficient tier name %q must be set and unique", tier.Name)
+		}
+		names[tier.Name] = true
+
+		if tier.FromHours < 0 || (tier.ToHours != 0 && tier.ToHours <= tier.FromHours) {
+			return fmt.Errorf("tier %q must span a positive window before the event", tier.Name)
+		}
+		if tier.Coefficient < 1 || tier.Coefficient > 10 {
+			return fmt.Errorf("tier %q coefficient must be between 1 and 10", tier.Name)
+		}
+		if i == 0 {
+			continue
+		}
+
+		earlier := tiers[i-1]
+		if tier.ToHours == 0 || tier.ToHours > earlier.FromHours {
+			return fmt.Errorf("tiers %q and %q overlap", earlier.Name, tier.Name)
+		}
+		if tier.Coefficient > earlier.Coefficient {
+			return fmt.Errorf("tier %q cannot pay more than the earlier tier %q", tier.Name, earlier.Name)
+		}
+	}
+	return nil
+}
+
+// contains checks if a prediction made the given hours before the event falls into the tier
+func (t Tier) contains(hoursUntilEvent float64) bool {
+	return hoursUntilEvent >= t.FromHours && (t.ToHours == 0 || hoursUntilEvent < t.ToHours)
 }
@@ -40,3 +40,82 @@ func TestCalculate(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigCalculate(t *testing.T) {
+	now := time.Now()
+	custom := &Config{Tiers: []Tier{
+		{Name: "Week Ahead", FromHours: 168, Coefficient: 3.0},
+		{Name: "Matchday", FromHours: 2, ToHours: 24, Coefficient: 1.2},
+	}}
+
+	tests := []struct {
+		name          string
+		config        *Config
+		hoursAhead    float64
+		expectedCoeff float64
+		expectedTier  string
+	}{
+		{"nil config uses defaults", nil, 200, 2.0, "Early Bird"},
+		{"empty tiers use defaults", &Config{}, 48, 1.25, "Timely"},
+		{"disabled", &Config{Disabled: true}, 200, 1.0, StandardTier},
+		{"custom early tier", custom, 200, 3.0, "Week Ahead"},
+		{"gap between custom tiers", custom, 48, 1.0, StandardTier},
+		{"custom late tier", custom, 3, 1.2, "Matchday"},
+		{"custom tier upper bound is exclusive", custom, 24, 1.0, StandardTier},
+		{"event in past", custom, -1, 1.0, StandardTier},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventDate := now.Add(time.Duration(tt.hoursAhead * float64(time.Hour)))
+			result := tt.config.Calculate(now, eventDate)
+			if result.Coefficient != tt.expectedCoeff || result.Tier != tt.expectedTier {
+				t.Errorf("Calculate() = (%v, %q), want (%v, %q)", result.Coefficient, result.Tier, tt.expectedCoeff, tt.expectedTier)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *Config
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"disabled", &Config{Disabled: true}, false},
+		{"defaults", &Config{Tiers: DefaultTiers()}, false},
+		{"unordered but valid", &Config{Tiers: []Tier{
+			{Name: "Late", FromHours: 1, ToHours: 12, Coefficient: 1.1},
+			{Name: "Early", FromHours: 48, Coefficient: 1.5},
+		}}, false},
+		{"overlapping", &Config{Tiers: []Tier{
+			{Name: "Early", FromHours: 48, Coefficient: 1.5},
+			{Name: "Late", FromHours: 12, ToHours: 72, Coefficient: 1.1},
+		}}, true},
+		{"two unbounded tiers", &Config{Tiers: []Tier{
+			{Name: "Early", FromHours: 48, Coefficient: 1.5},
+			{Name: "Late", FromHours: 12, Coefficient: 1.1},
+		}}, true},
+		{"later tier pays more", &Config{Tiers: []Tier{
+			{Name: "Early", FromHours: 48, Coefficient: 1.2},
+			{Name: "Late", FromHours: 12, ToHours: 48, Coefficient: 1.5},
+		}}, true},
+		{"empty window", &Config{Tiers: []Tier{{Name: "Early", FromHours: 48, ToHours: 24, Coefficient: 1.5}}}, true},
+		{"coefficient below one", &Config{Tiers: []Tier{{Name: "Early", FromHours: 48, Coefficient: 0.5}}}, true},
+		{"missing name", &Config{Tiers: []Tier{{FromHours: 48, Coefficient: 1.5}}}, true},
+		{"duplicate name", &Config{Tiers: []Tier{
+			{Name: "Bonus", FromHours: 48, Coefficient: 1.5},
+			{Name: "Bonus", FromHours: 12, ToHours: 48, Coefficient: 1.1},
+		}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
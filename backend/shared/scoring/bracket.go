@@ -0,0 +1,266 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ContestTypeBracket is a knockout bracket contest: before the first match the participant
+// picks the winner of every slot, later rounds only from their own earlier picks
+const ContestTypeBracket ContestType = "bracket"
+
+// Bracket pick outcomes reported as match_type
+const (
+	BracketCorrect = "bracket_correct"
+	BracketWrong   = "bracket_wrong"
+)
+
+// BracketRules defines rules for bracket contest
+type BracketRules struct {
+	Rounds []BracketRound `json:"rounds"` // rounds from first to final
+	Slots  []BracketSlot  `json:"slots"`  // every match of the bracket
+}
+
+// BracketRound defines the points a correct pick earns in a round
+type BracketRound struct {
+	Name   string  `json:"name"`
+	Points float64 `json:"points"` // default doubles every round: 1, 2, 4, ...
+}
+
+// BracketSlot is one match of the bracket. First round slots are seeded with teams,
+// later slots are fed by the winners of two earlier slots.
+type BracketSlot struct {
+	ID       string `json:"id"`
+	Round    int    `json:"round"`    // 1-based
+	EventID  uint   `json:"event_id"` // event played in this slot; later rounds use placeholder events
+	HomeSeed string `json:"home_seed,omitempty"`
+	AwaySeed string `json:"away_seed,omitempty"`
+	HomeFrom string `json:"home_from,omitempty"` // slot whose winner plays at home
+	AwayFrom string `json:"away_from,omitempty"` // slot whose winner plays away
+}
+
+// BracketSlotResult is the decided outcome of a bracket slot
+type BracketSlotResult struct {
+	Winner string
+	Loser  string
+}
+
+// Slot returns the slot with the given ID
+func (b *BracketRules) Slot(id string) (*BracketSlot, bool) {
+	for i := range b.Slots {
+		if b.Slots[i].ID == id {
+			return &b.Slots[i], true
+		}
+	}
+	return nil, false
+}
+
+// SlotByEvent returns the slot played as the given event
+func (b *BracketRules) SlotByEvent(eventID uint) (*BracketSlot, bool) {
+	for i := range b.Slots {
+		if b.Slots[i].EventID == eventID {
+			return &b.Slots[i], true
+		}
+	}
+	return nil, false
+}
+
+// EventIDs returns the events of every slot in bracket order
+func (b *BracketRules) EventIDs() []uint {
+	eventIDs := make([]uint, len(b.Slots))
+	for i, slot := range b.Slots {
+		eventIDs[i] = slot.EventID
+	}
+	return eventIDs
+}
+
+// RoundPoints returns the points for a correct pick in a 1-based round
+func (b *BracketRules) RoundPoints(round int) float64 {
+	if round < 1 || round > len(b.Rounds) {
+		return 0
+	}
+	return b.Rounds[round-1].Points
+}
+
+// MaxRemainingPoints returns the most points a participant can still earn: the points of
+// every undecided slot whose picked team has not been knocked out yet
+func (b *BracketRules) MaxRemainingPoints(picks map[string]string, results map[string]BracketSlotResult) float64 {
+	eliminated := make(map[string]bool, len(results))
+	for _, result := range results {
+		if result.Loser != "" {
+			eliminated[strings.ToLower(result.Loser)] = true
+		}
+	}
+
+	var remaining float64
+	for _, slot := range b.Slots {
+		if _, decided := results[slot.ID]; decided {
+			continue
+		}
+		team, ok := picks[slot.ID]
+		if !ok || eliminated[strings.ToLower(team)] {
+			continue
+		}
+		remaining += b.RoundPoints(slot.Round)
+	}
+	return remaining
+}
+
+func init() {
+	Register(bracketType{})
+}
+
+// bracketType scores each slot as its event settles: picking the team that went
+// through earns the round's points. Multipliers are not applied.
+type bracketType struct{}
+
+func (bracketType) Type() ContestType { return ContestTypeBracket }
+
+func (bracketType) ApplyDefaults(rules *ContestRules) {
+	if rules.Bracket == nil {
+		rules.Bracket = &BracketRules{}
+	}
+	points := 1.0
+	for i := range rules.Bracket.Rounds {
+		if rules.Bracket.Rounds[i].Points == 0 {
+			rules.Bracket.Rounds[i].Points = points
+		}
+		points *= 2
+	}
+}
+
+func (bracketType) Validate(rules *ContestRules) error {
+	bracket := rules.Bracket
+	if bracket == nil || len(bracket.Rounds) == 0 || len(bracket.Slots) == 0 {
+		return errors.New("bracket rounds and slots required for bracket contest")
+	}
+	if len(bracket.Slots) > 127 {
+		return errors.New("bracket cannot have more than 127 slots")
+	}
+	for _, round := range bracket.Rounds {
+		if round.Points < 0 {
+			return errors.New("round points cannot be negative")
+		}
+	}
+
+	slots := make(map[string]*BracketSlot, len(bracket.Slots))
+	events := make(map[uint]bool, len(bracket.Slots))
+	for i := range bracket.Slots {
+		slot := &bracket.Slots[i]
+		if slot.ID == "" {
+			return errors.New("every bracket slot needs an id")
+		}
+		if slots[slot.ID] != nil {
+			return fmt.Errorf("duplicate bracket slot %q", slot.ID)
+		}
+		if slot.Round < 1 || slot.Round > len(bracket.Rounds) {
+			return fmt.Errorf("slot %q has round %d outside 1..%d", slot.ID, slot.Round, len(bracket.Rounds))
+		}
+		if slot.EventID == 0 || events[slot.EventID] {
+			return fmt.Errorf("slot %q needs its own event_id", slot.ID)
+		}
+		slots[slot.ID] = slot
+		events[slot.EventID] = true
+	}
+
+	fed := make(map[string]bool, len(bracket.Slots))
+	finals := 0
+	for _, slot := range bracket.Slots {
+		if slot.Round == len(bracket.Rounds) {
+			finals++
+		}
+		if slot.Round == 1 {
+			if slot.HomeSeed == "" || slot.AwaySeed == "" || strings.EqualFold(slot.HomeSeed, slot.AwaySeed) {
+				return fmt.Errorf("first round slot %q needs two different seeded teams", slot.ID)
+			}
+			continue
+		}
+		for _, from := range []string{slot.HomeFrom, slot.AwayFrom} {
+			feeder, ok := slots[from]
+			if !ok || feeder.Round != slot.Round-1 {
+				return fmt.Errorf("slot %q must be fed by two slots of round %d", slot.ID, slot.Round-1)
+			}
+			if fed[from] {
+				return fmt.Errorf("slot %q feeds more than one slot", from)
+			}
+			fed[from] = true
+		}
+	}
+	if finals != 1 {
+		return errors.New("the last round must have exactly one slot")
+	}
+	if len(fed) != len(bracket.Slots)-1 {
+		return errors.New("every slot except the final must feed a later slot")
+	}
+	return nil
+}
+
+func (bracketType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	if _, ok := rules.Bracket.Slot(prediction.Slot); !ok {
+		return fmt.Errorf("unknown bracket slot %q", prediction.Slot)
+	}
+	if strings.TrimSpace(prediction.Team) == "" {
+		return errors.New("bracket pick must name a team")
+	}
+	return nil
+}
+
+// ValidatePredictionSet checks that the whole bracket is filled in and that every pick
+// can actually reach its slot: a seeded team in the first round, otherwise the winner
+// the participant picked in one of the two feeding slots
+func (bracketType) ValidatePredictionSet(rules *ContestRules, predictions []*PredictionData) error {
+	picks := make(map[string]string, len(predictions))
+	for _, prediction := range predictions {
+		if _, exists := picks[prediction.Slot]; exists {
+			return fmt.Errorf("slot %q is picked more than once", prediction.Slot)
+		}
+		picks[prediction.Slot] = prediction.Team
+	}
+
+	for _, slot := range rules.Bracket.Slots {
+		team, ok := picks[slot.ID]
+		if !ok {
+			return fmt.Errorf("slot %q is not picked", slot.ID)
+		}
+
+		candidates := []string{slot.HomeSeed, slot.AwaySeed}
+		if slot.Round > 1 {
+			candidates = []string{picks[slot.HomeFrom], picks[slot.AwayFrom]}
+		}
+		if !strings.EqualFold(team, candidates[0]) && !strings.EqualFold(team, candidates[1]) {
+			return fmt.Errorf("slot %q pick %q must be %q or %q", slot.ID, team, candidates[0], candidates[1])
+		}
+	}
+	return nil
+}
+
+func (bracketType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	details := map[string]interface{}{"type": string(ContestTypeBracket)}
+	slot, ok := rules.Bracket.Slot(prediction.Slot)
+	if !ok {
+		details["error"] = "Unknown bracket slot"
+		return CalculationResult{Points: 0, Details: details}
+	}
+
+	winner := result.WinningTeam()
+	details["slot"] = slot.ID
+	details["round"] = slot.Round
+	details["pick"] = prediction.Team
+	if winner == "" {
+		details["error"] = "Knockout result needs a winner"
+		return CalculationResult{Points: 0, Details: details}
+	}
+	details["winner"] = winner
+
+	if strings.EqualFold(prediction.Team, winner) {
+		details["match_type"] = BracketCorrect
+		return CalculationResult{Points: rules.Bracket.RoundPoints(slot.Round), Details: details}
+	}
+
+	details["match_type"] = BracketWrong
+	return CalculationResult{Points: 0, Details: details}
+}
+
+// FixedPoints reports that bracket points scale by round only and must not be scaled further
+func (bracketType) FixedPoints(rules *ContestRules) bool { return true }
@@ -0,0 +1,111 @@
+package scoring
+
+import (
+	"fmt"
+	"testing"
+)
+
+const fourTeamBracket = `{"type":"bracket","bracket":{
+	"rounds":[{"name":"Semi-finals"},{"name":"Final"}],
+	"slots":[
+		{"id":"SF1","round":1,"event_id":1,"home_seed":"Brazil","away_seed":"Spain"},
+		{"id":"SF2","round":1,"event_id":2,"home_seed":"France","away_seed":"Japan"},
+		{"id":"F","round":2,"event_id":3,"home_from":"SF1","away_from":"SF2"}
+	]}}`
+
+func TestBracketValidate(t *testing.T) {
+	rules, err := ParseRules(fourTeamBracket)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if rules.Bracket.RoundPoints(1) != 1 || rules.Bracket.RoundPoints(2) != 2 {
+		t.Errorf("default round points = %v, %v, want 1, 2", rules.Bracket.RoundPoints(1), rules.Bracket.RoundPoints(2))
+	}
+
+	invalid := []string{
+		`{"type":"bracket"}`,
+		`{"type":"bracket","bracket":{"rounds":[{}],"slots":[{"id":"F","round":1,"event_id":1,"home_seed":"A"}]}}`,
+		`{"type":"bracket","bracket":{"rounds":[{},{}],"slots":[
+			{"id":"SF1","round":1,"event_id":1,"home_seed":"A","away_seed":"B"},
+			{"id":"SF2","round":1,"event_id":2,"home_seed":"C","away_seed":"D"},
+			{"id":"F","round":2,"event_id":3,"home_from":"SF1","away_from":"SF1"}]}}`,
+	}
+	for _, rulesJSON := range invalid {
+		rules, err := ParseRules(rulesJSON)
+		if err != nil {
+			t.Fatalf("ParseRules() error = %v", err)
+		}
+		if err := rules.Validate(); err == nil {
+			t.Errorf("Validate() expected error for %s", rulesJSON)
+		}
+	}
+}
+
+func TestBracketValidatePredictionSet(t *testing.T) {
+	rules, _ := ParseRules(fourTeamBracket)
+	pick := func(slot, team string) string {
+		return fmt.Sprintf(`{"type":"bracket","slot":%q,"team":%q}`, slot, team)
+	}
+
+	tests := []struct {
+		name    string
+		picks   []string
+		wantErr bool
+	}{
+		{"consistent bracket", []string{pick("SF1", "Brazil"), pick("SF2", "Japan"), pick("F", "japan")}, false},
+		{"final pick knocked out earlier", []string{pick("SF1", "Brazil"), pick("SF2", "Japan"), pick("F", "France")}, true},
+		{"unseeded first round pick", []string{pick("SF1", "Italy"), pick("SF2", "Japan"), pick("F", "Japan")}, true},
+		{"missing slot", []string{pick("SF1", "Brazil"), pick("SF2", "Japan")}, true},
+		{"unknown slot", []string{pick("SF1", "Brazil"), pick("SF2", "Japan"), pick("QF", "Japan")}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.ValidatePredictionSet(tt.picks)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePredictionSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBracketScore(t *testing.T) {
+	rules, _ := ParseRules(fourTeamBracket)
+	if rules.AppliesMultipliers() {
+		t.Error("bracket points must not be scaled by multipliers")
+	}
+
+	// Final level after extra time, Brazil through on penalties
+	result := &ResultData{HomeScore: 1, AwayScore: 1, Winner: "home", HomeTeam: "Brazil", AwayTeam: "Japan"}
+	got := rules.Score(&PredictionData{Slot: "F", Team: "Brazil"}, result)
+	if got.Points != 2 || got.Details["match_type"] != BracketCorrect {
+		t.Errorf("Score() = %v %v, want 2 %s", got.Points, got.Details["match_type"], BracketCorrect)
+	}
+
+	got = rules.Score(&PredictionData{Slot: "F", Team: "Japan"}, result)
+	if got.Points != 0 || got.Details["match_type"] != BracketWrong {
+		t.Errorf("Score() = %v %v, want 0 %s", got.Points, got.Details["match_type"], BracketWrong)
+	}
+
+	got = rules.Score(&PredictionData{Slot: "F", Team: "Brazil"}, &ResultData{HomeScore: 1, AwayScore: 1, HomeTeam: "Brazil", AwayTeam: "Japan"})
+	if _, ok := got.Details["error"]; !ok {
+		t.Error("Score() expected error for a knockout draw without winner")
+	}
+}
+
+func TestBracketMaxRemainingPoints(t *testing.T) {
+	rules, _ := ParseRules(fourTeamBracket)
+	picks := map[string]string{"SF1": "Brazil", "SF2": "Japan", "F": "Japan"}
+
+	if got := rules.Bracket.MaxRemainingPoints(picks, nil); got != 4 {
+		t.Errorf("MaxRemainingPoints() before kick-off = %v, want 4", got)
+	}
+
+	results := map[string]BracketSlotResult{"SF2": {Winner: "France", Loser: "Japan"}}
+	if got := rules.Bracket.MaxRemainingPoints(picks, results); got != 1 {
+		t.Errorf("MaxRemainingPoints() after champion pick is out = %v, want 1", got)
+	}
+}
@@ -36,6 +36,11 @@ func (c *Calculator) calculateWithScoring(prediction, result ScoreData, isAnyOth
 		"is_any_other":    isAnyOther,
 	}
 
+	profile := c.profile()
+	if profile.Sport != SportFootball {
+		details["sport"] = profile.Sport
+	}
+
 	// Handle "any other" prediction
 	if isAnyOther {
 		isOther := c.isOtherScore(result.HomeScore, result.AwayScore)
@@ -60,10 +65,15 @@ func (c *Calculator) calculateWithScoring(prediction, result ScoreData, isAnyOth
 	details["predicted_outcome"] = predictedOutcome
 	details["actual_outcome"] = actualOutcome
 
-	// Goal difference match
+	// Goal difference match; sports with margin bands only need the margin in the same band
 	predictedDiff := prediction.HomeScore - prediction.AwayScore
 	actualDiff := result.HomeScore - result.AwayScore
-	if predictedDiff == actualDiff {
+	if len(profile.MarginBands) > 0 {
+		if predictedOutcome == actualOutcome && profile.marginBandOf(abs(predictedDiff)) == profile.marginBandOf(abs(actualDiff)) {
+			details["match_type"] = MarginBandMatch
+			return CalculationResult{Points: scoring.GoalDifference, Details: details}
+		}
+	} else if predictedDiff == actualDiff {
 		details["match_type"] = "goal_difference"
 		return CalculationResult{Points: scoring.GoalDifference, Details: details}
 	}
@@ -73,7 +83,7 @@ func (c *Calculator) calculateWithScoring(prediction, result ScoreData, isAnyOth
 		homeGoalsMatch := prediction.HomeScore == result.HomeScore
 		awayGoalsMatch := prediction.AwayScore == result.AwayScore
 
-		if homeGoalsMatch || awayGoalsMatch {
+		if profile.TeamScores && (homeGoalsMatch || awayGoalsMatch) {
 			details["match_type"] = "outcome_plus_team_goals"
 			details["home_goals_match"] = homeGoalsMatch
 			details["away_goals_match"] = awayGoalsMatch
@@ -199,9 +209,25 @@ func (c *Calculator) determineOutcome(homeScore, awayScore int) string {
 }
 
 // isOtherScore checks if score is outside common range (for "any other" predictions)
-// Common scores are typically 0-4 for each team
+// Common scores depend on the sport, e.g. 0-4 for each team in football
 func (c *Calculator) isOtherScore(homeScore, awayScore int) bool {
-	return homeScore > 4 || awayScore > 4
+	return c.profile().isOther(homeScore, awayScore)
+}
+
+// profile returns the scoring profile of the contest's sport
+func (c *Calculator) profile() *SportProfile {
+	if c.rules == nil {
+		return sportProfiles[SportFootball]
+	}
+	return c.rules.Profile()
+}
+
+// abs returns the absolute value of a score difference
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
 
 // ValidateRiskySelections checks if selections are valid
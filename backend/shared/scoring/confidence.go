@@ -0,0 +1,110 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ContestTypeConfidence is a confidence-points pick'em: for a round of N matches the
+// participant picks every outcome and ranks the picks with unique weights 1..N
+const ContestTypeConfidence ContestType = "confidence"
+
+// Confidence pick outcomes reported as match_type
+const (
+	ConfidenceCorrect = "correct_pick"
+	ConfidenceWrong   = "wrong_pick"
+)
+
+// ConfidenceRules defines rules for confidence contest.
+// Admin selects the round's matches with SetContestEvents, like a totalizator.
+type ConfidenceRules struct {
+	EventCount int `json:"event_count"` // matches in the round, and the highest weight (default 10)
+}
+
+// DefaultConfidenceRules returns default confidence rules
+func DefaultConfidenceRules() ConfidenceRules {
+	return ConfidenceRules{
+		EventCount: 10,
+	}
+}
+
+func init() {
+	Register(confidenceType{})
+}
+
+// confidenceType scores an outcome pick with the weight the participant gave it.
+// No multipliers are applied so the weights are exactly what a pick is worth.
+type confidenceType struct{}
+
+func (confidenceType) Type() ContestType { return ContestTypeConfidence }
+
+func (confidenceType) ApplyDefaults(rules *ContestRules) {
+	if rules.Confidence == nil {
+		defaultConfidence := DefaultConfidenceRules()
+		rules.Confidence = &defaultConfidence
+	}
+}
+
+func (confidenceType) Validate(rules *ContestRules) error {
+	if rules.Confidence == nil {
+		return errors.New("confidence rules required for confidence contest")
+	}
+	if rules.Confidence.EventCount < 2 || rules.Confidence.EventCount > 30 {
+		return errors.New("event_count must be between 2 and 30")
+	}
+	return nil
+}
+
+func (confidenceType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	if prediction.Winner == nil || (*prediction.Winner != "home" && *prediction.Winner != "draw" && *prediction.Winner != "away") {
+		return errors.New("confidence pick must be \"home\", \"draw\" or \"away\"")
+	}
+	if prediction.Confidence < 1 || prediction.Confidence > rules.Confidence.EventCount {
+		return fmt.Errorf("confidence must be between 1 and %d", rules.Confidence.EventCount)
+	}
+	return nil
+}
+
+// ValidatePredictionSet checks that a round's weights are a permutation of 1..N
+func (confidenceType) ValidatePredictionSet(rules *ContestRules, predictions []*PredictionData) error {
+	n := rules.Confidence.EventCount
+	if len(predictions) != n {
+		return fmt.Errorf("all %d matches of the round must be picked, got %d", n, len(predictions))
+	}
+
+	used := make(map[int]bool, n)
+	for _, prediction := range predictions {
+		if prediction.Confidence < 1 || prediction.Confidence > n {
+			return fmt.Errorf("confidence must be between 1 and %d", n)
+		}
+		if used[prediction.Confidence] {
+			return fmt.Errorf("confidence %d is used more than once", prediction.Confidence)
+		}
+		used[prediction.Confidence] = true
+	}
+	return nil
+}
+
+func (confidenceType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	details := map[string]interface{}{"type": string(ContestTypeConfidence)}
+	if prediction.Winner == nil || prediction.Confidence < 1 {
+		details["error"] = "Missing confidence pick"
+		return CalculationResult{Points: 0, Details: details}
+	}
+
+	actual := NewCalculator(rules).determineOutcome(result.HomeScore, result.AwayScore)
+	details["pick"] = *prediction.Winner
+	details["actual_outcome"] = actual
+	details["confidence"] = prediction.Confidence
+
+	if actual == *prediction.Winner {
+		details["match_type"] = ConfidenceCorrect
+		return CalculationResult{Points: float64(prediction.Confidence), Details: details}
+	}
+
+	details["match_type"] = ConfidenceWrong
+	return CalculationResult{Points: 0, Details: details}
+}
+
+// FixedPoints reports that confidence points are the weights themselves and must not be scaled
+func (confidenceType) FixedPoints(rules *ContestRules) bool { return true }
@@ -0,0 +1,64 @@
+package scoring
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConfidenceValidatePredictionSet(t *testing.T) {
+	rules, err := ParseRules(`{"type":"confidence","confidence":{"event_count":3}}`)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if !rules.RequiresPredictionSet() {
+		t.Fatal("confidence predictions must be submitted as a set")
+	}
+
+	pick := func(winner string, confidence int) string {
+		return fmt.Sprintf(`{"type":"confidence","winner":%q,"confidence":%d}`, winner, confidence)
+	}
+
+	tests := []struct {
+		name        string
+		predictions []string
+		wantErr     bool
+	}{
+		{"permutation", []string{pick("home", 2), pick("draw", 3), pick("away", 1)}, false},
+		{"duplicate weight", []string{pick("home", 2), pick("draw", 2), pick("away", 1)}, true},
+		{"weight out of range", []string{pick("home", 2), pick("draw", 4), pick("away", 1)}, true},
+		{"missing match", []string{pick("home", 2), pick("away", 1)}, true},
+		{"invalid pick", []string{pick("home", 2), pick("nobody", 3), pick("away", 1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.ValidatePredictionSet(tt.predictions)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePredictionSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfidenceScore(t *testing.T) {
+	rules, _ := ParseRules(`{"type":"confidence"}`)
+	if rules.AppliesMultipliers() {
+		t.Error("confidence points must not be scaled by multipliers")
+	}
+	if standard, _ := ParseRules(""); standard.RequiresPredictionSet() {
+		t.Error("standard predictions must not require a set")
+	}
+
+	home, draw := "home", "draw"
+	result := &ResultData{HomeScore: 2, AwayScore: 1}
+
+	got := rules.Score(&PredictionData{Winner: &home, Confidence: 7}, result)
+	if got.Points != 7 || got.Details["match_type"] != ConfidenceCorrect {
+		t.Errorf("correct pick Score() = %v %v, want 7 %s", got.Points, got.Details["match_type"], ConfidenceCorrect)
+	}
+
+	got = rules.Score(&PredictionData{Winner: &draw, Confidence: 7}, result)
+	if got.Points != 0 || got.Details["match_type"] != ConfidenceWrong {
+		t.Errorf("wrong pick Score() = %v %v, want 0 %s", got.Points, got.Details["match_type"], ConfidenceWrong)
+	}
+}
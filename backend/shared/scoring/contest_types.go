@@ -0,0 +1,241 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+)
+
+func init() {
+	Register(standardType{})
+	Register(riskyType{})
+	Register(totalizatorType{})
+	Register(relayType{})
+}
+
+// standardType scores a predicted score line against the final score
+type standardType struct{}
+
+func (standardType) Type() ContestType { return ContestTypeStandard }
+
+func (standardType) ApplyDefaults(rules *ContestRules) {
+	if rules.Standard == nil {
+		defaultRules := DefaultStandardRules()
+		rules.Standard = &defaultRules
+	}
+}
+
+func (standardType) Validate(rules *ContestRules) error {
+	if rules.Standard == nil {
+		return errors.New("standard rules required for standard contest")
+	}
+	return validateScoringPoints(rules.Standard)
+}
+
+func (standardType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	return validateScorePrediction(rules, prediction)
+}
+
+func (standardType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	return scoreLine(rules, prediction, result, rules.Standard, ContestTypeStandard)
+}
+
+// riskyType scores picked match events, adding points when they happen and subtracting when they do not
+type riskyType struct{}
+
+func (riskyType) Type() ContestType { return ContestTypeRisky }
+
+func (riskyType) ApplyDefaults(rules *ContestRules) {
+	if rules.Risky == nil {
+		defaultRisky := DefaultRiskyRules()
+		rules.Risky = &defaultRisky
+	}
+}
+
+func (riskyType) Validate(rules *ContestRules) error {
+	if rules.Risky == nil {
+		return errors.New("risky rules required for risky contest")
+	}
+	if rules.Risky.MaxSelections < 1 || rules.Risky.MaxSelections > 10 {
+		return errors.New("max_selections must be between 1 and 10")
+	}
+	if len(rules.Risky.Events) == 0 {
+		return errors.New("risky contest must have at least one event")
+	}
+	return nil
+}
+
+// ValidatePrediction only checks the shape of the selections: the events offered for a
+// match come from the risky event catalogue with per-match overrides, not from the rules
+func (riskyType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	selections := prediction.Selections()
+	if len(selections) == 0 {
+		return errors.New("at least one risky event must be selected")
+	}
+
+	maxSelections := rules.MaxRiskySelections()
+	if len(selections) > maxSelections {
+		return fmt.Errorf("too many selections: max %d allowed, got %d", maxSelections, len(selections))
+	}
+
+	seen := make(map[string]bool, len(selections))
+	for _, slug := range selections {
+		if slug == "" {
+			return errors.New("risky selection cannot be empty")
+		}
+		if seen[slug] {
+			return fmt.Errorf("duplicate selection: %s", slug)
+		}
+		seen[slug] = true
+	}
+	return nil
+}
+
+func (riskyType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	selections := prediction.Selections()
+	if len(selections) == 0 {
+		return CalculationResult{
+			Points:  0,
+			Details: map[string]interface{}{"type": "risky", "error": "Missing risky_selections"},
+		}
+	}
+	return NewCalculator(rules).CalculateRisky(selections, result.Outcomes())
+}
+
+// totalizatorType scores a fixed set of admin-selected matches with standard score-line rules
+type totalizatorType struct{}
+
+func (totalizatorType) Type() ContestType { return ContestTypeTotalizator }
+
+func (totalizatorType) ApplyDefaults(rules *ContestRules) {
+	if rules.Totalizator == nil {
+		defaultTotalizator := DefaultTotalizatorRules()
+		rules.Totalizator = &defaultTotalizator
+	}
+}
+
+func (totalizatorType) Validate(rules *ContestRules) error {
+	if rules.Totalizator == nil {
+		return errors.New("totalizator rules required for totalizator contest")
+	}
+	if rules.Totalizator.EventCount < 5 || rules.Totalizator.EventCount > 30 {
+		return errors.New("event_count must be between 5 and 30")
+	}
+	if rules.Totalizator.Pool != nil {
+		if err := validatePool(rules.Totalizator.Pool, rules.Totalizator.EventCount); err != nil {
+			return err
+		}
+	}
+	return validateScoringPoints(&rules.Totalizator.Scoring)
+}
+
+func (totalizatorType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	// Pool draws are decided by outcomes, so a plain 1X2 pick is enough
+	if rules.Totalizator.Pool != nil && prediction.Winner != nil {
+		if w := *prediction.Winner; w != "home" && w != "draw" && w != "away" {
+			return errors.New("winner must be \"home\", \"draw\" or \"away\"")
+		}
+		return nil
+	}
+	return validateScorePrediction(rules, prediction)
+}
+
+func (totalizatorType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	if rules.Totalizator.Pool != nil {
+		return scorePoolPick(rules, prediction, result)
+	}
+	return scoreLine(rules, prediction, result, &rules.Totalizator.Scoring, ContestTypeTotalizator)
+}
+
+// FixedPoints reports that pool draws count correct picks, which multipliers must not scale
+func (totalizatorType) FixedPoints(rules *ContestRules) bool {
+	return rules.Totalizator.Pool != nil
+}
+
+// relayType is a team contest where a captain assigns matches to members; each
+// assigned match is scored with standard score-line rules
+type relayType struct{}
+
+func (relayType) Type() ContestType { return ContestTypeRelay }
+
+func (relayType) ApplyDefaults(rules *ContestRules) {
+	if rules.Relay == nil {
+		defaultRelay := DefaultRelayRules()
+		rules.Relay = &defaultRelay
+	}
+}
+
+func (relayType) Validate(rules *ContestRules) error {
+	if rules.Relay == nil {
+		return errors.New("relay rules required for relay contest")
+	}
+	if rules.Relay.TeamSize < 2 || rules.Relay.TeamSize > 10 {
+		return errors.New("team_size must be between 2 and 10")
+	}
+	if rules.Relay.EventCount < 5 || rules.Relay.EventCount > 50 {
+		return errors.New("event_count must be between 5 and 50")
+	}
+	return validateScoringPoints(&rules.Relay.Scoring)
+}
+
+func (relayType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	return validateScorePrediction(rules, prediction)
+}
+
+func (relayType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	return scoreLine(rules, prediction, result, &rules.Relay.Scoring, ContestTypeRelay)
+}
+
+// validateScoringPoints rejects negative score-line points
+func validateScoringPoints(scoring *StandardScoringRules) error {
+	if scoring.ExactScore < 0 || scoring.GoalDifference < 0 ||
+		scoring.CorrectOutcome < 0 || scoring.OutcomePlusTeamGoals < 0 || scoring.AnyOther < 0 {
+		return errors.New("scoring points cannot be negative")
+	}
+	return nil
+}
+
+// validateScorePrediction checks a score-line prediction against the contest's sport. Other
+// prediction shapes (winner, props) are still accepted in score-line contests.
+func validateScorePrediction(rules *ContestRules, prediction *PredictionData) error {
+	profile := rules.Profile()
+	switch prediction.Type {
+	case "any_other":
+		return profile.validatePrediction(prediction)
+	case "exact_score":
+		if !prediction.HasScore() {
+			return errors.New("home_score and away_score are required")
+		}
+	case "props":
+		if len(prediction.Props) == 0 {
+			return errors.New("at least one prop must be predicted")
+		}
+	}
+
+	if (prediction.HomeScore != nil && *prediction.HomeScore < 0) ||
+		(prediction.AwayScore != nil && *prediction.AwayScore < 0) {
+		return errors.New("scores cannot be negative")
+	}
+	return profile.validatePrediction(prediction)
+}
+
+// scoreLine scores a predicted score line with the given points table
+func scoreLine(rules *ContestRules, prediction *PredictionData, result *ResultData, scoring *StandardScoringRules, contestType ContestType) CalculationResult {
+	profile := rules.Profile()
+	actual := profile.scoredResult(result)
+	if prediction.Margin != "" && prediction.Winner != nil && len(profile.MarginBands) > 0 {
+		return scoreMarginPick(prediction, actual, scoring, profile, contestType)
+	}
+	if prediction.IsAnyOther() {
+		return NewCalculator(rules).calculateWithScoring(ScoreData{}, actual, true, scoring, string(contestType))
+	}
+
+	if !prediction.HasScore() {
+		return CalculationResult{
+			Points:  0,
+			Details: map[string]interface{}{"type": string(contestType), "error": "Missing score prediction"},
+		}
+	}
+
+	predicted := ScoreData{HomeScore: *prediction.HomeScore, AwayScore: *prediction.AwayScore}
+	return NewCalculator(rules).calculateWithScoring(predicted, actual, false, scoring, string(contestType))
+}
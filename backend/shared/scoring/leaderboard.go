@@ -0,0 +1,184 @@
+package scoring
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Tie-breakers applied in order to participants level on points
+const (
+	TieBreakExactScores        = "exact_scores"        // more exact score predictions
+	TieBreakCorrectOutcomes    = "correct_outcomes"    // more predictions with the right outcome
+	TieBreakMaxStreak          = "max_streak"          // longer best streak
+	TieBreakEarliestSubmission = "earliest_submission" // predictions made earlier before kickoff on average
+	TieBreakFewestMissed       = "fewest_missed"       // fewer contest matches left unpredicted
+)
+
+// How participants still level after every tie-breaker are ranked
+const (
+	RankingShared = "shared" // 1, 1, 3 (default)
+	RankingDense  = "dense"  // 1, 1, 2
+)
+
+// When round snapshots of the standings are taken
+const (
+	SnapshotMatchday = "matchday" // after the last match of each day is settled (default)
+	SnapshotManual   = "manual"   // only when requested, e.g. by a scheduler at the end of a round
+)
+
+// LeaderboardRules configures how a contest's leaderboard orders participants level on points
+// and when its standings are snapshotted
+type LeaderboardRules struct {
+	TieBreakers []string `json:"tie_breakers,omitempty"`
+	Ranking     string   `json:"ranking,omitempty"`
+	Snapshots   string   `json:"snapshots,omitempty"`
+}
+
+// Standing is a participant's total and the statistics tie-breakers compare
+type Standing struct {
+	UserID          uint
+	TotalPoints     float64
+	ExactScores     int64
+	CorrectOutcomes int64
+	MaxStreak       uint
+	AvgLeadHours    float64 // average hours predictions were made before kickoff
+	Missed          int64
+}
+
+// CorrectOutcomeRules returns the match types of predictions that got the outcome right
+func CorrectOutcomeRules() []string {
+	rules := make([]string, 0, len(outcomeHits))
+	for rule := range outcomeHits {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// Uses reports whether the leaderboard breaks ties with the given tie-breaker
+func (r *LeaderboardRules) Uses(tieBreaker string) bool {
+	if r == nil {
+		return false
+	}
+	for _, t := range r.TieBreakers {
+		if t == tieBreaker {
+			return true
+		}
+	}
+	return false
+}
+
+// SnapshotsMatchdays reports whether standings are snapshotted after each matchday
+func (r *LeaderboardRules) SnapshotsMatchdays() bool {
+	return r == nil || r.Snapshots == "" || r.Snapshots == SnapshotMatchday
+}
+
+// Rank sorts standings from first to last and returns the rank of each. Participants level
+// after every tie-breaker share a rank and are listed by user ID.
+func (r *LeaderboardRules) Rank(standings []Standing) []uint {
+	sort.SliceStable(standings, func(i, j int) bool {
+		if c := r.compare(standings[i], standings[j]); c != 0 {
+			return c < 0
+		}
+		return standings[i].UserID < standings[j].UserID
+	})
+
+	dense := r != nil && r.Ranking == RankingDense
+	ranks := make([]uint, len(standings))
+	for i := range standings {
+		switch {
+		case i == 0:
+			ranks[i] = 1
+		case r.compare(standings[i-1], standings[i]) == 0:
+			ranks[i] = ranks[i-1]
+		case dense:
+			ranks[i] = ranks[i-1] + 1
+		default:
+			ranks[i] = uint(i + 1)
+		}
+	}
+	return ranks
+}
+
+// Percentile returns the share of the other participants ranked below the given rank, from 0
+// for last place to 100 for first. A lone participant is in the 100th percentile.
+func Percentile(rank uint, participants int64) float64 {
+	if rank == 0 || participants <= 0 {
+		return 0
+	}
+	if participants == 1 {
+		return 100
+	}
+	below := max(participants-int64(rank), 0)
+	return float64(below) / float64(participants-1) * 100
+}
+
+// compare returns a negative number when a ranks ahead of b, positive when behind and zero when level
+func (r *LeaderboardRules) compare(a, b Standing) int {
+	if c := compareDesc(a.TotalPoints, b.TotalPoints); c != 0 || r == nil {
+		return c
+	}
+	for _, tieBreaker := range r.TieBreakers {
+		var c int
+		switch tieBreaker {
+		case TieBreakExactScores:
+			c = compareDesc(a.ExactScores, b.ExactScores)
+		case TieBreakCorrectOutcomes:
+			c = compareDesc(a.CorrectOutcomes, b.CorrectOutcomes)
+		case TieBreakMaxStreak:
+			c = compareDesc(a.MaxStreak, b.MaxStreak)
+		case TieBreakEarliestSubmission:
+			c = compareDesc(a.AvgLeadHours, b.AvgLeadHours)
+		case TieBreakFewestMissed:
+			c = -compareDesc(a.Missed, b.Missed)
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// compareDesc orders higher values first
+func compareDesc[T int64 | uint | float64](a, b T) int {
+	switch {
+	case a > b:
+		return -1
+	case a < b:
+		return 1
+	}
+	return 0
+}
+
+// Validate checks the ranking and snapshot modes and that tie-breakers are known and listed once
+func (r *LeaderboardRules) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	switch r.Ranking {
+	case "", RankingShared, RankingDense:
+	default:
+		return fmt.Errorf("leaderboard ranking must be %q or %q", RankingShared, RankingDense)
+	}
+
+	switch r.Snapshots {
+	case "", SnapshotMatchday, SnapshotManual:
+	default:
+		return fmt.Errorf("leaderboard snapshots must be %q or %q", SnapshotMatchday, SnapshotManual)
+	}
+
+	seen := make(map[string]bool, len(r.TieBreakers))
+	for _, tieBreaker := range r.TieBreakers {
+		switch tieBreaker {
+		case TieBreakExactScores, TieBreakCorrectOutcomes, TieBreakMaxStreak, TieBreakEarliestSubmission, TieBreakFewestMissed:
+		default:
+			return fmt.Errorf("unknown tie-breaker %q", tieBreaker)
+		}
+		if seen[tieBreaker] {
+			return fmt.Errorf("tie-breaker %q is listed twice", tieBreaker)
+		}
+		seen[tieBreaker] = true
+	}
+	return nil
+}
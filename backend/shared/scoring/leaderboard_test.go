@@ -0,0 +1,112 @@
+package scoring
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLeaderboardRank(t *testing.T) {
+	standings := func() []Standing {
+		return []Standing{
+			{UserID: 4, TotalPoints: 8, ExactScores: 1, MaxStreak: 3},
+			{UserID: 1, TotalPoints: 10, ExactScores: 1, MaxStreak: 2},
+			{UserID: 2, TotalPoints: 10, ExactScores: 2, MaxStreak: 1},
+			{UserID: 3, TotalPoints: 10, ExactScores: 1, MaxStreak: 2},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		rules     *LeaderboardRules
+		wantUsers []uint
+		wantRanks []uint
+	}{
+		{"points only", nil, []uint{1, 2, 3, 4}, []uint{1, 1, 1, 4}},
+		{"dense points only", &LeaderboardRules{Ranking: RankingDense}, []uint{1, 2, 3, 4}, []uint{1, 1, 1, 2}},
+		{"exact scores", &LeaderboardRules{TieBreakers: []string{TieBreakExactScores}}, []uint{2, 1, 3, 4}, []uint{1, 2, 2, 4}},
+		{"streak before exact scores", &LeaderboardRules{TieBreakers: []string{TieBreakMaxStreak, TieBreakExactScores}}, []uint{1, 3, 2, 4}, []uint{1, 1, 3, 4}},
+		{"dense with tie-breakers", &LeaderboardRules{TieBreakers: []string{TieBreakExactScores}, Ranking: RankingDense}, []uint{2, 1, 3, 4}, []uint{1, 2, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := standings()
+			ranks := tt.rules.Rank(got)
+
+			users := make([]uint, len(got))
+			for i, standing := range got {
+				users[i] = standing.UserID
+			}
+			if !reflect.DeepEqual(users, tt.wantUsers) {
+				t.Errorf("order = %v, want %v", users, tt.wantUsers)
+			}
+			if !reflect.DeepEqual(ranks, tt.wantRanks) {
+				t.Errorf("ranks = %v, want %v", ranks, tt.wantRanks)
+			}
+		})
+	}
+}
+
+func TestLeaderboardRankLowerIsBetter(t *testing.T) {
+	rules := &LeaderboardRules{TieBreakers: []string{TieBreakFewestMissed, TieBreakEarliestSubmission}}
+	standings := []Standing{
+		{UserID: 1, TotalPoints: 5, Missed: 2, AvgLeadHours: 90},
+		{UserID: 2, TotalPoints: 5, Missed: 0, AvgLeadHours: 3},
+		{UserID: 3, TotalPoints: 5, Missed: 0, AvgLeadHours: 30},
+	}
+
+	rules.Rank(standings)
+	for i, want := range []uint{3, 2, 1} {
+		if standings[i].UserID != want {
+			t.Errorf("position %d = user %d, want %d", i+1, standings[i].UserID, want)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	tests := []struct {
+		rank         uint
+		participants int64
+		want         float64
+	}{
+		{1, 101, 100},
+		{101, 101, 0},
+		{51, 101, 50},
+		{2, 5, 75},
+		{1, 1, 100},
+		{0, 10, 0},
+	}
+
+	for _, tt := range tests {
+		if got := Percentile(tt.rank, tt.participants); got != tt.want {
+			t.Errorf("Percentile(%d, %d) = %v, want %v", tt.rank, tt.participants, got, tt.want)
+		}
+	}
+}
+
+func TestValidateLeaderboard(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{"tie-breakers", `{"leaderboard":{"tie_breakers":["exact_scores","max_streak"],"ranking":"dense"}}`, false},
+		{"unknown tie-breaker", `{"leaderboard":{"tie_breakers":["coin_toss"]}}`, true},
+		{"duplicate tie-breaker", `{"leaderboard":{"tie_breakers":["max_streak","max_streak"]}}`, true},
+		{"unknown ranking", `{"leaderboard":{"ranking":"olympic"}}`, true},
+		{"manual snapshots", `{"leaderboard":{"snapshots":"manual"}}`, false},
+		{"unknown snapshots", `{"leaderboard":{"snapshots":"hourly"}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.rules)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			if err := rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Totalizator pool pick outcomes reported as match_type
+const (
+	PoolHit  = "pool_hit"
+	PoolMiss = "pool_miss"
+)
+
+// TotalizatorPool turns a totalizator into a parimutuel draw: nobody earns points per match,
+// instead the pool is split at the end of the draw by how many matches each participant got right
+type TotalizatorPool struct {
+	Amount float64    `json:"amount"` // virtual points paid out by the draw
+	Series string     `json:"series"` // draws of a series share a jackpot that rolls over between them
+	Tiers  []PoolTier `json:"tiers"`  // prize tiers, e.g. 15, 14 and 13 correct
+}
+
+// PoolTier is a share of the pool for participants with exactly Correct right picks
+type PoolTier struct {
+	Correct int     `json:"correct"`
+	Share   float64 `json:"share"` // fraction of the pool; shares add up to at most 1
+}
+
+// PoolSettlement is the outcome of splitting a draw's pool
+type PoolSettlement struct {
+	Payouts  map[uint]float64 // by user, tier winners only
+	Rollover float64          // unclaimed tier money carried into the series jackpot
+}
+
+// TopTier returns the tier with the most correct picks, which receives the jackpot
+func (p *TotalizatorPool) TopTier() PoolTier {
+	var top PoolTier
+	for _, tier := range p.Tiers {
+		if tier.Correct > top.Correct {
+			top = tier
+		}
+	}
+	return top
+}
+
+// Split divides the pool among participants by their number of correct picks. Each tier's
+// share is split equally among its winners and the jackpot is added to the top tier. Everything
+// not paid out rolls over to the series' next draw: tiers nobody won, including the jackpot,
+// pool money outside the tiers and the cents left over from rounding payouts down.
+func (p *TotalizatorPool) Split(jackpot float64, correct map[uint]int) PoolSettlement {
+	winners := make(map[int][]uint, len(p.Tiers))
+	for userID, count := range correct {
+		winners[count] = append(winners[count], userID)
+	}
+
+	settlement := PoolSettlement{Payouts: make(map[uint]float64)}
+	top := p.TopTier()
+	var paid float64
+	for _, tier := range p.Tiers {
+		pot := p.Amount * tier.Share
+		if tier.Correct == top.Correct {
+			pot += jackpot
+		}
+
+		tierWinners := winners[tier.Correct]
+		if len(tierWinners) == 0 {
+			continue
+		}
+
+		// Round down to whole cents so the draw never pays out more than its pool
+		share := math.Floor(pot/float64(len(tierWinners))*100) / 100
+		for _, userID := range tierWinners {
+			settlement.Payouts[userID] = share
+		}
+		paid += share * float64(len(tierWinners))
+	}
+
+	settlement.Rollover = math.Round((p.Amount+jackpot-paid)*100) / 100
+	return settlement
+}
+
+// validatePool checks a pool configuration against the number of matches in the draw
+func validatePool(pool *TotalizatorPool, eventCount int) error {
+	if pool.Amount <= 0 {
+		return errors.New("pool amount must be positive")
+	}
+	if pool.Series == "" {
+		return errors.New("pool series is required for jackpot rollover")
+	}
+	if len(pool.Tiers) == 0 {
+		return errors.New("pool needs at least one prize tier")
+	}
+
+	tiers := append([]PoolTier(nil), pool.Tiers...)
+	sort.Slice(tiers, func(i, j int) bool { return tiers[i].Correct > tiers[j].Correct })
+
+	var totalShare float64
+	for i, tier := range tiers {
+		if tier.Correct < 1 || tier.Correct > eventCount {
+			return fmt.Errorf("tier correct must be between 1 and %d", eventCount)
+		}
+		if i > 0 && tier.Correct == tiers[i-1].Correct {
+			return fmt.Errorf("duplicate tier for %d correct", tier.Correct)
+		}
+		if tier.Share <= 0 {
+			return errors.New("tier share must be positive")
+		}
+		totalShare += tier.Share
+	}
+	if totalShare > 1+1e-9 {
+		return errors.New("tier shares cannot add up to more than 1")
+	}
+	return nil
+}
+
+// scorePoolPick records whether a pool pick got the match outcome right. A hit counts one
+// towards the participant's number of correct picks; the pool itself is paid at draw settlement.
+func scorePoolPick(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	details := map[string]interface{}{"type": string(ContestTypeTotalizator)}
+
+	calc := NewCalculator(rules)
+	var pick string
+	switch {
+	case prediction.Winner != nil:
+		pick = *prediction.Winner
+	case prediction.HasScore():
+		pick = calc.determineOutcome(*prediction.HomeScore, *prediction.AwayScore)
+	default:
+		details["error"] = "Missing outcome pick"
+		return CalculationResult{Points: 0, Details: details}
+	}
+
+	actual := calc.determineOutcome(result.HomeScore, result.AwayScore)
+	details["pick"] = pick
+	details["actual_outcome"] = actual
+	if pick == actual {
+		details["match_type"] = PoolHit
+		return CalculationResult{Points: 1, Details: details}
+	}
+
+	details["match_type"] = PoolMiss
+	return CalculationResult{Points: 0, Details: details}
+}
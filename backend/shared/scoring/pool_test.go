@@ -0,0 +1,95 @@
+package scoring
+
+import "testing"
+
+const poolRules = `{"type":"totalizator","totalizator":{"event_count":15,"pool":{
+	"amount":1000,"series":"weekly",
+	"tiers":[{"correct":15,"share":0.5},{"correct":14,"share":0.3},{"correct":13,"share":0.2}]}}}`
+
+func TestPoolValidate(t *testing.T) {
+	rules, err := ParseRules(poolRules)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := rules.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if rules.AppliesMultipliers() {
+		t.Error("pool draws must not apply multipliers")
+	}
+	if plain, _ := ParseRules(`{"type":"totalizator"}`); !plain.AppliesMultipliers() {
+		t.Error("totalizator without a pool keeps multipliers")
+	}
+
+	invalid := []string{
+		`{"type":"totalizator","totalizator":{"event_count":15,"pool":{"amount":1000,"tiers":[{"correct":15,"share":1}]}}}`,
+		`{"type":"totalizator","totalizator":{"event_count":15,"pool":{"amount":1000,"series":"w","tiers":[{"correct":15,"share":0.7},{"correct":14,"share":0.7}]}}}`,
+		`{"type":"totalizator","totalizator":{"event_count":15,"pool":{"amount":1000,"series":"w","tiers":[{"correct":16,"share":1}]}}}`,
+		`{"type":"totalizator","totalizator":{"event_count":15,"pool":{"amount":1000,"series":"w","tiers":[{"correct":15,"share":0.5},{"correct":15,"share":0.5}]}}}`,
+	}
+	for _, rulesJSON := range invalid {
+		rules, _ := ParseRules(rulesJSON)
+		if err := rules.Validate(); err == nil {
+			t.Errorf("Validate() expected error for %s", rulesJSON)
+		}
+	}
+}
+
+func TestPoolSplit(t *testing.T) {
+	rules, _ := ParseRules(poolRules)
+	pool := rules.Totalizator.Pool
+
+	// Nobody hits 15: the top tier and the old jackpot roll over
+	got := pool.Split(200, map[uint]int{1: 14, 2: 13, 3: 13, 4: 9})
+	if got.Payouts[1] != 300 || got.Payouts[2] != 100 || got.Payouts[3] != 100 {
+		t.Errorf("Split() payouts = %v", got.Payouts)
+	}
+	if _, ok := got.Payouts[4]; ok {
+		t.Error("Split() paid a participant outside the tiers")
+	}
+	if got.Rollover != 700 {
+		t.Errorf("Split() rollover = %v, want 700", got.Rollover)
+	}
+
+	// Jackpot hit is shared by the top tier winners
+	got = pool.Split(700, map[uint]int{1: 15, 2: 15, 3: 14, 4: 13})
+	if got.Payouts[1] != 600 || got.Payouts[2] != 600 || got.Rollover != 0 {
+		t.Errorf("Split() with jackpot = %v rollover %v", got.Payouts, got.Rollover)
+	}
+}
+
+func TestPoolSplitRollsOverRemainder(t *testing.T) {
+	rules, _ := ParseRules(`{"type":"totalizator","totalizator":{"event_count":3,"pool":{
+		"amount":100,"series":"weekly","tiers":[{"correct":3,"share":0.6},{"correct":2,"share":0.3}]}}}`)
+	pool := rules.Totalizator.Pool
+
+	// Three winners share 60.00 as 20.00 each, three share 30.00 as 10.00, and the
+	// 10.00 outside the tiers rolls over
+	got := pool.Split(0, map[uint]int{1: 3, 2: 3, 3: 3, 4: 2, 5: 2, 6: 2})
+	if got.Payouts[1] != 20 || got.Payouts[4] != 10 || got.Rollover != 10 {
+		t.Errorf("Split() = %v rollover %v, want 20/10 rollover 10", got.Payouts, got.Rollover)
+	}
+
+	// 60.10 split three ways pays 20.03 each and the cent left over rolls over
+	got = pool.Split(0.1, map[uint]int{1: 3, 2: 3, 3: 3, 4: 2})
+	if got.Payouts[1] != 20.03 || got.Payouts[4] != 30 || got.Rollover != 10.01 {
+		t.Errorf("Split() = %v rollover %v, want 20.03/30 rollover 10.01", got.Payouts, got.Rollover)
+	}
+}
+
+func TestPoolScore(t *testing.T) {
+	rules, _ := ParseRules(poolRules)
+	home := "home"
+	result := &ResultData{HomeScore: 1, AwayScore: 0}
+
+	got := rules.Score(&PredictionData{Winner: &home}, result)
+	if got.Points != 1 || got.Details["match_type"] != PoolHit {
+		t.Errorf("Score() = %v %v, want 1 %s", got.Points, got.Details["match_type"], PoolHit)
+	}
+
+	zero, two := 0, 2
+	got = rules.Score(&PredictionData{Type: "exact_score", HomeScore: &zero, AwayScore: &two}, result)
+	if got.Points != 0 || got.Details["match_type"] != PoolMiss {
+		t.Errorf("Score() = %v %v, want 0 %s", got.Points, got.Details["match_type"], PoolMiss)
+	}
+}
@@ -0,0 +1,145 @@
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// PredictionData represents the structure of prediction data
+type PredictionData struct {
+	Type            string           `json:"type"`                       // "exact_score", "any_other", "winner", "over_under", "props", "risky", "survivor", "confidence", "bracket"
+	HomeScore       *int             `json:"home_score"`                 // For exact score predictions
+	AwayScore       *int             `json:"away_score"`                 // For exact score predictions
+	Winner          *string          `json:"winner"`                     // "home", "away", "draw"
+	OverUnder       *string          `json:"over_under"`                 // "over", "under"
+	Threshold       *float64         `json:"threshold"`                  // For over/under predictions
+	Value           interface{}      `json:"value"`                      // Generic value for other prediction types
+	Props           []PropPrediction `json:"props,omitempty"`            // Props predictions
+	RiskySelections []string         `json:"risky_selections,omitempty"` // Risky event slugs picked by the user
+	Team            string           `json:"team,omitempty"`             // Survivor and bracket: name of the picked team
+	Round           int              `json:"round,omitempty"`            // Survivor: contest round of the pick, filled in by the prediction service
+	Confidence      int              `json:"confidence,omitempty"`       // Confidence: weight 1..N assigned to the pick
+	Slot            string           `json:"slot,omitempty"`             // Bracket: slot the pick is for, filled in by the prediction service
+	Margin          string           `json:"margin,omitempty"`           // Basketball: winning margin band of the picked winner, e.g. "6-10"
+}
+
+// PropPrediction represents a single prop prediction
+type PropPrediction struct {
+	PropTypeID  uint    `json:"prop_type_id"`
+	PropSlug    string  `json:"prop_slug"`
+	Line        float64 `json:"line"`
+	Selection   string  `json:"selection"`
+	PlayerID    string  `json:"player_id,omitempty"`
+	PointsValue float64 `json:"points_value"`
+}
+
+// ResultData represents the structure of event result data
+type ResultData struct {
+	HomeScore   int                    `json:"home_score"`
+	AwayScore   int                    `json:"away_score"`
+	Winner      string                 `json:"winner"` // "home" or "away"; decides knockout matches level after the score
+	TotalGoals  int                    `json:"total_goals"`
+	DecidedIn   string                 `json:"decided_in,omitempty"` // hockey: "regulation", "overtime" or "shootout"
+	HomeTeam    string                 `json:"home_team,omitempty"`
+	AwayTeam    string                 `json:"away_team,omitempty"`
+	Stats       map[string]interface{} `json:"stats,omitempty"`
+	PlayerStats map[string]interface{} `json:"player_stats,omitempty"`
+	Timeline    []TimelineEvent        `json:"timeline,omitempty"` // goals, penalties and cards, used to derive risky event outcomes
+}
+
+// ParsePrediction parses a prediction payload
+func ParsePrediction(predictionJSON string) (*PredictionData, error) {
+	var prediction PredictionData
+	if err := json.Unmarshal([]byte(predictionJSON), &prediction); err != nil {
+		return nil, errors.New("invalid prediction data")
+	}
+	return &prediction, nil
+}
+
+// ParseResult parses an event result payload
+func ParseResult(resultJSON string) (*ResultData, error) {
+	var result ResultData
+	if err := json.Unmarshal([]byte(resultJSON), &result); err != nil {
+		return nil, errors.New("invalid result data")
+	}
+	return &result, nil
+}
+
+// IsAnyOther checks if the prediction is an "any other score" pick
+func (p *PredictionData) IsAnyOther() bool {
+	return p.Type == "any_other"
+}
+
+// HasScore checks if both team scores were predicted
+func (p *PredictionData) HasScore() bool {
+	return p.HomeScore != nil && p.AwayScore != nil
+}
+
+// Selections returns the risky event slugs picked by the user.
+// Selections are stored top-level as {"risky_selections": [...]}; older clients nested them under "value".
+func (p *PredictionData) Selections() []string {
+	if len(p.RiskySelections) > 0 {
+		return p.RiskySelections
+	}
+
+	value, ok := p.Value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	raw, ok := value["risky_selections"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	selections := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if slug, ok := v.(string); ok {
+			selections = append(selections, slug)
+		}
+	}
+	return selections
+}
+
+// WinningTeam returns the name of the team that went through, using the explicit winner
+// for matches decided after the score (extra time, penalties). It is empty for a draw.
+func (r *ResultData) WinningTeam() string {
+	winner := r.Winner
+	if winner != "home" && winner != "away" {
+		switch {
+		case r.HomeScore > r.AwayScore:
+			winner = "home"
+		case r.AwayScore > r.HomeScore:
+			winner = "away"
+		}
+	}
+
+	switch winner {
+	case "home":
+		return r.HomeTeam
+	case "away":
+		return r.AwayTeam
+	}
+	return ""
+}
+
+// LosingTeam returns the name of the team that went out. It is empty for a draw.
+func (r *ResultData) LosingTeam() string {
+	switch r.WinningTeam() {
+	case "":
+		return ""
+	case r.HomeTeam:
+		return r.AwayTeam
+	}
+	return r.HomeTeam
+}
+
+// Outcomes returns the boolean event outcomes recorded in the result stats
+func (r *ResultData) Outcomes() map[string]bool {
+	outcomes := make(map[string]bool)
+	for key, val := range r.Stats {
+		if occurred, ok := val.(bool); ok {
+			outcomes[key] = occurred
+		}
+	}
+	return outcomes
+}
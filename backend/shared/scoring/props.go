@@ -0,0 +1,294 @@
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// How a prop's stat is compared with the pick
+const (
+	PropOverUnder = "over_under" // numeric stat against a line, picked "over" or "under"
+	PropYesNo     = "yes_no"     // true stat, or numeric stat above the line (default 0), picked "yes" or "no"
+	PropEquals    = "equals"     // stat equal to the selection, e.g. the first team to score
+	PropIsPlayer  = "is_player"  // stat names a player, e.g. the first goalscorer; won when it is the picked player
+)
+
+// Whose stats a prop reads
+const (
+	PropScopeMatch  = "match"  // ResultData.Stats and the derived stats
+	PropScopePlayer = "player" // the picked player's entry in ResultData.PlayerStats
+)
+
+// Prop outcomes. Pushes and voids earn nothing and do not count as wrong.
+const (
+	PropWon  = "won"
+	PropLost = "lost"
+	PropPush = "push" // the stat landed exactly on the line
+	PropVoid = "void" // the stat is missing from the result, or the picked player did not play
+)
+
+// Stats derived from the score rather than read from ResultData.Stats
+const (
+	StatHomeScore       = "home_score"
+	StatAwayScore       = "away_score"
+	StatTotalGoals      = "total_goals"
+	StatBothTeamsScored = "both_teams_scored"
+	StatFirstGoalscorer = "first_goalscorer" // read from Stats when present, else from the score and timeline
+)
+
+// PropEvaluation defines how a prop type is settled, so organizers can add props without a
+// code change. Stat is a derived stat or a dot-separated path into ResultData.Stats, e.g.
+// "corners" or "cards.home". Player-scoped stats are paths into the picked player's stats.
+type PropEvaluation struct {
+	Stat       string   `json:"stat"`
+	Comparator string   `json:"comparator"`
+	Scope      string   `json:"scope,omitempty"`        // "match" (default) or "player"
+	Line       *float64 `json:"line,omitempty"`         // fixed line overriding the one predicted
+	PushOnLine bool     `json:"push_on_line,omitempty"` // over/under exactly on the line is a push rather than a loss
+}
+
+// BuiltinPropEvaluations settle the original prop types that predate stored evaluations
+var BuiltinPropEvaluations = map[string]PropEvaluation{
+	"total-goals-ou":   {Stat: StatTotalGoals, Comparator: PropOverUnder},
+	"total-corners-ou": {Stat: "corners", Comparator: PropOverUnder},
+	"total-cards-ou":   {Stat: "cards", Comparator: PropOverUnder},
+	"btts":             {Stat: StatBothTeamsScored, Comparator: PropYesNo},
+	"first-to-score":   {Stat: "first_to_score", Comparator: PropEquals},
+	"player-goal":      {Stat: "goals", Comparator: PropYesNo, Scope: PropScopePlayer},
+}
+
+// ParsePropEvaluation parses and validates a stored prop evaluation
+func ParsePropEvaluation(evaluationJSON string) (*PropEvaluation, error) {
+	var evaluation PropEvaluation
+	if err := json.Unmarshal([]byte(evaluationJSON), &evaluation); err != nil {
+		return nil, errors.New("invalid prop evaluation")
+	}
+	if err := evaluation.Validate(); err != nil {
+		return nil, err
+	}
+	return &evaluation, nil
+}
+
+// Validate checks that the evaluation names a stat and a known comparator
+func (e *PropEvaluation) Validate() error {
+	if strings.TrimSpace(e.Stat) == "" {
+		return errors.New("prop evaluation stat is required")
+	}
+	switch e.Comparator {
+	case PropOverUnder, PropYesNo, PropEquals, PropIsPlayer:
+	default:
+		return fmt.Errorf("unknown prop comparator %q: must be over_under, yes_no, equals or is_player", e.Comparator)
+	}
+	switch e.Scope {
+	case "", PropScopeMatch, PropScopePlayer:
+	default:
+		return fmt.Errorf("unknown prop scope %q: must be match or player", e.Scope)
+	}
+	if e.PushOnLine && e.Comparator != PropOverUnder {
+		return errors.New("push_on_line only applies to over_under props")
+	}
+	if e.Comparator == PropIsPlayer && e.Scope == PropScopePlayer {
+		return errors.New("is_player props compare a match stat with the picked player")
+	}
+	return nil
+}
+
+// NeedsPlayer reports whether picks must name a player
+func (e *PropEvaluation) NeedsPlayer() bool {
+	return e.Scope == PropScopePlayer || e.Comparator == PropIsPlayer
+}
+
+// Evaluate settles a prop pick against a result, returning the outcome and the stat value
+// it was settled on
+func (e *PropEvaluation) Evaluate(prop PropPrediction, result *ResultData) (string, interface{}) {
+	if e.NeedsPlayer() && prop.PlayerID == "" {
+		return PropVoid, nil
+	}
+	// A pick on a player who took no part is void rather than lost
+	if prop.PlayerID != "" && result.DidNotPlay(prop.PlayerID) {
+		return PropVoid, nil
+	}
+
+	var value interface{}
+	var ok bool
+	if e.Scope == PropScopePlayer {
+		value, ok = result.PlayerStat(prop.PlayerID, e.Stat)
+	} else {
+		value, ok = result.Stat(e.Stat)
+	}
+	if !ok {
+		return PropVoid, nil
+	}
+
+	line := prop.Line
+	if e.Line != nil {
+		line = *e.Line
+	}
+	selection := strings.ToLower(strings.TrimSpace(prop.Selection))
+
+	switch e.Comparator {
+	case PropOverUnder:
+		number, ok := toNumber(value)
+		if !ok {
+			return PropVoid, value
+		}
+		switch {
+		case number == line && e.PushOnLine:
+			return PropPush, value
+		case selection == "over":
+			return wonIf(number > line), value
+		case selection == "under":
+			return wonIf(number < line), value
+		}
+		return PropLost, value
+
+	case PropYesNo:
+		happened, ok := value.(bool)
+		if !ok {
+			number, isNumber := toNumber(value)
+			if !isNumber {
+				return PropVoid, value
+			}
+			happened = number > line
+		}
+		return wonIf((selection == "yes") == happened), value
+
+	case PropEquals:
+		if number, isNumber := toNumber(value); isNumber {
+			picked, err := strconv.ParseFloat(selection, 64)
+			return wonIf(err == nil && picked == number), value
+		}
+		return wonIf(strings.EqualFold(fmt.Sprint(value), strings.TrimSpace(prop.Selection))), value
+
+	case PropIsPlayer:
+		// An empty stat means nobody qualified, e.g. a goalless match, which loses a "yes" pick
+		isPlayer := fmt.Sprint(value) == prop.PlayerID
+		return wonIf((selection != "no") == isPlayer), value
+	}
+	return PropVoid, value
+}
+
+// Stat looks up a derived stat or a dot-separated path into the result's stats
+func (r *ResultData) Stat(path string) (interface{}, bool) {
+	switch path {
+	case StatHomeScore:
+		return float64(r.HomeScore), true
+	case StatAwayScore:
+		return float64(r.AwayScore), true
+	case StatTotalGoals:
+		if r.TotalGoals == 0 {
+			return float64(r.HomeScore + r.AwayScore), true
+		}
+		return float64(r.TotalGoals), true
+	case StatBothTeamsScored:
+		return r.HomeScore > 0 && r.AwayScore > 0, true
+	case StatFirstGoalscorer:
+		if value, ok := lookupPath(r.Stats, path); ok {
+			return value, true
+		}
+		return r.firstGoalscorer()
+	}
+	return lookupPath(r.Stats, path)
+}
+
+// firstGoalscorer derives the player who scored first. Own goals do not count, so a goalless
+// match or one with only own goals has no first goalscorer; otherwise the timeline must list
+// every goal with its scorer.
+func (r *ResultData) firstGoalscorer() (interface{}, bool) {
+	if r.HomeScore+r.AwayScore == 0 {
+		return "", true
+	}
+	if !r.hasFullTimeline() {
+		return nil, false
+	}
+
+	goals := make([]TimelineEvent, 0, len(r.Timeline))
+	for _, event := range r.Timeline {
+		if event.IsGoal() && event.Type != TimelineOwnGoal {
+			goals = append(goals, event)
+		}
+	}
+	if len(goals) == 0 {
+		return "", true
+	}
+	sort.SliceStable(goals, func(i, j int) bool { return goals[i].Minute < goals[j].Minute })
+	if goals[0].PlayerID == "" {
+		return nil, false
+	}
+	return goals[0].PlayerID, true
+}
+
+// PlayerStat looks up a dot-separated path into a player's stats
+func (r *ResultData) PlayerStat(playerID, path string) (interface{}, bool) {
+	stats, ok := r.PlayerStats[playerID].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return lookupPath(stats, path)
+}
+
+// DidNotPlay reports whether the player stats show that a player took no part: the player
+// is missing from them, is marked "played": false or played zero "minutes". A result
+// without player stats says nothing about who played.
+func (r *ResultData) DidNotPlay(playerID string) bool {
+	if len(r.PlayerStats) == 0 {
+		return false
+	}
+	stats, ok := r.PlayerStats[playerID].(map[string]interface{})
+	if !ok {
+		return true
+	}
+	if played, ok := stats["played"].(bool); ok {
+		return !played
+	}
+	if minutes, ok := toNumber(stats["minutes"]); ok {
+		return minutes <= 0
+	}
+	return false
+}
+
+// lookupPath walks a dot-separated path through nested JSON objects
+func lookupPath(data map[string]interface{}, path string) (interface{}, bool) {
+	var value interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// toNumber converts a decoded JSON number, or a numeric string, to float64
+func toNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// wonIf maps a settled comparison to an outcome
+func wonIf(won bool) string {
+	if won {
+		return PropWon
+	}
+	return PropLost
+}
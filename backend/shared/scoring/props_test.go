@@ -0,0 +1,159 @@
+package scoring
+
+import "testing"
+
+func TestPropEvaluationEvaluate(t *testing.T) {
+	result := &ResultData{
+		HomeScore: 2,
+		AwayScore: 1,
+		Stats: map[string]interface{}{
+			"corners":        float64(10),
+			"first_to_score": "home",
+			"cards":          map[string]interface{}{"home": float64(3), "away": float64(1)},
+			"penalty":        true,
+		},
+	}
+	fixed := 2.5
+
+	tests := []struct {
+		name       string
+		evaluation PropEvaluation
+		prop       PropPrediction
+		want       string
+	}{
+		{"over the line", BuiltinPropEvaluations["total-goals-ou"], PropPrediction{Line: 2.5, Selection: "over"}, PropWon},
+		{"under the line", BuiltinPropEvaluations["total-goals-ou"], PropPrediction{Line: 2.5, Selection: "under"}, PropLost},
+		{"on the line loses", BuiltinPropEvaluations["total-corners-ou"], PropPrediction{Line: 10, Selection: "over"}, PropLost},
+		{"on the line pushes", PropEvaluation{Stat: "corners", Comparator: PropOverUnder, PushOnLine: true}, PropPrediction{Line: 10, Selection: "under"}, PropPush},
+		{"fixed line", PropEvaluation{Stat: "cards.home", Comparator: PropOverUnder, Line: &fixed}, PropPrediction{Line: 5, Selection: "over"}, PropWon},
+		{"both teams scored", BuiltinPropEvaluations["btts"], PropPrediction{Selection: "yes"}, PropWon},
+		{"yes on a flag", PropEvaluation{Stat: "penalty", Comparator: PropYesNo}, PropPrediction{Selection: "no"}, PropLost},
+		{"yes above a count", PropEvaluation{Stat: "cards.away", Comparator: PropYesNo}, PropPrediction{Selection: "yes"}, PropWon},
+		{"equals a team", BuiltinPropEvaluations["first-to-score"], PropPrediction{Selection: "Home"}, PropWon},
+		{"equals a number", PropEvaluation{Stat: StatHomeScore, Comparator: PropEquals}, PropPrediction{Selection: "2"}, PropWon},
+		{"missing stat voids", PropEvaluation{Stat: "offsides", Comparator: PropOverUnder}, PropPrediction{Line: 3.5, Selection: "over"}, PropVoid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.evaluation.Evaluate(tt.prop, result); got != tt.want {
+				t.Errorf("Evaluate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePropEvaluation(t *testing.T) {
+	if _, err := ParsePropEvaluation(`{"stat": "shots.home", "comparator": "over_under", "push_on_line": true}`); err != nil {
+		t.Errorf("ParsePropEvaluation() error = %v", err)
+	}
+	for _, invalid := range []string{
+		`{"comparator": "over_under"}`,
+		`{"stat": "corners", "comparator": "between"}`,
+		`{"stat": "penalty", "comparator": "yes_no", "push_on_line": true}`,
+		`{"stat": "goals", "comparator": "yes_no", "scope": "team"}`,
+		`{"stat": "first_goalscorer", "comparator": "is_player", "scope": "player"}`,
+		`not json`,
+	} {
+		if _, err := ParsePropEvaluation(invalid); err == nil {
+			t.Errorf("ParsePropEvaluation(%s) accepted an invalid evaluation", invalid)
+		}
+	}
+}
+
+func TestPlayerPropEvaluate(t *testing.T) {
+	result := &ResultData{
+		HomeScore: 1,
+		Stats:     map[string]interface{}{"first_goalscorer": "p9"},
+		PlayerStats: map[string]interface{}{
+			"p9":  map[string]interface{}{"minutes": float64(90), "goals": float64(1), "shots": float64(4)},
+			"p10": map[string]interface{}{"minutes": float64(75), "goals": float64(0), "shots": float64(2)},
+			"p11": map[string]interface{}{"minutes": float64(0)},
+			"p12": map[string]interface{}{"played": false, "shots": float64(0)},
+		},
+	}
+	shots := PropEvaluation{Stat: "shots", Comparator: PropOverUnder, Scope: PropScopePlayer}
+	firstScorer := PropEvaluation{Stat: "first_goalscorer", Comparator: PropIsPlayer}
+
+	tests := []struct {
+		name       string
+		evaluation PropEvaluation
+		prop       PropPrediction
+		want       string
+	}{
+		{"anytime scorer scored", BuiltinPropEvaluations["player-goal"], PropPrediction{PlayerID: "p9", Selection: "yes"}, PropWon},
+		{"anytime scorer blanked", BuiltinPropEvaluations["player-goal"], PropPrediction{PlayerID: "p10", Selection: "yes"}, PropLost},
+		{"player shots over", shots, PropPrediction{PlayerID: "p9", Line: 2.5, Selection: "over"}, PropWon},
+		{"player shots under", shots, PropPrediction{PlayerID: "p10", Line: 2.5, Selection: "under"}, PropWon},
+		{"first goalscorer", firstScorer, PropPrediction{PlayerID: "p9", Selection: "yes"}, PropWon},
+		{"not the first goalscorer", firstScorer, PropPrediction{PlayerID: "p10", Selection: "yes"}, PropLost},
+		{"zero minutes voids", BuiltinPropEvaluations["player-goal"], PropPrediction{PlayerID: "p11", Selection: "yes"}, PropVoid},
+		{"not played voids", shots, PropPrediction{PlayerID: "p12", Line: 0.5, Selection: "under"}, PropVoid},
+		{"missing player voids", firstScorer, PropPrediction{PlayerID: "p99", Selection: "yes"}, PropVoid},
+		{"no player picked voids", shots, PropPrediction{Line: 2.5, Selection: "over"}, PropVoid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := tt.evaluation.Evaluate(tt.prop, result); got != tt.want {
+				t.Errorf("Evaluate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	goalless := &ResultData{Stats: map[string]interface{}{"first_goalscorer": ""}}
+	if got, _ := firstScorer.Evaluate(PropPrediction{PlayerID: "p9", Selection: "yes"}, goalless); got != PropLost {
+		t.Errorf("first goalscorer of a goalless match = %s, want %s", got, PropLost)
+	}
+}
+
+func TestFirstGoalscorerWithoutStat(t *testing.T) {
+	firstScorer := PropEvaluation{Stat: StatFirstGoalscorer, Comparator: PropIsPlayer}
+	pick := PropPrediction{PlayerID: "p9", Selection: "yes"}
+
+	tests := []struct {
+		name   string
+		result *ResultData
+		want   string
+	}{
+		{"goalless result without stat", &ResultData{}, PropLost},
+		{"scorer from the timeline", &ResultData{HomeScore: 2, AwayScore: 1, Timeline: []TimelineEvent{
+			{Minute: 60, Type: TimelineGoal, Team: "home", PlayerID: "p10"},
+			{Minute: 12, Type: TimelineOwnGoal, Team: "away", PlayerID: "p4"},
+			{Minute: 30, Type: TimelinePenaltyGoal, Team: "home", PlayerID: "p9"},
+		}}, PropWon},
+		{"only own goals", &ResultData{HomeScore: 1, Timeline: []TimelineEvent{
+			{Minute: 5, Type: TimelineOwnGoal, Team: "home", PlayerID: "p4"},
+		}}, PropLost},
+		{"goals without a timeline void", &ResultData{HomeScore: 1}, PropVoid},
+		{"first goal without a scorer voids", &ResultData{HomeScore: 1, Timeline: []TimelineEvent{
+			{Minute: 5, Type: TimelineGoal, Team: "home"},
+		}}, PropVoid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, _ := firstScorer.Evaluate(pick, tt.result); got != tt.want {
+				t.Errorf("Evaluate() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRosters(t *testing.T) {
+	rosters, err := ParseRosters(`{"home": [{"id": "p9", "name": "Striker"}], "away": [{"id": "p4", "name": "Defender"}]}`)
+	if err != nil {
+		t.Fatalf("ParseRosters() error = %v", err)
+	}
+	if !rosters.Has("p4") || rosters.Has("p5") {
+		t.Errorf("Has() did not match the rosters")
+	}
+	for _, invalid := range []string{
+		`{"home": [{"name": "Nobody"}]}`,
+		`{"home": [{"id": "p9"}], "away": [{"id": "p9"}]}`,
+		`not json`,
+	} {
+		if _, err := ParseRosters(invalid); err == nil {
+			t.Errorf("ParseRosters(%s) accepted invalid rosters", invalid)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package scoring
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ContestTypeHandler implements one contest format. Each format registers a handler
+// so rules parsing, validation and scoring work the same way in every service.
+type ContestTypeHandler interface {
+	// Type returns the value of the rules "type" field this handler owns
+	Type() ContestType
+	// ApplyDefaults fills in type-specific configuration missing from the rules
+	ApplyDefaults(rules *ContestRules)
+	// Validate checks the type-specific configuration
+	Validate(rules *ContestRules) error
+	// ValidatePrediction checks a prediction payload before it is accepted
+	ValidatePrediction(rules *ContestRules, prediction *PredictionData) error
+	// Score evaluates a prediction against an event result
+	Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult
+}
+
+// FixedPointsType is implemented by contest types whose points must not be scaled by
+// streak or time multipliers, for example because points count rounds survived
+type FixedPointsType interface {
+	FixedPoints(rules *ContestRules) bool
+}
+
+// PredictionSetValidator is implemented by contest types whose predictions for a round
+// are only valid together, for example when weights must be spread across all events
+type PredictionSetValidator interface {
+	ValidatePredictionSet(rules *ContestRules, predictions []*PredictionData) error
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[ContestType]ContestTypeHandler)
+)
+
+// Register makes a contest type available. It panics if the type is empty or
+// registered twice, since both are programming errors caught at startup.
+func Register(handler ContestTypeHandler) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	contestType := handler.Type()
+	if contestType == "" {
+		panic("scoring: Register called with empty contest type")
+	}
+	if _, exists := registry[contestType]; exists {
+		panic("scoring: Register called twice for contest type " + string(contestType))
+	}
+	registry[contestType] = handler
+}
+
+// Lookup returns the handler registered for a contest type
+func Lookup(contestType ContestType) (ContestTypeHandler, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	handler, ok := registry[contestType]
+	return handler, ok
+}
+
+// RegisteredTypes returns all registered contest types in alphabetical order
+func RegisteredTypes() []ContestType {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]ContestType, 0, len(registry))
+	for contestType := range registry {
+		types = append(types, contestType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
+
+// unknownTypeError describes an unregistered contest type and lists the valid ones
+func unknownTypeError(contestType ContestType) error {
+	types := RegisteredTypes()
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = "'" + string(t) + "'"
+	}
+	return fmt.Errorf("invalid contest type %q: must be one of %s", contestType, strings.Join(names, ", "))
+}
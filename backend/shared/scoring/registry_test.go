@@ -0,0 +1,150 @@
+package scoring
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinTypesRegistered(t *testing.T) {
+	for _, contestType := range []ContestType{ContestTypeStandard, ContestTypeRisky, ContestTypeTotalizator, ContestTypeRelay, ContestTypeSurvivor, ContestTypeConfidence, ContestTypeBracket} {
+		if _, ok := Lookup(contestType); !ok {
+			t.Errorf("contest type %q is not registered", contestType)
+		}
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic when registering a contest type twice")
+		}
+	}()
+	Register(standardType{})
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    string
+		wantType ContestType
+		wantErr  bool
+	}{
+		{"empty rules", "", ContestTypeStandard, false},
+		{"missing type", `{"scoring":{"exact_score":10}}`, ContestTypeStandard, false},
+		{"risky defaults", `{"type":"risky"}`, ContestTypeRisky, false},
+		{"unknown type", `{"type":"bingo"}`, "", true},
+		{"invalid json", `{`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if rules.Type != tt.wantType {
+				t.Errorf("ParseRules() type = %q, want %q", rules.Type, tt.wantType)
+			}
+			if err := rules.Validate(); err != nil {
+				t.Errorf("Validate() on parsed defaults = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseRulesOrDefault(t *testing.T) {
+	rules := ParseRulesOrDefault(`{"type":"bingo"}`)
+	if rules.Type != ContestTypeStandard || rules.Standard == nil {
+		t.Errorf("ParseRulesOrDefault() = %+v, want default standard rules", rules)
+	}
+}
+
+func TestValidateUnknownTypeListsRegistered(t *testing.T) {
+	err := (&ContestRules{Type: "bingo"}).Validate()
+	if err == nil || !strings.Contains(err.Error(), "'risky'") {
+		t.Errorf("Validate() error = %v, want it to list registered types", err)
+	}
+}
+
+func TestValidateTimeCoefficient(t *testing.T) {
+	valid, err := ParseRules(`{"time_coefficient":{"tiers":[{"name":"Early","from_hours":48,"coefficient":1.5},{"name":"Late","from_hours":6,"to_hours":48,"coefficient":1.2}]}}`)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	overlapping, err := ParseRules(`{"time_coefficient":{"tiers":[{"name":"Early","from_hours":48,"coefficient":1.5},{"name":"Late","from_hours":6,"to_hours":72,"coefficient":1.2}]}}`)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := overlapping.Validate(); err == nil {
+		t.Error("expected error for overlapping tiers")
+	}
+}
+
+func TestValidatePrediction(t *testing.T) {
+	risky, _ := ParseRules(`{"type":"risky","risky":{"max_selections":2,"events":[{"slug":"penalty","points":3}]}}`)
+	standard, _ := ParseRules("")
+
+	tests := []struct {
+		name       string
+		rules      *ContestRules
+		prediction string
+		wantErr    bool
+	}{
+		{"standard exact score", standard, `{"type":"exact_score","home_score":2,"away_score":1}`, false},
+		{"standard any other", standard, `{"type":"any_other"}`, false},
+		{"standard missing score", standard, `{"type":"exact_score","home_score":2}`, true},
+		{"standard negative score", standard, `{"home_score":-1,"away_score":0}`, true},
+		{"risky within limit", risky, `{"risky_selections":["penalty","red_card"]}`, false},
+		{"risky legacy value", risky, `{"value":{"risky_selections":["penalty"]}}`, false},
+		{"risky too many", risky, `{"risky_selections":["penalty","red_card","own_goal"]}`, true},
+		{"risky duplicate", risky, `{"risky_selections":["penalty","penalty"]}`, true},
+		{"risky empty", risky, `{"risky_selections":[]}`, true},
+		{"invalid json", standard, `not json`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.ValidatePrediction(tt.prediction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePrediction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScore(t *testing.T) {
+	home, away := 2, 1
+	result := &ResultData{HomeScore: 3, AwayScore: 2, Stats: map[string]interface{}{"penalty": true, "red_card": false}}
+
+	standard, _ := ParseRules("")
+	got := standard.Score(&PredictionData{Type: "exact_score", HomeScore: &home, AwayScore: &away}, result)
+	if got.Points != 3 || got.Details["match_type"] != "goal_difference" {
+		t.Errorf("standard Score() = %v %v, want 3 goal_difference", got.Points, got.Details["match_type"])
+	}
+	if got.Details["contest_type"] != ContestTypeStandard {
+		t.Errorf("standard Score() contest_type = %v", got.Details["contest_type"])
+	}
+
+	relay, _ := ParseRules(`{"type":"relay","relay":{"team_size":2,"event_count":5,"scoring":{"exact_score":10,"goal_difference":6}}}`)
+	if got := relay.Score(&PredictionData{HomeScore: &home, AwayScore: &away}, result); got.Points != 6 {
+		t.Errorf("relay Score() = %v, want 6", got.Points)
+	}
+
+	risky, _ := ParseRules(`{"type":"risky"}`)
+	got = risky.Score(&PredictionData{RiskySelections: []string{"penalty", "red_card"}}, result)
+	if got.Points != -1 {
+		t.Errorf("risky Score() = %v, want -1 (+3 penalty, -4 red card)", got.Points)
+	}
+
+	got = standard.Score(&PredictionData{Type: "exact_score"}, result)
+	if _, ok := got.Details["error"]; !ok || got.Points != 0 {
+		t.Errorf("Score() without scores = %v %v, want 0 with error", got.Points, got.Details)
+	}
+}
@@ -0,0 +1,249 @@
+package scoring
+
+import "sort"
+
+// Timeline event types
+const (
+	TimelineGoal          = "goal"
+	TimelinePenaltyGoal   = "penalty_goal"
+	TimelinePenaltyMissed = "penalty_missed"
+	TimelineOwnGoal       = "own_goal"
+	TimelineRedCard       = "red_card"
+)
+
+// TimelineEvent is a match incident. Team is the side it counts for, so an own goal is
+// credited to the team that benefited. First-half stoppage time is reported as minute 45.
+type TimelineEvent struct {
+	Minute   int    `json:"minute"`
+	Type     string `json:"type"`
+	Team     string `json:"team"` // "home" or "away"
+	PlayerID string `json:"player_id,omitempty"`
+}
+
+// IsGoal reports whether the incident changed the score
+func (e TimelineEvent) IsGoal() bool {
+	return e.Type == TimelineGoal || e.Type == TimelinePenaltyGoal || e.Type == TimelineOwnGoal
+}
+
+// RiskyResolver derives whether a risky event happened from a result. known is false when
+// the result does not carry the data needed, leaving the outcome to be set by hand.
+type RiskyResolver func(result *ResultData) (occurred, known bool)
+
+// RiskyResolvers derive the outcomes of the default risky events
+var RiskyResolvers = map[string]RiskyResolver{
+	"both_teams_score": func(r *ResultData) (bool, bool) {
+		return r.HomeScore > 0 && r.AwayScore > 0, true
+	},
+	"over_3_goals": func(r *ResultData) (bool, bool) {
+		total, _ := r.Stat(StatTotalGoals)
+		return total.(float64) > 3, true
+	},
+	"clean_sheet_home": func(r *ResultData) (bool, bool) {
+		return r.AwayScore == 0, true
+	},
+	"clean_sheet_away": func(r *ResultData) (bool, bool) {
+		return r.HomeScore == 0, true
+	},
+	"first_half_draw": resolveFirstHalfDraw,
+	"comeback":        resolveComeback,
+	"hat_trick":       resolveHatTrick,
+	"penalty": func(r *ResultData) (bool, bool) {
+		return r.resolveIncident("penalties", TimelinePenaltyGoal, TimelinePenaltyMissed)
+	},
+	"red_card": func(r *ResultData) (bool, bool) {
+		return r.resolveIncident("red_cards", TimelineRedCard)
+	},
+	"own_goal": func(r *ResultData) (bool, bool) {
+		if r.HomeScore+r.AwayScore == 0 {
+			return false, true
+		}
+		if count, ok := r.countStat("own_goals"); ok {
+			return count > 0, true
+		}
+		if !r.hasFullTimeline() {
+			return false, false
+		}
+		return r.hasIncident(TimelineOwnGoal), true
+	},
+}
+
+// ResolveRiskyOutcome derives whether a risky event happened. A boolean stat named after the
+// slug in the result wins over the resolver.
+func ResolveRiskyOutcome(slug string, result *ResultData) (occurred, known bool) {
+	if occurred, ok := result.Stats[slug].(bool); ok {
+		return occurred, true
+	}
+	resolver, ok := RiskyResolvers[slug]
+	if !ok {
+		return false, false
+	}
+	return resolver(result)
+}
+
+// resolveFirstHalfDraw reads the half-time score, or counts first-half goals in the timeline
+func resolveFirstHalfDraw(r *ResultData) (bool, bool) {
+	home, homeOK := r.countStat("half_time.home")
+	away, awayOK := r.countStat("half_time.away")
+	if homeOK && awayOK {
+		return home == away, true
+	}
+	if !r.hasFullTimeline() {
+		return false, false
+	}
+	var balance int
+	for _, event := range r.Timeline {
+		if event.IsGoal() && event.Minute <= 45 {
+			balance += goalSign(event)
+		}
+	}
+	return balance == 0, true
+}
+
+// resolveComeback reports whether a team that trailed by two goals avoided defeat
+func resolveComeback(r *ResultData) (bool, bool) {
+	// Without a side that conceded twice and did not lose there cannot have been a comeback
+	if !(r.HomeScore >= r.AwayScore && r.AwayScore >= 2) && !(r.AwayScore >= r.HomeScore && r.HomeScore >= 2) {
+		return false, true
+	}
+	if !r.hasFullTimeline() {
+		return false, false
+	}
+
+	goals := make([]TimelineEvent, 0, len(r.Timeline))
+	for _, event := range r.Timeline {
+		if event.IsGoal() {
+			goals = append(goals, event)
+		}
+	}
+	sort.SliceStable(goals, func(i, j int) bool { return goals[i].Minute < goals[j].Minute })
+
+	// balance is home goals minus away goals as the match went on
+	var balance, lowest, highest int
+	for _, goal := range goals {
+		balance += goalSign(goal)
+		lowest = min(lowest, balance)
+		highest = max(highest, balance)
+	}
+	homeCameBack := lowest <= -2 && balance >= 0
+	awayCameBack := highest >= 2 && balance <= 0
+	return homeCameBack || awayCameBack, true
+}
+
+// resolveHatTrick looks for a player with three goals in the player stats or the timeline
+func resolveHatTrick(r *ResultData) (bool, bool) {
+	if r.HomeScore < 3 && r.AwayScore < 3 {
+		return false, true
+	}
+
+	if len(r.PlayerStats) > 0 {
+		for playerID := range r.PlayerStats {
+			if goals, ok := r.PlayerStat(playerID, "goals"); ok {
+				if count, ok := toNumber(goals); ok && count >= 3 {
+					return true, true
+				}
+			}
+		}
+		return false, true
+	}
+
+	if !r.hasFullTimeline() {
+		return false, false
+	}
+	goals := make(map[string]int)
+	for _, event := range r.Timeline {
+		if event.Type == TimelineOwnGoal || !event.IsGoal() {
+			continue
+		}
+		if event.PlayerID == "" {
+			// A goal without a scorer could complete anyone's hat-trick
+			return false, false
+		}
+		goals[event.PlayerID]++
+		if goals[event.PlayerID] >= 3 {
+			return true, true
+		}
+	}
+	return false, true
+}
+
+// resolveIncident reads a counted stat, or looks for the incident types in the timeline. A
+// timeline is not known to list every incident, so only the counted stat can show that none
+// happened.
+func (r *ResultData) resolveIncident(stat string, types ...string) (bool, bool) {
+	if count, ok := r.countStat(stat); ok {
+		return count > 0, true
+	}
+	if r.hasIncident(types...) {
+		return true, true
+	}
+	return false, false
+}
+
+// hasIncident reports whether the timeline has an incident of any of the types
+func (r *ResultData) hasIncident(types ...string) bool {
+	for _, event := range r.Timeline {
+		for _, t := range types {
+			if event.Type == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasFullTimeline reports whether the timeline lists every goal of the final score
+func (r *ResultData) hasFullTimeline() bool {
+	if r.Timeline == nil {
+		return false
+	}
+	var home, away int
+	for _, event := range r.Timeline {
+		if !event.IsGoal() {
+			continue
+		}
+		switch event.Team {
+		case "home":
+			home++
+		case "away":
+			away++
+		default:
+			return false
+		}
+	}
+	return home == r.HomeScore && away == r.AwayScore
+}
+
+// countStat reads a stat as a count: a number, a flag, or the sum of a per-team object such
+// as {"home": 1, "away": 0}
+func (r *ResultData) countStat(path string) (float64, bool) {
+	value, ok := r.Stat(path)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1, true
+		}
+		return 0, true
+	case map[string]interface{}:
+		var total float64
+		for _, side := range v {
+			count, ok := toNumber(side)
+			if !ok {
+				return 0, false
+			}
+			total += count
+		}
+		return total, true
+	}
+	return toNumber(value)
+}
+
+// goalSign is +1 for a home goal and -1 for an away goal
+func goalSign(goal TimelineEvent) int {
+	if goal.Team == "home" {
+		return 1
+	}
+	return -1
+}
@@ -0,0 +1,73 @@
+package scoring
+
+import "testing"
+
+func TestResolveRiskyOutcome(t *testing.T) {
+	// Home came back from 0:2 to win 3:2 with a penalty, an own goal and a hat-trick
+	comeback := &ResultData{
+		HomeScore: 3,
+		AwayScore: 2,
+		Timeline: []TimelineEvent{
+			{Minute: 10, Type: TimelineGoal, Team: "away", PlayerID: "a9"},
+			{Minute: 30, Type: TimelineOwnGoal, Team: "away", PlayerID: "h4"},
+			{Minute: 44, Type: TimelineRedCard, Team: "away", PlayerID: "a5"},
+			{Minute: 50, Type: TimelinePenaltyGoal, Team: "home", PlayerID: "h9"},
+			{Minute: 70, Type: TimelineGoal, Team: "home", PlayerID: "h9"},
+			{Minute: 89, Type: TimelineGoal, Team: "home", PlayerID: "h9"},
+		},
+	}
+	goalless := &ResultData{Timeline: []TimelineEvent{}}
+	scoreOnly := &ResultData{HomeScore: 3, AwayScore: 2}
+	withStats := &ResultData{
+		HomeScore: 1,
+		AwayScore: 1,
+		Stats: map[string]interface{}{
+			"half_time": map[string]interface{}{"home": float64(1), "away": float64(0)},
+			"red_cards": map[string]interface{}{"home": float64(0), "away": float64(1)},
+			"penalty":   false,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		slug      string
+		result    *ResultData
+		want      bool
+		wantKnown bool
+	}{
+		{"both teams scored", "both_teams_score", comeback, true, true},
+		{"over 3 goals", "over_3_goals", comeback, true, true},
+		{"no home clean sheet", "clean_sheet_home", comeback, false, true},
+		{"away clean sheet", "clean_sheet_away", goalless, true, true},
+		{"first half from timeline", "first_half_draw", comeback, false, true},
+		{"first half from half-time score", "first_half_draw", withStats, false, true},
+		{"first half unknown", "first_half_draw", scoreOnly, false, false},
+		{"comeback", "comeback", comeback, true, true},
+		{"no comeback possible", "comeback", withStats, false, true},
+		{"comeback unknown", "comeback", scoreOnly, false, false},
+		{"hat-trick from timeline", "hat_trick", comeback, true, true},
+		{"hat-trick impossible", "hat_trick", withStats, false, true},
+		{"hat-trick unknown", "hat_trick", scoreOnly, false, false},
+		{"penalty from timeline", "penalty", comeback, true, true},
+		{"no penalty in empty timeline unknown", "penalty", goalless, false, false},
+		{"no red card in goals-only timeline unknown", "red_card", &ResultData{HomeScore: 1, Timeline: []TimelineEvent{
+			{Minute: 20, Type: TimelineGoal, Team: "home", PlayerID: "h9"},
+		}}, false, false},
+		{"no red card from stats", "red_card", &ResultData{Stats: map[string]interface{}{"red_cards": float64(0)}}, false, true},
+		{"explicit stat wins", "penalty", withStats, false, true},
+		{"red card from stats", "red_card", withStats, true, true},
+		{"own goal from timeline", "own_goal", comeback, true, true},
+		{"no own goal without goals", "own_goal", &ResultData{}, false, true},
+		{"own goal unknown", "own_goal", scoreOnly, false, false},
+		{"unknown slug", "var_decision", comeback, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, known := ResolveRiskyOutcome(tt.slug, tt.result)
+			if got != tt.want || known != tt.wantKnown {
+				t.Errorf("ResolveRiskyOutcome(%s) = %v, %v, want %v, %v", tt.slug, got, known, tt.want, tt.wantKnown)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RosterPlayer is a player who may take part in a match
+type RosterPlayer struct {
+	ID   string `json:"id"` // the key of the player's entry in ResultData.PlayerStats
+	Name string `json:"name"`
+}
+
+// Rosters lists the players of each side of a match, the players prop picks may name
+type Rosters struct {
+	Home []RosterPlayer `json:"home"`
+	Away []RosterPlayer `json:"away"`
+}
+
+// ParseRosters parses and validates an event's rosters. Empty input means no rosters.
+func ParseRosters(rostersJSON string) (*Rosters, error) {
+	var rosters Rosters
+	if strings.TrimSpace(rostersJSON) == "" {
+		return &rosters, nil
+	}
+	if err := json.Unmarshal([]byte(rostersJSON), &rosters); err != nil {
+		return nil, errors.New("invalid rosters")
+	}
+	if err := rosters.Validate(); err != nil {
+		return nil, err
+	}
+	return &rosters, nil
+}
+
+// Validate checks that every player has an ID and appears once across both rosters
+func (r *Rosters) Validate() error {
+	seen := make(map[string]bool)
+	for _, player := range append(append([]RosterPlayer{}, r.Home...), r.Away...) {
+		if strings.TrimSpace(player.ID) == "" {
+			return errors.New("roster player ID is required")
+		}
+		if seen[player.ID] {
+			return fmt.Errorf("player %q appears more than once in the rosters", player.ID)
+		}
+		seen[player.ID] = true
+	}
+	return nil
+}
+
+// IsEmpty reports whether no players are listed
+func (r *Rosters) IsEmpty() bool {
+	return len(r.Home) == 0 && len(r.Away) == 0
+}
+
+// Has reports whether a player is on either roster
+func (r *Rosters) Has(playerID string) bool {
+	for _, players := range [][]RosterPlayer{r.Home, r.Away} {
+		for _, player := range players {
+			if player.ID == playerID {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,8 +1,12 @@
 package scoring
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
-	"errors"
+	"fmt"
+
+	"github.com/sports-prediction-contests/shared/coefficient"
 )
 
 // ContestType defines the type of contest
@@ -42,8 +46,9 @@ type RiskyScoringRules struct {
 // TotalizatorRules defines rules for totalizator contest
 // Admin manually selects matches from different leagues
 type TotalizatorRules struct {
-	EventCount int                  `json:"event_count"` // number of matches (default 15)
-	Scoring    StandardScoringRules `json:"scoring"`     // scoring rules for all matches
+	EventCount int                  `json:"event_count"`    // number of matches (default 15)
+	Scoring    StandardScoringRules `json:"scoring"`        // scoring rules for all matches
+	Pool       *TotalizatorPool     `json:"pool,omitempty"` // parimutuel prize pool; replaces per-match points when set
 }
 
 // RelayRules defines rules for relay (team) contest
@@ -58,10 +63,18 @@ type RelayRules struct {
 // ContestRules combines all rule types
 type ContestRules struct {
 	Type        ContestType           `json:"type"`
+	Sport       string                `json:"sport,omitempty"` // scoring profile; defaults to football
 	Standard    *StandardScoringRules `json:"scoring,omitempty"`
 	Risky       *RiskyScoringRules    `json:"risky,omitempty"`
 	Totalizator *TotalizatorRules     `json:"totalizator,omitempty"`
 	Relay       *RelayRules           `json:"relay,omitempty"`
+	Survivor    *SurvivorRules        `json:"survivor,omitempty"`
+	Confidence  *ConfidenceRules      `json:"confidence,omitempty"`
+	Bracket     *BracketRules         `json:"bracket,omitempty"`
+
+	TimeCoefficient *coefficient.Config `json:"time_coefficient,omitempty"` // early prediction bonus tiers; defaults when omitted
+	Streak          *StreakRules        `json:"streak,omitempty"`           // streak hits, multiplier ladder and freezes; defaults when omitted
+	Leaderboard     *LeaderboardRules   `json:"leaderboard,omitempty"`      // tie-breakers and shared or dense ranks
 }
 
 // DefaultStandardRules returns default scoring for standard contests
@@ -117,48 +130,61 @@ func DefaultRelayRules() RelayRules {
 	}
 }
 
-// ParseRules parses JSON rules string into ContestRules
+// ParseRules parses JSON rules string into ContestRules and applies the defaults of
+// its contest type. Empty rules mean a standard contest with default scoring.
 func ParseRules(rulesJSON string) (*ContestRules, error) {
-	if rulesJSON == "" {
-		// Return default standard rules
-		defaultRules := DefaultStandardRules()
-		return &ContestRules{
-			Type:     ContestTypeStandard,
-			Standard: &defaultRules,
-		}, nil
-	}
-
 	var rules ContestRules
-	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
-		return nil, err
+	if rulesJSON != "" {
+		if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
+			return nil, err
+		}
 	}
 
-	// Validate and set defaults
 	if rules.Type == "" {
 		rules.Type = ContestTypeStandard
 	}
 
-	if rules.Type == ContestTypeStandard && rules.Standard == nil {
-		defaultRules := DefaultStandardRules()
-		rules.Standard = &defaultRules
+	handler, ok := Lookup(rules.Type)
+	if !ok {
+		return nil, unknownTypeError(rules.Type)
 	}
+	handler.ApplyDefaults(&rules)
 
-	if rules.Type == ContestTypeRisky && rules.Risky == nil {
-		defaultRisky := DefaultRiskyRules()
-		rules.Risky = &defaultRisky
-	}
+	return &rules, nil
+}
 
-	if rules.Type == ContestTypeTotalizator && rules.Totalizator == nil {
-		defaultTotalizator := DefaultTotalizatorRules()
-		rules.Totalizator = &defaultTotalizator
+// ParseRulesOrDefault parses rules like ParseRules but falls back to default standard
+// rules when they are malformed, for callers that only need to display or route a contest
+func ParseRulesOrDefault(rulesJSON string) *ContestRules {
+	rules, err := ParseRules(rulesJSON)
+	if err != nil {
+		rules, _ = ParseRules("")
 	}
+	return rules
+}
+
+// DefaultRulesVersion identifies scores calculated without contest-specific rules
+const DefaultRulesVersion = "default"
 
-	if rules.Type == ContestTypeRelay && rules.Relay == nil {
-		defaultRelay := DefaultRelayRules()
-		rules.Relay = &defaultRelay
+// RulesVersion returns a short fingerprint of a contest's rules JSON so a stored score
+// can be traced back to the exact rules it was calculated with
+func RulesVersion(rulesJSON string) string {
+	if rulesJSON == "" {
+		return DefaultRulesVersion
 	}
+	sum := sha256.Sum256([]byte(rulesJSON))
+	return hex.EncodeToString(sum[:6])
+}
 
-	return &rules, nil
+// Rules bases choose which version of a contest's rules scores a prediction
+const (
+	RulesBasisSubmission = "submission" // the version in force when the prediction was submitted
+	RulesBasisKickoff    = "kickoff"    // the version in force when the match kicked off
+)
+
+// ValidRulesBasis reports whether basis names a rules basis. Empty means submission.
+func ValidRulesBasis(basis string) bool {
+	return basis == "" || basis == RulesBasisSubmission || basis == RulesBasisKickoff
 }
 
 // ToJSON serializes ContestRules to JSON string
@@ -170,72 +196,135 @@ func (r *ContestRules) ToJSON() (string, error) {
 	return string(data), nil
 }
 
+// Handler returns the registered handler for the rules' contest type
+func (r *ContestRules) Handler() (ContestTypeHandler, error) {
+	handler, ok := Lookup(r.Type)
+	if !ok {
+		return nil, unknownTypeError(r.Type)
+	}
+	return handler, nil
+}
+
 // Validate checks if rules are valid
 func (r *ContestRules) Validate() error {
-	validTypes := map[ContestType]bool{
-		ContestTypeStandard:    true,
-		ContestTypeRisky:       true,
-		ContestTypeTotalizator: true,
-		ContestTypeRelay:       true,
+	handler, err := r.Handler()
+	if err != nil {
+		return err
+	}
+	if r.Sport != "" {
+		if _, ok := LookupSportProfile(r.Sport); !ok {
+			return fmt.Errorf("unknown sport %q", r.Sport)
+		}
 	}
-	if !validTypes[r.Type] {
-		return errors.New("invalid contest type: must be 'standard', 'risky', 'totalizator', or 'relay'")
+	if err := r.TimeCoefficient.Validate(); err != nil {
+		return err
 	}
+	if err := r.Streak.Validate(); err != nil {
+		return err
+	}
+	if err := r.Leaderboard.Validate(); err != nil {
+		return err
+	}
+	return handler.Validate(r)
+}
 
-	if r.Type == ContestTypeStandard {
-		if r.Standard == nil {
-			return errors.New("standard rules required for standard contest")
-		}
-		if r.Standard.ExactScore < 0 || r.Standard.GoalDifference < 0 ||
-			r.Standard.CorrectOutcome < 0 || r.Standard.AnyOther < 0 {
-			return errors.New("scoring points cannot be negative")
-		}
+// Profile returns the scoring profile of the contest's sport, football if none is set
+func (r *ContestRules) Profile() *SportProfile {
+	if profile, ok := LookupSportProfile(r.Sport); ok {
+		return profile
 	}
+	return sportProfiles[SportFootball]
+}
 
-	if r.Type == ContestTypeRisky {
-		if r.Risky == nil {
-			return errors.New("risky rules required for risky contest")
-		}
-		if r.Risky.MaxSelections < 1 || r.Risky.MaxSelections > 10 {
-			return errors.New("max_selections must be between 1 and 10")
-		}
-		if len(r.Risky.Events) == 0 {
-			return errors.New("risky contest must have at least one event")
-		}
+// ValidatePrediction checks a raw prediction payload against the contest type
+func (r *ContestRules) ValidatePrediction(predictionJSON string) error {
+	handler, err := r.Handler()
+	if err != nil {
+		return err
 	}
 
-	if r.Type == ContestTypeTotalizator {
-		if r.Totalizator == nil {
-			return errors.New("totalizator rules required for totalizator contest")
-		}
-		if r.Totalizator.EventCount < 5 || r.Totalizator.EventCount > 30 {
-			return errors.New("event_count must be between 5 and 30")
-		}
-		if r.Totalizator.Scoring.ExactScore < 0 || r.Totalizator.Scoring.GoalDifference < 0 ||
-			r.Totalizator.Scoring.CorrectOutcome < 0 || r.Totalizator.Scoring.AnyOther < 0 {
-			return errors.New("scoring points cannot be negative")
-		}
+	prediction, err := ParsePrediction(predictionJSON)
+	if err != nil {
+		return err
 	}
+	return handler.ValidatePrediction(r, prediction)
+}
 
-	if r.Type == ContestTypeRelay {
-		if r.Relay == nil {
-			return errors.New("relay rules required for relay contest")
-		}
-		if r.Relay.TeamSize < 2 || r.Relay.TeamSize > 10 {
-			return errors.New("team_size must be between 2 and 10")
-		}
-		if r.Relay.EventCount < 5 || r.Relay.EventCount > 50 {
-			return errors.New("event_count must be between 5 and 50")
+// RequiresPredictionSet reports whether the contest's predictions for a round must be
+// submitted together instead of one event at a time
+func (r *ContestRules) RequiresPredictionSet() bool {
+	handler, err := r.Handler()
+	if err != nil {
+		return false
+	}
+	_, ok := handler.(PredictionSetValidator)
+	return ok
+}
+
+// ValidatePredictionSet checks the raw prediction payloads of a whole round: each one
+// on its own and, for contest types that require it, the set as a whole
+func (r *ContestRules) ValidatePredictionSet(predictionsJSON []string) error {
+	handler, err := r.Handler()
+	if err != nil {
+		return err
+	}
+
+	predictions := make([]*PredictionData, len(predictionsJSON))
+	for i, predictionJSON := range predictionsJSON {
+		prediction, err := ParsePrediction(predictionJSON)
+		if err != nil {
+			return err
 		}
-		if r.Relay.Scoring.ExactScore < 0 || r.Relay.Scoring.GoalDifference < 0 ||
-			r.Relay.Scoring.CorrectOutcome < 0 || r.Relay.Scoring.AnyOther < 0 {
-			return errors.New("scoring points cannot be negative")
+		if err := handler.ValidatePrediction(r, prediction); err != nil {
+			return err
 		}
+		predictions[i] = prediction
 	}
 
+	if setValidator, ok := handler.(PredictionSetValidator); ok {
+		return setValidator.ValidatePredictionSet(r, predictions)
+	}
 	return nil
 }
 
+// Score evaluates a prediction against an event result with the contest type's scoring
+func (r *ContestRules) Score(prediction *PredictionData, result *ResultData) CalculationResult {
+	details := map[string]interface{}{
+		"prediction_type": prediction.Type,
+		"contest_type":    r.Type,
+	}
+
+	handler, err := r.Handler()
+	if err != nil {
+		details["error"] = err.Error()
+		return CalculationResult{Points: 0, Details: details}
+	}
+
+	calcResult := handler.Score(r, prediction, result)
+	for k, v := range calcResult.Details {
+		details[k] = v
+	}
+	return CalculationResult{Points: calcResult.Points, Details: details}
+}
+
+// AppliesMultipliers reports whether streak and time multipliers scale the contest's points
+func (r *ContestRules) AppliesMultipliers() bool {
+	handler, err := r.Handler()
+	if err != nil {
+		return true
+	}
+	fixed, ok := handler.(FixedPointsType)
+	return !ok || !fixed.FixedPoints(r)
+}
+
+// MaxRiskySelections returns how many risky events a user may pick in the contest
+func (r *ContestRules) MaxRiskySelections() int {
+	if r.Risky != nil && r.Risky.MaxSelections > 0 {
+		return r.Risky.MaxSelections
+	}
+	return DefaultRiskyRules().MaxSelections
+}
+
 // GetEventBySlug finds a risky event by slug
 func (r *RiskyScoringRules) GetEventBySlug(slug string) *RiskyEvent {
 	for i := range r.Events {
@@ -0,0 +1,286 @@
+package scoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Sports with their own scoring profile. Contests of any other sport are scored as football.
+const (
+	SportFootball      = "football"
+	SportBasketball    = "basketball"
+	SportHockey        = "hockey"
+	SportTennis        = "tennis"     // best of three sets
+	SportTennisBestOf5 = "tennis_bo5" // best of five sets
+)
+
+// How a hockey match was decided, reported in the result's decided_in
+const (
+	DecidedRegulation = "regulation"
+	DecidedOvertime   = "overtime"
+	DecidedShootout   = "shootout"
+)
+
+// Margin band pick outcome reported as match_type
+const MarginBandMatch = "margin_band"
+
+// MarginBand is a range of winning margins scored as one outcome
+type MarginBand struct {
+	Min int
+	Max int // 0 means no upper bound
+}
+
+// Label returns the band as shown to users and stored in picks, e.g. "6-10" or "21+"
+func (b MarginBand) Label() string {
+	if b.Max == 0 {
+		return strconv.Itoa(b.Min) + "+"
+	}
+	return fmt.Sprintf("%d-%d", b.Min, b.Max)
+}
+
+// Contains checks if a winning margin falls into the band
+func (b MarginBand) Contains(margin int) bool {
+	return margin >= b.Min && (b.Max == 0 || margin <= b.Max)
+}
+
+// SportProfile describes how score lines of a sport are predicted and scored. Profiles are
+// shared and must not be modified.
+type SportProfile struct {
+	Sport       string
+	Draws       bool            // whether a result can be level
+	OtherAbove  int             // a side scoring more makes the result "any other"; 0 when any_other is not offered
+	MaxScore    int             // highest score a prediction may name
+	TeamScores  bool            // whether a correct outcome with one side's score right earns the bonus
+	MarginBands []MarginBand    // basketball: score differences are judged by band and picks may name a band
+	SetsToWin   int             // tennis: scores are sets and the match goes to the first side winning this many
+	Regulation  bool            // hockey: predictions are scored against the score after regulation time
+	QuickPicks  [][]ScoreOption // score lines offered as quick picks by chat keyboards, by row
+}
+
+// ScoreOption is a score line offered as a quick pick
+type ScoreOption struct {
+	Home int
+	Away int
+}
+
+var sportProfiles = map[string]*SportProfile{
+	SportFootball: {
+		Sport:      SportFootball,
+		Draws:      true,
+		OtherAbove: 4,
+		MaxScore:   20,
+		TeamScores: true,
+		QuickPicks: [][]ScoreOption{
+			{{0, 0}, {1, 1}, {2, 2}},
+			{{1, 0}, {2, 0}, {2, 1}},
+			{{3, 0}, {3, 1}, {3, 2}},
+			{{0, 1}, {0, 2}, {1, 2}},
+			{{0, 3}, {1, 3}, {2, 3}},
+		},
+	},
+	SportBasketball: {
+		Sport:    SportBasketball,
+		MaxScore: 250,
+		MarginBands: []MarginBand{
+			{Min: 1, Max: 5}, {Min: 6, Max: 10}, {Min: 11, Max: 15}, {Min: 16, Max: 20}, {Min: 21},
+		},
+	},
+	SportHockey: {
+		Sport:      SportHockey,
+		Draws:      true,
+		OtherAbove: 5,
+		MaxScore:   20,
+		TeamScores: true,
+		Regulation: true,
+		QuickPicks: [][]ScoreOption{
+			{{1, 1}, {2, 2}, {3, 3}},
+			{{1, 0}, {2, 1}, {3, 2}},
+			{{2, 0}, {3, 1}, {4, 2}},
+			{{0, 1}, {1, 2}, {2, 3}},
+			{{0, 2}, {1, 3}, {2, 4}},
+		},
+	},
+	SportTennis: {
+		Sport:     SportTennis,
+		MaxScore:  2,
+		SetsToWin: 2,
+		QuickPicks: [][]ScoreOption{
+			{{2, 0}, {2, 1}},
+			{{0, 2}, {1, 2}},
+		},
+	},
+	SportTennisBestOf5: {
+		Sport:     SportTennisBestOf5,
+		MaxScore:  3,
+		SetsToWin: 3,
+		QuickPicks: [][]ScoreOption{
+			{{3, 0}, {3, 1}, {3, 2}},
+			{{0, 3}, {1, 3}, {2, 3}},
+		},
+	},
+}
+
+// sportAliases maps other spellings of a sport, e.g. from a contest's sport_type
+var sportAliases = map[string]string{
+	"soccer":           SportFootball,
+	"ice_hockey":       SportHockey,
+	"tennis_bo3":       SportTennis,
+	"tennis_best_of_3": SportTennis,
+	"tennis_best_of_5": SportTennisBestOf5,
+}
+
+// LookupSportProfile returns the scoring profile of a sport
+func LookupSportProfile(sport string) (*SportProfile, bool) {
+	profile, ok := sportProfiles[normalizeSport(sport)]
+	return profile, ok
+}
+
+// normalizeSport turns a sport name such as "Ice Hockey" into its profile key
+func normalizeSport(sport string) string {
+	key := strings.ToLower(strings.TrimSpace(sport))
+	key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+	if alias, ok := sportAliases[key]; ok {
+		return alias
+	}
+	return key
+}
+
+// SameSport reports whether two sport names, such as "Soccer" and "football", are the same sport
+func SameSport(a, b string) bool {
+	return normalizeSport(a) == normalizeSport(b)
+}
+
+// RulesWithSport selects the scoring profile of a contest's sport type for rules that do not
+// name a sport themselves. Rules of football contests and of sports without a profile are
+// returned unchanged.
+func RulesWithSport(rulesJSON, sportType string) string {
+	profile, ok := LookupSportProfile(sportType)
+	if !ok || profile.Sport == SportFootball {
+		return rulesJSON
+	}
+
+	raw := make(map[string]json.RawMessage)
+	if strings.TrimSpace(rulesJSON) != "" {
+		if err := json.Unmarshal([]byte(rulesJSON), &raw); err != nil {
+			return rulesJSON
+		}
+	}
+	if _, ok := raw["sport"]; ok {
+		return rulesJSON
+	}
+
+	raw["sport"] = json.RawMessage(strconv.Quote(profile.Sport))
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return rulesJSON
+	}
+	return string(data)
+}
+
+// MarginBand returns the band with the given label
+func (p *SportProfile) MarginBand(label string) (MarginBand, bool) {
+	for _, band := range p.MarginBands {
+		if band.Label() == label {
+			return band, true
+		}
+	}
+	return MarginBand{}, false
+}
+
+// marginBandOf returns the label of the band a winning margin falls into
+func (p *SportProfile) marginBandOf(margin int) string {
+	for _, band := range p.MarginBands {
+		if band.Contains(margin) {
+			return band.Label()
+		}
+	}
+	return ""
+}
+
+// ValidateScore checks that a predicted score line is a possible result of the sport
+func (p *SportProfile) ValidateScore(home, away int) error {
+	if home < 0 || away < 0 {
+		return errors.New("scores cannot be negative")
+	}
+	if home > p.MaxScore || away > p.MaxScore {
+		return fmt.Errorf("scores cannot be higher than %d in %s", p.MaxScore, p.Sport)
+	}
+	if home == away && !p.Draws {
+		return fmt.Errorf("%s results cannot be a draw", p.Sport)
+	}
+	if p.SetsToWin > 0 && max(home, away) != p.SetsToWin {
+		return fmt.Errorf("the winner of a %s match takes %d sets", p.Sport, p.SetsToWin)
+	}
+	return nil
+}
+
+// validatePrediction checks a score-line contest prediction against the sport
+func (p *SportProfile) validatePrediction(prediction *PredictionData) error {
+	if prediction.IsAnyOther() && p.OtherAbove == 0 {
+		return fmt.Errorf("\"any other\" predictions are not offered in %s", p.Sport)
+	}
+	if prediction.Margin != "" {
+		if _, ok := p.MarginBand(prediction.Margin); !ok {
+			return fmt.Errorf("unknown margin band %q", prediction.Margin)
+		}
+		if prediction.Winner == nil || (*prediction.Winner != "home" && *prediction.Winner != "away") {
+			return errors.New("a margin pick needs winner \"home\" or \"away\"")
+		}
+		return nil
+	}
+	if prediction.HasScore() {
+		return p.ValidateScore(*prediction.HomeScore, *prediction.AwayScore)
+	}
+	return nil
+}
+
+// scoredResult returns the score predictions are judged against: the final score, or for
+// hockey the score after regulation time, taking away the winning goal of overtime or a shootout
+func (p *SportProfile) scoredResult(result *ResultData) ScoreData {
+	score := ScoreData{HomeScore: result.HomeScore, AwayScore: result.AwayScore}
+	if !p.Regulation || (result.DecidedIn != DecidedOvertime && result.DecidedIn != DecidedShootout) {
+		return score
+	}
+	switch {
+	case score.HomeScore > score.AwayScore:
+		score.HomeScore--
+	case score.AwayScore > score.HomeScore:
+		score.AwayScore--
+	}
+	return score
+}
+
+// isOther checks if a result falls outside the sport's common score lines
+func (p *SportProfile) isOther(homeScore, awayScore int) bool {
+	return p.OtherAbove > 0 && (homeScore > p.OtherAbove || awayScore > p.OtherAbove)
+}
+
+// scoreMarginPick scores a basketball-style pick of the winner and their winning margin band
+func scoreMarginPick(prediction *PredictionData, result ScoreData, scoring *StandardScoringRules, profile *SportProfile, contestType ContestType) CalculationResult {
+	calc := NewCalculator(nil)
+	actualOutcome := calc.determineOutcome(result.HomeScore, result.AwayScore)
+	margin := abs(result.HomeScore - result.AwayScore)
+	details := map[string]interface{}{
+		"type":              string(contestType),
+		"sport":             profile.Sport,
+		"predicted_outcome": *prediction.Winner,
+		"predicted_margin":  prediction.Margin,
+		"actual_outcome":    actualOutcome,
+		"actual_score":      fmt.Sprintf("%d:%d", result.HomeScore, result.AwayScore),
+		"actual_margin":     profile.marginBandOf(margin),
+	}
+
+	if *prediction.Winner != actualOutcome {
+		details["match_type"] = "none"
+		return CalculationResult{Points: 0, Details: details}
+	}
+	if band, ok := profile.MarginBand(prediction.Margin); ok && band.Contains(margin) {
+		details["match_type"] = MarginBandMatch
+		return CalculationResult{Points: scoring.GoalDifference, Details: details}
+	}
+	details["match_type"] = "correct_outcome"
+	return CalculationResult{Points: scoring.CorrectOutcome, Details: details}
+}
@@ -0,0 +1,161 @@
+package scoring
+
+import "testing"
+
+func TestLookupSportProfile(t *testing.T) {
+	tests := []struct {
+		sport string
+		want  string
+		ok    bool
+	}{
+		{"football", SportFootball, true},
+		{"Soccer", SportFootball, true},
+		{"Ice Hockey", SportHockey, true},
+		{"tennis-best-of-5", SportTennisBestOf5, true},
+		{"curling", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sport, func(t *testing.T) {
+			profile, ok := LookupSportProfile(tt.sport)
+			if ok != tt.ok {
+				t.Fatalf("LookupSportProfile(%q) ok = %v, want %v", tt.sport, ok, tt.ok)
+			}
+			if ok && profile.Sport != tt.want {
+				t.Errorf("LookupSportProfile(%q) = %q, want %q", tt.sport, profile.Sport, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesWithSport(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     string
+		sportType string
+		wantSport string
+	}{
+		{"empty rules", "", "basketball", SportBasketball},
+		{"rules without sport", `{"type":"standard"}`, "hockey", SportHockey},
+		{"rules name their sport", `{"type":"standard","sport":"tennis"}`, "hockey", SportTennis},
+		{"football", `{"type":"standard"}`, "football", SportFootball},
+		{"sport without profile", `{"type":"standard"}`, "curling", SportFootball},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(RulesWithSport(tt.rules, tt.sportType))
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			if got := rules.Profile().Sport; got != tt.wantSport {
+				t.Errorf("Profile() = %q, want %q", got, tt.wantSport)
+			}
+		})
+	}
+
+	if got := RulesWithSport(`{"type":"standard"}`, "football"); got != `{"type":"standard"}` {
+		t.Errorf("football rules changed to %s", got)
+	}
+}
+
+func TestValidateUnknownSport(t *testing.T) {
+	rules, err := ParseRules(`{"sport":"curling"}`)
+	if err != nil {
+		t.Fatalf("ParseRules() error = %v", err)
+	}
+	if err := rules.Validate(); err == nil {
+		t.Error("expected error for unknown sport")
+	}
+}
+
+func TestSportValidatePrediction(t *testing.T) {
+	tests := []struct {
+		name       string
+		sport      string
+		prediction string
+		wantErr    bool
+	}{
+		{"football draw", "football", `{"type":"exact_score","home_score":1,"away_score":1}`, false},
+		{"football any other", "football", `{"type":"any_other"}`, false},
+		{"basketball score", "basketball", `{"type":"exact_score","home_score":104,"away_score":98}`, false},
+		{"basketball draw", "basketball", `{"type":"exact_score","home_score":100,"away_score":100}`, true},
+		{"basketball any other", "basketball", `{"type":"any_other"}`, true},
+		{"basketball margin", "basketball", `{"type":"winner","winner":"away","margin":"6-10"}`, false},
+		{"basketball open margin", "basketball", `{"type":"winner","winner":"home","margin":"21+"}`, false},
+		{"basketball unknown margin", "basketball", `{"type":"winner","winner":"home","margin":"3-7"}`, true},
+		{"basketball margin without winner", "basketball", `{"type":"winner","margin":"1-5"}`, true},
+		{"hockey regulation draw", "hockey", `{"type":"exact_score","home_score":2,"away_score":2}`, false},
+		{"tennis straight sets", "tennis", `{"type":"exact_score","home_score":2,"away_score":0}`, false},
+		{"tennis too many sets", "tennis", `{"type":"exact_score","home_score":3,"away_score":1}`, true},
+		{"tennis unfinished", "tennis", `{"type":"exact_score","home_score":1,"away_score":1}`, true},
+		{"tennis best of five", "tennis_bo5", `{"type":"exact_score","home_score":2,"away_score":3}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(`{"type":"standard","sport":"` + tt.sport + `"}`)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			err = rules.ValidatePrediction(tt.prediction)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePrediction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSportScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		sport      string
+		prediction string
+		result     string
+		wantPoints float64
+		wantMatch  string
+	}{
+		{"football goal difference", "football", `{"type":"exact_score","home_score":2,"away_score":1}`, `{"home_score":3,"away_score":2}`, 3, "goal_difference"},
+		{"football any other", "football", `{"type":"any_other"}`, `{"home_score":5,"away_score":0}`, 4, "any_other_correct"},
+		{"basketball exact", "basketball", `{"type":"exact_score","home_score":101,"away_score":95}`, `{"home_score":101,"away_score":95}`, 5, "exact_score"},
+		{"basketball same band", "basketball", `{"type":"exact_score","home_score":110,"away_score":102}`, `{"home_score":98,"away_score":91}`, 3, MarginBandMatch},
+		{"basketball other band", "basketball", `{"type":"exact_score","home_score":110,"away_score":108}`, `{"home_score":98,"away_score":91}`, 1, "correct_outcome"},
+		{"basketball no team score bonus", "basketball", `{"type":"exact_score","home_score":98,"away_score":70}`, `{"home_score":98,"away_score":91}`, 1, "correct_outcome"},
+		{"basketball margin pick", "basketball", `{"type":"winner","winner":"away","margin":"21+"}`, `{"home_score":80,"away_score":104}`, 3, MarginBandMatch},
+		{"basketball margin pick wrong band", "basketball", `{"type":"winner","winner":"away","margin":"1-5"}`, `{"home_score":80,"away_score":104}`, 1, "correct_outcome"},
+		{"basketball margin pick wrong winner", "basketball", `{"type":"winner","winner":"home","margin":"1-5"}`, `{"home_score":80,"away_score":104}`, 0, "none"},
+		{"hockey overtime is a regulation draw", "hockey", `{"type":"exact_score","home_score":2,"away_score":2}`, `{"home_score":3,"away_score":2,"decided_in":"overtime"}`, 5, "exact_score"},
+		{"hockey shootout", "hockey", `{"type":"exact_score","home_score":1,"away_score":1}`, `{"home_score":2,"away_score":3,"decided_in":"shootout"}`, 3, "goal_difference"},
+		{"hockey regulation winner", "hockey", `{"type":"exact_score","home_score":3,"away_score":2}`, `{"home_score":3,"away_score":3,"decided_in":"overtime"}`, 0, "none"},
+		{"hockey regulation", "hockey", `{"type":"exact_score","home_score":3,"away_score":2}`, `{"home_score":3,"away_score":2,"decided_in":"regulation"}`, 5, "exact_score"},
+		{"hockey any other", "hockey", `{"type":"any_other"}`, `{"home_score":5,"away_score":1}`, 0, "any_other_incorrect"},
+		{"tennis exact sets", "tennis", `{"type":"exact_score","home_score":2,"away_score":1}`, `{"home_score":2,"away_score":1}`, 5, "exact_score"},
+		{"tennis winner only", "tennis", `{"type":"exact_score","home_score":2,"away_score":1}`, `{"home_score":2,"away_score":0}`, 1, "correct_outcome"},
+		{"tennis wrong winner", "tennis", `{"type":"exact_score","home_score":2,"away_score":1}`, `{"home_score":1,"away_score":2}`, 0, "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(`{"type":"standard","sport":"` + tt.sport + `"}`)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			prediction, err := ParsePrediction(tt.prediction)
+			if err != nil {
+				t.Fatalf("ParsePrediction() error = %v", err)
+			}
+			result, err := ParseResult(tt.result)
+			if err != nil {
+				t.Fatalf("ParseResult() error = %v", err)
+			}
+
+			calc := rules.Score(prediction, result)
+			if calc.Points != tt.wantPoints {
+				t.Errorf("Score() points = %v, want %v (details %v)", calc.Points, tt.wantPoints, calc.Details)
+			}
+			if calc.Details["match_type"] != tt.wantMatch {
+				t.Errorf("Score() match_type = %v, want %q", calc.Details["match_type"], tt.wantMatch)
+			}
+		})
+	}
+}
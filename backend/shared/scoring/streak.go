@@ -0,0 +1,148 @@
+package scoring
+
+import (
+	"errors"
+	"fmt"
+)
+
+// What counts as a streak hit
+const (
+	StreakHitPositive = "positive_points" // any prediction that earns points (default)
+	StreakHitOutcome  = "correct_outcome" // the outcome of the match was picked right
+	StreakHitExact    = "exact_score"     // only exact score predictions
+)
+
+// StreakStep is a rung of the multiplier ladder: streaks of at least Length hits earn Multiplier
+type StreakStep struct {
+	Length     uint    `json:"length"`
+	Multiplier float64 `json:"multiplier"`
+}
+
+// StreakRules configures how consecutive hits scale a contest's points
+type StreakRules struct {
+	Disabled      bool         `json:"disabled,omitempty"`       // streaks are tracked but never multiply points
+	Hit           string       `json:"hit,omitempty"`            // what counts as a hit; default positive_points
+	Ladder        []StreakStep `json:"ladder,omitempty"`         // empty uses DefaultStreakLadder
+	MaxMultiplier float64      `json:"max_multiplier,omitempty"` // cap on the multiplier; 0 means no cap
+	Freezes       uint         `json:"freezes,omitempty"`        // misses per contest that pause the streak instead of resetting it
+}
+
+// outcomeHits are the rules matched by predictions that got the match outcome right
+var outcomeHits = map[string]bool{
+	"exact_score":             true,
+	"goal_difference":         true,
+	MarginBandMatch:           true,
+	"outcome_plus_team_goals": true,
+	"correct_outcome":         true,
+	"any_other_correct":       true,
+	ConfidenceCorrect:         true,
+	BracketCorrect:            true,
+	PoolHit:                   true,
+	SurvivorSurvived:          true,
+}
+
+// judgedByOutcome are the rules that describe whether a match was predicted right. Others,
+// such as risky picks or manual scores, only have points to go by.
+var judgedByOutcome = map[string]bool{
+	"none":                true,
+	"any_other_incorrect": true,
+	ConfidenceWrong:       true,
+	BracketWrong:          true,
+	PoolMiss:              true,
+	SurvivorLost:          true,
+}
+
+// DefaultStreakLadder returns the multiplier ladder used when a contest does not define one
+func DefaultStreakLadder() []StreakStep {
+	return []StreakStep{
+		{Length: 3, Multiplier: 1.25},
+		{Length: 5, Multiplier: 1.5},
+		{Length: 7, Multiplier: 1.75},
+		{Length: 10, Multiplier: 2.0},
+	}
+}
+
+// IsHit reports whether a scored prediction extends the streak. Rules that do not describe
+// a match outcome, such as risky picks or manual scores, count as hits when they earn points.
+// Nil rules use the defaults.
+func (r *StreakRules) IsHit(ruleMatched string, basePoints float64) bool {
+	hit := StreakHitPositive
+	if r != nil && r.Hit != "" {
+		hit = r.Hit
+	}
+
+	if hit == StreakHitPositive || (!outcomeHits[ruleMatched] && !judgedByOutcome[ruleMatched]) {
+		return basePoints > 0
+	}
+	if hit == StreakHitExact {
+		return ruleMatched == "exact_score"
+	}
+	return outcomeHits[ruleMatched]
+}
+
+// Multiplier returns the points multiplier for a streak of the given length
+func (r *StreakRules) Multiplier(streak uint) float64 {
+	if r != nil && r.Disabled {
+		return 1.0
+	}
+
+	ladder := DefaultStreakLadder()
+	if r != nil && len(r.Ladder) > 0 {
+		ladder = r.Ladder
+	}
+
+	multiplier := 1.0
+	for _, step := range ladder {
+		if streak >= step.Length && step.Multiplier > multiplier {
+			multiplier = step.Multiplier
+		}
+	}
+	if r != nil && r.MaxMultiplier > 0 && multiplier > r.MaxMultiplier {
+		multiplier = r.MaxMultiplier
+	}
+	return multiplier
+}
+
+// FreezeCount returns how many misses per contest keep the streak alive
+func (r *StreakRules) FreezeCount() uint {
+	if r == nil {
+		return 0
+	}
+	return r.Freezes
+}
+
+// Validate checks the hit mode and that the ladder climbs with the streak
+func (r *StreakRules) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	switch r.Hit {
+	case "", StreakHitPositive, StreakHitOutcome, StreakHitExact:
+	default:
+		return fmt.Errorf("streak hit must be %q, %q or %q", StreakHitPositive, StreakHitOutcome, StreakHitExact)
+	}
+
+	if len(r.Ladder) > 20 {
+		return errors.New("streak ladder cannot have more than 20 steps")
+	}
+	for i, step := range r.Ladder {
+		if step.Length == 0 {
+			return errors.New("streak ladder step length must be positive")
+		}
+		if step.Multiplier < 1 || step.Multiplier > 10 {
+			return errors.New("streak multiplier must be between 1 and 10")
+		}
+		if i > 0 && (step.Length <= r.Ladder[i-1].Length || step.Multiplier < r.Ladder[i-1].Multiplier) {
+			return errors.New("streak ladder must list longer streaks after shorter ones without lowering the multiplier")
+		}
+	}
+
+	if r.MaxMultiplier != 0 && r.MaxMultiplier < 1 {
+		return errors.New("streak max_multiplier must be at least 1")
+	}
+	if r.Freezes > 10 {
+		return errors.New("a contest cannot offer more than 10 streak freezes")
+	}
+	return nil
+}
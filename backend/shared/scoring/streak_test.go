@@ -0,0 +1,91 @@
+package scoring
+
+import "testing"
+
+func TestStreakIsHit(t *testing.T) {
+	tests := []struct {
+		name        string
+		hit         string
+		ruleMatched string
+		points      float64
+		want        bool
+	}{
+		{"default counts points", "", "correct_outcome", 1, true},
+		{"default misses without points", "", "none", 0, false},
+		{"outcome counts outcome", StreakHitOutcome, "correct_outcome", 1, true},
+		{"outcome counts margin band", StreakHitOutcome, MarginBandMatch, 3, true},
+		{"outcome misses wrong pick", StreakHitOutcome, ConfidenceWrong, 0, false},
+		{"outcome of risky pick uses points", StreakHitOutcome, "risky", 2.5, true},
+		{"outcome of losing risky pick", StreakHitOutcome, "risky", -1, false},
+		{"exact counts exact", StreakHitExact, "exact_score", 5, true},
+		{"exact misses goal difference", StreakHitExact, "goal_difference", 3, false},
+		{"exact of manual score uses points", StreakHitExact, "", 2, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := &StreakRules{Hit: tt.hit}
+			if got := rules.IsHit(tt.ruleMatched, tt.points); got != tt.want {
+				t.Errorf("IsHit(%q, %v) = %v, want %v", tt.ruleMatched, tt.points, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStreakMultiplier(t *testing.T) {
+	custom := &StreakRules{
+		Ladder:        []StreakStep{{Length: 2, Multiplier: 1.1}, {Length: 4, Multiplier: 1.5}, {Length: 6, Multiplier: 3}},
+		MaxMultiplier: 2,
+	}
+
+	tests := []struct {
+		name   string
+		rules  *StreakRules
+		streak uint
+		want   float64
+	}{
+		{"default short", nil, 2, 1.0},
+		{"default three", nil, 3, 1.25},
+		{"default ten", nil, 12, 2.0},
+		{"disabled", &StreakRules{Disabled: true}, 10, 1.0},
+		{"custom first step", custom, 2, 1.1},
+		{"custom middle", custom, 5, 1.5},
+		{"custom capped", custom, 6, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rules.Multiplier(tt.streak); got != tt.want {
+				t.Errorf("Multiplier(%d) = %v, want %v", tt.streak, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateStreak(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   string
+		wantErr bool
+	}{
+		{"defaults", `{"streak":{}}`, false},
+		{"custom ladder", `{"streak":{"hit":"exact_score","ladder":[{"length":2,"multiplier":1.2},{"length":4,"multiplier":1.5}],"max_multiplier":1.5,"freezes":1}}`, false},
+		{"unknown hit", `{"streak":{"hit":"sometimes"}}`, true},
+		{"unordered ladder", `{"streak":{"ladder":[{"length":4,"multiplier":1.5},{"length":2,"multiplier":1.2}]}}`, true},
+		{"lowering multiplier", `{"streak":{"ladder":[{"length":2,"multiplier":1.5},{"length":4,"multiplier":1.2}]}}`, true},
+		{"multiplier below one", `{"streak":{"ladder":[{"length":2,"multiplier":0.5}]}}`, true},
+		{"cap below one", `{"streak":{"max_multiplier":0.5}}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.rules)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			if err := rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
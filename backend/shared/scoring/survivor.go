@@ -0,0 +1,146 @@
+package scoring
+
+import (
+	"errors"
+	"time"
+)
+
+// ContestTypeSurvivor is a last-man-standing contest: each round a participant picks one
+// team to win, may not reuse a team, and is eliminated after losing all lives
+const ContestTypeSurvivor ContestType = "survivor"
+
+// Survivor pick outcomes reported as match_type
+const (
+	SurvivorSurvived = "survived"
+	SurvivorLost     = "lost"
+)
+
+// SurvivorRules defines rules for survivor contest
+type SurvivorRules struct {
+	Lives           int  `json:"lives"`             // losses allowed before elimination (default 1)
+	DrawSurvives    bool `json:"draw_survives"`     // a draw keeps the participant alive instead of costing a life
+	RoundLengthDays int  `json:"round_length_days"` // rounds are consecutive windows of this many days from contest start (default 7)
+}
+
+// DefaultSurvivorRules returns default survivor rules
+func DefaultSurvivorRules() SurvivorRules {
+	return SurvivorRules{
+		Lives:           1,
+		DrawSurvives:    false,
+		RoundLengthDays: 7,
+	}
+}
+
+// Round returns the 1-based round an event belongs to. Events before the contest
+// start fall into the first round.
+func (r *SurvivorRules) Round(contestStart, eventDate time.Time) int {
+	length := time.Duration(r.RoundLengthDays) * 24 * time.Hour
+	if length <= 0 || !eventDate.After(contestStart) {
+		return 1
+	}
+	return int(eventDate.Sub(contestStart)/length) + 1
+}
+
+// IsEliminated checks if the given number of lost picks eliminates a participant
+func (r *SurvivorRules) IsEliminated(livesLost int) bool {
+	return livesLost >= r.Lives
+}
+
+// SurvivorStanding summarises a participant's survivor picks
+type SurvivorStanding struct {
+	RoundsSurvived int
+	LivesLost      int
+	Eliminated     bool
+}
+
+// Standing replays pick outcomes (SurvivorSurvived or SurvivorLost) in round order.
+// Picks settled after the participant was eliminated do not count.
+func (r *SurvivorRules) Standing(outcomes []string) SurvivorStanding {
+	var standing SurvivorStanding
+	for _, outcome := range outcomes {
+		if standing.Eliminated {
+			break
+		}
+		switch outcome {
+		case SurvivorSurvived:
+			standing.RoundsSurvived++
+		case SurvivorLost:
+			standing.LivesLost++
+			standing.Eliminated = r.IsEliminated(standing.LivesLost)
+		}
+	}
+	return standing
+}
+
+func init() {
+	Register(survivorType{})
+}
+
+// survivorType scores a single team pick per round: a surviving pick earns one point
+// and no multipliers are applied. Leaderboard totals are rounds survived up to elimination.
+type survivorType struct{}
+
+func (survivorType) Type() ContestType { return ContestTypeSurvivor }
+
+func (survivorType) ApplyDefaults(rules *ContestRules) {
+	if rules.Survivor == nil {
+		defaultSurvivor := DefaultSurvivorRules()
+		rules.Survivor = &defaultSurvivor
+		return
+	}
+	if rules.Survivor.Lives == 0 {
+		rules.Survivor.Lives = 1
+	}
+	if rules.Survivor.RoundLengthDays == 0 {
+		rules.Survivor.RoundLengthDays = 7
+	}
+}
+
+func (survivorType) Validate(rules *ContestRules) error {
+	if rules.Survivor == nil {
+		return errors.New("survivor rules required for survivor contest")
+	}
+	if rules.Survivor.Lives < 1 || rules.Survivor.Lives > 5 {
+		return errors.New("lives must be between 1 and 5")
+	}
+	if rules.Survivor.RoundLengthDays < 1 || rules.Survivor.RoundLengthDays > 31 {
+		return errors.New("round_length_days must be between 1 and 31")
+	}
+	return nil
+}
+
+func (survivorType) ValidatePrediction(rules *ContestRules, prediction *PredictionData) error {
+	if prediction.Winner == nil || (*prediction.Winner != "home" && *prediction.Winner != "away") {
+		return errors.New("survivor pick must be \"home\" or \"away\"")
+	}
+	return nil
+}
+
+func (survivorType) Score(rules *ContestRules, prediction *PredictionData, result *ResultData) CalculationResult {
+	details := map[string]interface{}{"type": string(ContestTypeSurvivor)}
+	if prediction.Winner == nil {
+		details["error"] = "Missing survivor pick"
+		return CalculationResult{Points: 0, Details: details}
+	}
+
+	actual := NewCalculator(rules).determineOutcome(result.HomeScore, result.AwayScore)
+	details["pick"] = *prediction.Winner
+	details["actual_outcome"] = actual
+	if prediction.Team != "" {
+		details["team"] = prediction.Team
+	}
+	if prediction.Round > 0 {
+		details["round"] = prediction.Round
+	}
+
+	if actual == *prediction.Winner || (actual == "draw" && rules.Survivor.DrawSurvives) {
+		details["match_type"] = SurvivorSurvived
+		return CalculationResult{Points: 1, Details: details}
+	}
+
+	details["match_type"] = SurvivorLost
+	return CalculationResult{Points: 0, Details: details}
+}
+
+// FixedPoints reports that survivor points count rounds survived and must not be scaled
+func (survivorType) FixedPoints(rules *ContestRules) bool { return true }
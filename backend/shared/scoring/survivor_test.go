@@ -0,0 +1,102 @@
+package scoring
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSurvivorRound(t *testing.T) {
+	rules := DefaultSurvivorRules()
+	start := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		eventDate time.Time
+		want      int
+	}{
+		{"before start", start.Add(-time.Hour), 1},
+		{"first day", start.Add(time.Hour), 1},
+		{"last moment of round one", start.Add(7*24*time.Hour - time.Second), 1},
+		{"second round", start.Add(7 * 24 * time.Hour), 2},
+		{"fourth round", start.Add(22 * 24 * time.Hour), 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rules.Round(start, tt.eventDate); got != tt.want {
+				t.Errorf("Round() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurvivorStanding(t *testing.T) {
+	tests := []struct {
+		name     string
+		lives    int
+		outcomes []string
+		want     SurvivorStanding
+	}{
+		{"no picks", 1, nil, SurvivorStanding{}},
+		{"all survived", 1, []string{SurvivorSurvived, SurvivorSurvived}, SurvivorStanding{RoundsSurvived: 2}},
+		{"picks after elimination ignored", 1, []string{SurvivorSurvived, SurvivorLost, SurvivorSurvived}, SurvivorStanding{RoundsSurvived: 1, LivesLost: 1, Eliminated: true}},
+		{"extra life", 2, []string{SurvivorLost, SurvivorSurvived, SurvivorSurvived}, SurvivorStanding{RoundsSurvived: 2, LivesLost: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := SurvivorRules{Lives: tt.lives, RoundLengthDays: 7}
+			if got := rules.Standing(tt.outcomes); got != tt.want {
+				t.Errorf("Standing() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSurvivorScore(t *testing.T) {
+	tests := []struct {
+		name        string
+		rules       string
+		prediction  string
+		result      string
+		wantPoints  float64
+		wantOutcome string
+	}{
+		{"pick wins", `{"type":"survivor"}`, `{"winner":"home","team":"Arsenal"}`, `{"home_score":2,"away_score":0}`, 1, SurvivorSurvived},
+		{"pick loses", `{"type":"survivor"}`, `{"winner":"away"}`, `{"home_score":2,"away_score":0}`, 0, SurvivorLost},
+		{"draw loses by default", `{"type":"survivor"}`, `{"winner":"home"}`, `{"home_score":1,"away_score":1}`, 0, SurvivorLost},
+		{"draw survives when allowed", `{"type":"survivor","survivor":{"draw_survives":true}}`, `{"winner":"home"}`, `{"home_score":1,"away_score":1}`, 1, SurvivorSurvived},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseRules(tt.rules)
+			if err != nil {
+				t.Fatalf("ParseRules() error = %v", err)
+			}
+			if rules.AppliesMultipliers() {
+				t.Error("survivor points must not be scaled by multipliers")
+			}
+
+			prediction, _ := ParsePrediction(tt.prediction)
+			result, _ := ParseResult(tt.result)
+			got := rules.Score(prediction, result)
+			if got.Points != tt.wantPoints {
+				t.Errorf("Score() points = %v, want %v", got.Points, tt.wantPoints)
+			}
+			if got.Details["match_type"] != tt.wantOutcome {
+				t.Errorf("Score() match_type = %v, want %v", got.Details["match_type"], tt.wantOutcome)
+			}
+		})
+	}
+}
+
+func TestSurvivorValidatePrediction(t *testing.T) {
+	rules, _ := ParseRules(`{"type":"survivor"}`)
+	if err := rules.ValidatePrediction(`{"winner":"home"}`); err != nil {
+		t.Errorf("ValidatePrediction() unexpected error = %v", err)
+	}
+	if err := rules.ValidatePrediction(`{"winner":"draw"}`); err == nil {
+		t.Error("ValidatePrediction() expected error for draw pick")
+	}
+}
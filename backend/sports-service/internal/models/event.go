@@ -8,17 +8,19 @@ import (
 
 // Event represents a sports event in user-friendly format
 type Event struct {
-	ID         uint           `gorm:"primaryKey" json:"id"`
-	Title      string         `gorm:"not null" json:"title"`
-	SportType  string         `gorm:"not null;index" json:"sport_type"`
-	HomeTeam   string         `gorm:"not null" json:"home_team"`
-	AwayTeam   string         `gorm:"not null" json:"away_team"`
-	EventDate  time.Time      `gorm:"not null;index" json:"event_date"`
-	Status     string         `gorm:"not null;default:'scheduled';index" json:"status"`
-	ResultData string         `gorm:"type:jsonb" json:"result_data"`
-	CreatedAt  time.Time      `json:"created_at"`
-	UpdatedAt  time.Time      `json:"updated_at"`
-	DeletedAt  gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	Title            string         `gorm:"not null" json:"title"`
+	SportType        string         `gorm:"not null;index" json:"sport_type"`
+	HomeTeam         string         `gorm:"not null" json:"home_team"`
+	AwayTeam         string         `gorm:"not null" json:"away_team"`
+	EventDate        time.Time      `gorm:"not null;index" json:"event_date"`
+	Status           string         `gorm:"not null;default:'scheduled';index" json:"status"`
+	ResultData       string         `gorm:"type:jsonb" json:"result_data"`
+	Rosters          string         `gorm:"type:text" json:"rosters"`            // players of each side, set through the prediction service
+	ScoredResultData string         `gorm:"type:text" json:"scored_result_data"` // result scores were last settled against while a correction awaits rescoring
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"deleted_at,omitempty"`
 }
 
 // TableName specifies the table name for Event
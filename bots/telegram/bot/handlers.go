@@ -16,6 +16,7 @@ import (
 	predictionpb "github.com/sports-prediction-contests/shared/proto/prediction"
 	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
 	userpb "github.com/sports-prediction-contests/shared/proto/user"
+	"github.com/sports-prediction-contests/shared/scoring"
 	"github.com/sports-prediction-contests/telegram-bot/clients"
 	"google.golang.org/grpc/metadata"
 )
@@ -170,6 +171,13 @@ func (h *Handlers) HandleCallback(cb *tgbotapi.CallbackQuery) {
 			return
 		}
 		h.handleLeaderboardCallback(chatID, msgID, uint32(id))
+	case strings.HasPrefix(data, "myscores_"):
+		id, err := strconv.ParseUint(strings.TrimPrefix(data, "myscores_"), 10, 32)
+		if err != nil {
+			h.editMessage(chatID, msgID, "Invalid contest ID.", BackToMainKeyboard())
+			return
+		}
+		h.handleMyScores(chatID, msgID, uint32(id))
 	case strings.HasPrefix(data, "matches_"):
 		// Format: matches_contestID_page
 		parts := strings.Split(strings.TrimPrefix(data, "matches_"), "_")
@@ -198,6 +206,15 @@ func (h *Handlers) HandleCallback(cb *tgbotapi.CallbackQuery) {
 		homeScore, _ := strconv.Atoi(parts[1])
 		awayScore, _ := strconv.Atoi(parts[2])
 		h.handlePredictionSubmit(chatID, msgID, uint32(matchID), homeScore, awayScore)
+	case strings.HasPrefix(data, "pm_"):
+		// Format: pm_matchID_winner_margin
+		parts := strings.SplitN(strings.TrimPrefix(data, "pm_"), "_", 3)
+		if len(parts) < 3 {
+			log.Printf("[WARN] Invalid margin prediction callback data: %s", data)
+			return
+		}
+		matchID, _ := strconv.ParseUint(parts[0], 10, 32)
+		h.handleMarginPrediction(chatID, msgID, uint32(matchID), parts[1], parts[2])
 	case strings.HasPrefix(data, "pany_"):
 		// Format: pany_matchID
 		id, _ := strconv.ParseUint(strings.TrimPrefix(data, "pany_"), 10, 32)
@@ -659,7 +676,8 @@ func (h *Handlers) handleRiskySubmit(chatID int64, msgID int, matchID uint32) {
 	h.editMessage(chatID, msgID, text, BackToMainKeyboard())
 }
 
-// getContestRules fetches contest rules from contest-service
+// getContestRules fetches contest rules from contest-service, with the scoring profile
+// of the contest's sport type selected when the rules do not name a sport
 func (h *Handlers) getContestRules(contestID uint32) string {
 	if contestID == 0 {
 		return ""
@@ -677,7 +695,7 @@ func (h *Handlers) getContestRules(contestID uint32) string {
 		return ""
 	}
 	
-	return resp.Contest.Rules
+	return scoring.RulesWithSport(resp.Contest.Rules, resp.Contest.SportType)
 }
 
 // Shutdown gracefully stops the handlers and cleanup goroutines
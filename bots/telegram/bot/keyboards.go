@@ -42,6 +42,9 @@ func ContestDetailKeyboard(contestID uint32) tgbotapi.InlineKeyboardMarkup {
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("🏅 Таблица лидеров", fmt.Sprintf("leaderboard_%d", contestID)),
 		),
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("🧾 Мои очки", fmt.Sprintf("myscores_%d", contestID)),
+		),
 		tgbotapi.NewInlineKeyboardRow(
 			tgbotapi.NewInlineKeyboardButtonData("« Назад", "contests"),
 		),
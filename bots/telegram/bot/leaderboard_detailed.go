@@ -10,7 +10,7 @@ import (
 
 // showDetailedLeaderboard displays leaderboard with detailed statistics breakdown.
 // If msgID > 0, edits the existing message. If msgID == 0, sends a new message.
-// Format: Rank | Nickname | Points | Exact | GoalDiff | Outcome | TeamGoals
+// Format: Rank | Nickname | Movement | Points (this round) | Exact | GoalDiff | Outcome | TeamGoals
 func (h *Handlers) showDetailedLeaderboard(chatID int64, msgID int, contestID uint32) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -52,7 +52,11 @@ func (h *Handlers) showDetailedLeaderboard(chatID int64, msgID int, contestID ui
 		outcomes := 0
 		teamGoals := 0
 
-		text += FormatDetailedLeaderboardEntry(i+1, e.UserName, e.TotalPoints, exactScores, goalDiffs, outcomes, teamGoals)
+		rank := int(e.Rank)
+		if rank == 0 {
+			rank = i + 1
+		}
+		text += FormatDetailedLeaderboardEntry(rank, e.UserName, e.TotalPoints, e.RankChange, e.RoundPoints, exactScores, goalDiffs, outcomes, teamGoals)
 	}
 
 	if msgID > 0 {
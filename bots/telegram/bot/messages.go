@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/sports-prediction-contests/shared/coefficient"
 	predictionpb "github.com/sports-prediction-contests/shared/proto/prediction"
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
 )
 
 const (
@@ -67,8 +69,35 @@ Max Streak: <b>%d</b>`
 	MsgOtherPredictions    = "\n\n👥 <b>Other Predictions:</b>\n"
 	MsgDetailedLeaderboard = "🏅 <b>Detailed Leaderboard</b>\n\n"
 	MsgSelectContestFirst  = "⚠️ Please select a contest first."
+	MsgMyScores            = "🧾 <b>Мои очки</b>\n\n"
+	MsgNoScores            = "Пока нет рассчитанных прогнозов."
 )
 
+// ruleNames maps scoring rules to human readable labels
+var ruleNames = map[string]string{
+	"exact":                   "Точный счёт",
+	"exact_score":             "Точный счёт",
+	"goal_difference":         "Разница мячей",
+	"margin_band":             "Разница в диапазоне",
+	"outcome_plus_team_goals": "Исход + голы команды",
+	"correct_outcome":         "Исход",
+	"any_other_correct":       "Другой счёт",
+	"any_other_incorrect":     "Мимо",
+	"none":                    "Мимо",
+	"risky":                   "Рисковые события",
+	"props":                   "Пропы",
+	"survived":                "Выжил",
+	"lost":                    "Выбыл",
+	"correct_pick":            "Верный исход",
+	"wrong_pick":              "Мимо",
+	"bracket_correct":         "Угадан проход",
+	"bracket_wrong":           "Мимо",
+	"pool_hit":                "Угадан исход",
+	"pool_miss":               "Мимо",
+	"manual":                  "Начислено вручную",
+	"unscorable":              "Не удалось рассчитать",
+}
+
 // FormatContest formats a contest entry for display in the contest list.
 // Returns a formatted string with emoji, title, sport type, and ID.
 func FormatContest(id uint32, title, sportType, status string) string {
@@ -138,8 +167,9 @@ func FormatMatchWithPredictions(match *predictionpb.Event, predictions []*predic
 }
 
 // FormatDetailedLeaderboardEntry formats leaderboard entry with detailed statistics breakdown.
-// Shows rank, name, total points, and detailed stats (exact scores, goal diffs, outcomes, team goals).
-func FormatDetailedLeaderboardEntry(rank int, name string, points float64, exactScores, goalDiffs, outcomes, teamGoals int) string {
+// Shows rank, name, movement since the last round, total points with the points earned this
+// round, and detailed stats (exact scores, goal diffs, outcomes, team goals).
+func FormatDetailedLeaderboardEntry(rank int, name string, points float64, rankChange int32, roundPoints float64, exactScores, goalDiffs, outcomes, teamGoals int) string {
 	medal := ""
 	switch rank {
 	case 1:
@@ -151,6 +181,61 @@ func FormatDetailedLeaderboardEntry(rank int, name string, points float64, exact
 	default:
 		medal = fmt.Sprintf("%d.", rank)
 	}
-	return fmt.Sprintf("%s %s\n💯 %.1f pts | 🎯 %d | ⚖️ %d | ✓ %d | ⚽ %d\n\n",
-		medal, name, points, exactScores, goalDiffs, outcomes, teamGoals)
+	roundText := ""
+	if roundPoints != 0 {
+		roundText = fmt.Sprintf(" (%+.1f)", roundPoints)
+	}
+	return fmt.Sprintf("%s %s%s\n💯 %.1f pts%s | 🎯 %d | ⚖️ %d | ✓ %d | ⚽ %d\n\n",
+		medal, name, FormatRankChange(rankChange), points, roundText, exactScores, goalDiffs, outcomes, teamGoals)
+}
+
+// FormatRankChange shows how many places a participant moved since the last round snapshot
+func FormatRankChange(change int32) string {
+	switch {
+	case change > 0:
+		return fmt.Sprintf(" ▲%d", change)
+	case change < 0:
+		return fmt.Sprintf(" ▼%d", -change)
+	}
+	return ""
+}
+
+// FormatScoreBreakdown explains how a prediction's points were calculated:
+// the rule that matched, base points and the multipliers applied.
+func FormatScoreBreakdown(b *scoringpb.ScoreBreakdown) string {
+	rule, ok := ruleNames[b.RuleMatched]
+	if !ok {
+		rule = b.RuleMatched
+	}
+	if rule == "" {
+		rule = "—"
+	}
+
+	text := fmt.Sprintf("📌 Прогноз #%d: <b>%.2f</b> очк.\n", b.PredictionId, b.FinalPoints)
+	text += fmt.Sprintf("   %s: %.2f\n", rule, b.BasePoints)
+	if b.StreakMultiplier != 1 {
+		text += fmt.Sprintf("   🔥 Серия: ×%.2f\n", b.StreakMultiplier)
+	}
+	if b.TimeCoefficient != 1 {
+		tier := b.TimeTier
+		if tier == "" {
+			tier = "Время"
+		}
+		text += fmt.Sprintf("   ⏱ %s: ×%.2f\n", tier, b.TimeCoefficient)
+	}
+	return text + "\n"
+}
+
+// FormatPotentialCoefficient formats the time bonus a prediction made now would earn.
+// Returns an empty string when no bonus applies.
+func FormatPotentialCoefficient(result coefficient.CoefficientResult) string {
+	if result.Coefficient == 1 {
+		return ""
+	}
+	return fmt.Sprintf("\n⏱ %s: ×%.2f", result.Tier, result.Coefficient)
+}
+
+// FormatTotalPoints formats the user's total points in a contest
+func FormatTotalPoints(total float64) string {
+	return fmt.Sprintf("Всего: <b>%.2f</b> очк.", total)
 }
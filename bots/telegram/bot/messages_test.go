@@ -0,0 +1,86 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sports-prediction-contests/shared/coefficient"
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
+)
+
+// TestFormatScoreBreakdown tests that only applied multipliers are shown
+func TestFormatScoreBreakdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		breakdown *scoringpb.ScoreBreakdown
+		want      []string
+		notWant   []string
+	}{
+		{
+			name: "all multipliers",
+			breakdown: &scoringpb.ScoreBreakdown{
+				PredictionId: 7, BasePoints: 3, RuleMatched: "goal_difference",
+				StreakMultiplier: 1.25, TimeCoefficient: 1.1, TimeTier: "Ahead of Time", FinalPoints: 4.13,
+			},
+			want: []string{"#7", "4.13", "Разница мячей: 3.00", "×1.25", "Ahead of Time: ×1.10"},
+		},
+		{
+			name: "no multipliers",
+			breakdown: &scoringpb.ScoreBreakdown{
+				PredictionId: 8, RuleMatched: "none", StreakMultiplier: 1, TimeCoefficient: 1,
+			},
+			want:    []string{"#8", "Мимо: 0.00"},
+			notWant: []string{"Серия", "⏱"},
+		},
+		{
+			name: "unknown rule",
+			breakdown: &scoringpb.ScoreBreakdown{
+				PredictionId: 9, BasePoints: 1, RuleMatched: "survivor_alive", StreakMultiplier: 1, TimeCoefficient: 1,
+			},
+			want: []string{"survivor_alive: 1.00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatScoreBreakdown(tt.breakdown)
+			for _, s := range tt.want {
+				if !strings.Contains(got, s) {
+					t.Errorf("FormatScoreBreakdown() = %q, want it to contain %q", got, s)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(got, s) {
+					t.Errorf("FormatScoreBreakdown() = %q, want it not to contain %q", got, s)
+				}
+			}
+		})
+	}
+}
+
+// TestFormatPotentialCoefficient tests that only a real time bonus is shown
+func TestFormatPotentialCoefficient(t *testing.T) {
+	if got := FormatPotentialCoefficient(coefficient.CoefficientResult{Coefficient: 1, Tier: coefficient.StandardTier}); got != "" {
+		t.Errorf("FormatPotentialCoefficient() without bonus = %q, want empty", got)
+	}
+	if got := FormatPotentialCoefficient(coefficient.CoefficientResult{Coefficient: 3, Tier: "Season Opener"}); !strings.Contains(got, "Season Opener: ×3.00") {
+		t.Errorf("FormatPotentialCoefficient() = %q, want tier and multiplier", got)
+	}
+}
+
+// TestFormatDetailedLeaderboardEntryMovement tests rank arrows and points earned this round
+func TestFormatDetailedLeaderboardEntryMovement(t *testing.T) {
+	up := FormatDetailedLeaderboardEntry(4, "alice", 42, 2, 7, 1, 0, 3, 0)
+	if !strings.Contains(up, "alice ▲2") || !strings.Contains(up, "42.0 pts (+7.0)") {
+		t.Errorf("FormatDetailedLeaderboardEntry() = %q, want upward arrow and round points", up)
+	}
+
+	down := FormatDetailedLeaderboardEntry(5, "bob", 30, -1, 0, 0, 0, 0, 0)
+	if !strings.Contains(down, "bob ▼1") || strings.Contains(down, "(+") {
+		t.Errorf("FormatDetailedLeaderboardEntry() = %q, want downward arrow without round points", down)
+	}
+
+	if got := FormatRankChange(0); got != "" {
+		t.Errorf("FormatRankChange(0) = %q, want empty", got)
+	}
+}
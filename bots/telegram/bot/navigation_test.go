@@ -2,6 +2,8 @@ package bot
 
 import (
 	"testing"
+
+	"github.com/sports-prediction-contests/shared/scoring"
 )
 
 // TestCalculatePagination tests pagination calculation with various inputs
@@ -94,3 +96,51 @@ func TestPaginationButtonsDivisionByZero(t *testing.T) {
 		})
 	}
 }
+
+// TestScorePredictionKeyboardProfiles tests that score keyboards follow the contest's sport profile
+func TestScorePredictionKeyboardProfiles(t *testing.T) {
+	tests := []struct {
+		sport        string
+		wantRows     int
+		wantFirst    string
+		wantAnyOther bool
+	}{
+		{scoring.SportFootball, 7, "p_42_0_0", true},
+		{scoring.SportHockey, 7, "p_42_1_1", true},
+		{scoring.SportTennis, 3, "p_42_2_0", false},
+		{scoring.SportBasketball, 6, "pm_42_home_1-5", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sport, func(t *testing.T) {
+			profile, ok := scoring.LookupSportProfile(tt.sport)
+			if !ok {
+				t.Fatalf("no profile for %s", tt.sport)
+			}
+
+			keyboard := ScorePredictionKeyboard(42, profile)
+			rows := keyboard.InlineKeyboard
+			if len(rows) != tt.wantRows {
+				t.Fatalf("got %d rows, want %d", len(rows), tt.wantRows)
+			}
+			if got := *rows[0][0].CallbackData; got != tt.wantFirst {
+				t.Errorf("first button callback = %q, want %q", got, tt.wantFirst)
+			}
+
+			hasAnyOther := false
+			for _, row := range rows {
+				for _, button := range row {
+					if *button.CallbackData == "pany_42" {
+						hasAnyOther = true
+					}
+					if len(*button.CallbackData) > 64 {
+						t.Errorf("callback data %q exceeds Telegram limit", *button.CallbackData)
+					}
+				}
+			}
+			if hasAnyOther != tt.wantAnyOther {
+				t.Errorf("any other button = %v, want %v", hasAnyOther, tt.wantAnyOther)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	predictionpb "github.com/sports-prediction-contests/shared/proto/prediction"
+	"github.com/sports-prediction-contests/shared/scoring"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -57,13 +58,17 @@ func (h *Handlers) handleMatchList(chatID int64, msgID int, contestID uint32, pa
 	if err == nil && predResp != nil && predResp.Predictions != nil {
 		for _, pred := range predResp.Predictions {
 			var predData struct {
-				Type      string `json:"type"`
-				HomeScore *int   `json:"home_score"`
-				AwayScore *int   `json:"away_score"`
+				Type      string  `json:"type"`
+				HomeScore *int    `json:"home_score"`
+				AwayScore *int    `json:"away_score"`
+				Winner    *string `json:"winner"`
+				Margin    string  `json:"margin"`
 			}
 			if json.Unmarshal([]byte(pred.PredictionData), &predData) == nil {
 				if predData.Type == "any_other" {
 					userPredictions[pred.EventId] = "other"
+				} else if predData.Margin != "" && predData.Winner != nil {
+					userPredictions[pred.EventId] = formatMarginPick(*predData.Winner, predData.Margin)
 				} else if predData.HomeScore != nil && predData.AwayScore != nil {
 					userPredictions[pred.EventId] = fmt.Sprintf("%d:%d", *predData.HomeScore, *predData.AwayScore)
 				}
@@ -168,13 +173,17 @@ func (h *Handlers) handleMatchDetail(chatID int64, msgID int, matchID uint32) {
 				if pred.EventId == matchID {
 					// Parse prediction data to extract score
 					var predData struct {
-						Type      string `json:"type"`
-						HomeScore *int   `json:"home_score"`
-						AwayScore *int   `json:"away_score"`
+						Type      string  `json:"type"`
+						HomeScore *int    `json:"home_score"`
+						AwayScore *int    `json:"away_score"`
+						Winner    *string `json:"winner"`
+						Margin    string  `json:"margin"`
 					}
 					if json.Unmarshal([]byte(pred.PredictionData), &predData) == nil {
 						if predData.Type == "any_other" {
 							existingPrediction = "\n\n✅ <b>Твой прогноз:</b> Any other"
+						} else if predData.Margin != "" && predData.Winner != nil {
+							existingPrediction = "\n\n✅ <b>Твой прогноз:</b> " + formatMarginPick(*predData.Winner, predData.Margin)
 						} else if predData.HomeScore != nil && predData.AwayScore != nil {
 							existingPrediction = fmt.Sprintf("\n\n✅ <b>Твой прогноз:</b> %d : %d", *predData.HomeScore, *predData.AwayScore)
 						}
@@ -194,16 +203,24 @@ func (h *Handlers) handleMatchDetail(chatID int64, msgID int, matchID uint32) {
 		selectText = "Выбери новый счёт для изменения прогноза:"
 	}
 	
-	text := fmt.Sprintf("%s<b>%s vs %s</b>\n\n📅 %s%s\n\n%s",
+	// Buttons and the time bonus follow the contest's rules; fixed-point contests have no bonus
+	rules := scoring.ParseRulesOrDefault(h.getContestRules(contestID))
+	timeBonus := ""
+	if rules.AppliesMultipliers() {
+		timeBonus = FormatPotentialCoefficient(rules.TimeCoefficient.Calculate(time.Now(), eventTime))
+	}
+
+	text := fmt.Sprintf("%s<b>%s vs %s</b>\n\n📅 %s%s%s\n\n%s",
 		MsgMatchDetail,
 		event.HomeTeam,
 		event.AwayTeam,
 		eventTime.Format("Jan 02, 15:04"),
+		timeBonus,
 		existingPrediction,
 		selectText,
 	)
 
-	h.editMessage(chatID, msgID, text, ScorePredictionKeyboard(matchID))
+	h.editMessage(chatID, msgID, text, ScorePredictionKeyboard(matchID, rules.Profile()))
 }
 
 // handlePredictionSubmit processes score prediction submission
@@ -388,6 +405,82 @@ func (h *Handlers) handleAnyOtherScore(chatID int64, msgID int, matchID uint32)
 	h.editMessage(chatID, msgID, successMsg, keyboard)
 }
 
+// handleMarginPrediction handles a pick of the winner and their winning margin band
+func (h *Handlers) handleMarginPrediction(chatID int64, msgID int, matchID uint32, winner, margin string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session := h.getSession(chatID)
+	if session == nil {
+		h.editMessage(chatID, msgID, MsgNotLinked, BackToMainKeyboard())
+		return
+	}
+
+	// Get match details to verify it hasn't started
+	eventResp, err := h.clients.Prediction.GetEvent(ctx, &predictionpb.GetEventRequest{
+		Id: matchID,
+	})
+
+	if err != nil || eventResp == nil || eventResp.Event == nil {
+		log.Printf("[ERROR] Failed to get event %d for validation: %v", matchID, err)
+		h.editMessage(chatID, msgID, MsgMatchNotFound, BackToMainKeyboard())
+		return
+	}
+
+	if time.Now().After(eventResp.Event.EventDate.AsTime()) {
+		h.editMessage(chatID, msgID, MsgMatchStarted, BackToMainKeyboard())
+		return
+	}
+
+	// Create margin prediction data; the band is checked against the sport by prediction-service
+	predictionJSON, err := json.Marshal(map[string]interface{}{
+		"type":   "winner",
+		"winner": winner,
+		"margin": margin,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Failed to marshal prediction data: %v", err)
+		h.editMessage(chatID, msgID, "Failed to save prediction.", BackToMainKeyboard())
+		return
+	}
+
+	// Submit prediction - require contest to be selected
+	contestID := session.CurrentContest
+	if contestID == 0 {
+		h.editMessage(chatID, msgID, MsgSelectContestFirst, BackToMainKeyboard())
+		return
+	}
+
+	// Add user_id to gRPC metadata for bot authentication
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", strconv.FormatUint(uint64(session.UserID), 10))
+
+	resp, err := h.clients.Prediction.SubmitPrediction(ctx, &predictionpb.SubmitPredictionRequest{
+		ContestId:      contestID,
+		EventId:        matchID,
+		PredictionData: string(predictionJSON),
+	})
+
+	if err != nil || resp == nil || resp.Response == nil || !resp.Response.Success {
+		errMsg := "Failed to save prediction"
+		if resp != nil && resp.Response != nil {
+			errMsg = resp.Response.Message
+		}
+		log.Printf("[ERROR] Failed to submit prediction (contest=%d, event=%d, user=%d): %v", contestID, matchID, session.UserID, err)
+		h.editMessage(chatID, msgID, fmt.Sprintf("❌ %s", errMsg), BackToMainKeyboard())
+		return
+	}
+
+	log.Printf("[INFO] Prediction submitted (user=%d, contest=%d, match=%d, margin=%s %s)", session.UserID, contestID, matchID, winner, margin)
+	successMsg := fmt.Sprintf("%s\n\nPrediction: %s", MsgPredictionSuccess, formatMarginPick(winner, margin))
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			tgbotapi.NewInlineKeyboardButtonData("« Back to Matches", fmt.Sprintf("matches_%d_1", contestID)),
+		),
+	)
+	h.editMessage(chatID, msgID, successMsg, keyboard)
+}
+
 // findNextUnpredictedMatch finds next match without prediction
 func (h *Handlers) findNextUnpredictedMatch(ctx context.Context, contestID, userID uint32) (*predictionpb.Event, error) {
 	// Get events for this contest
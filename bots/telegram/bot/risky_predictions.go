@@ -2,7 +2,6 @@ package bot
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -11,14 +10,11 @@ import (
 
 	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
 	predictionpb "github.com/sports-prediction-contests/shared/proto/prediction"
+	"github.com/sports-prediction-contests/shared/scoring"
 )
 
-// NOTE: Types below are duplicated from backend/shared/scoring/rules.go
-// This is intentional because the bot is a separate Go module and importing
-// the shared package would require complex module dependencies.
-// Keep these types in sync with the backend definitions.
-
-// RiskyEvent represents a risky event for prediction
+// RiskyEvent represents a risky event for prediction as shown in the bot.
+// Names carry the event icon, so this is kept separate from scoring.RiskyEvent.
 type RiskyEvent struct {
 	Slug   string  `json:"slug"`
 	Name   string  `json:"name"`
@@ -26,18 +22,6 @@ type RiskyEvent struct {
 	Points float64 `json:"points"`
 }
 
-// RiskyScoringRules represents risky contest rules
-type RiskyScoringRules struct {
-	MaxSelections int          `json:"max_selections"`
-	Events        []RiskyEvent `json:"events,omitempty"`
-}
-
-// ContestRules represents contest scoring rules
-type ContestRules struct {
-	Type   string             `json:"type"`
-	Risky  *RiskyScoringRules `json:"risky,omitempty"`
-}
-
 // Fallback risky events (used if API fails)
 var fallbackRiskyEvents = []RiskyEvent{
 	{Slug: "penalty", Name: "⚽ Пенальти", NameEn: "Penalty", Points: 3},
@@ -182,43 +166,33 @@ func (h *Handlers) fetchMatchRiskyEvents(eventID, contestID uint32) ([]RiskyEven
 	return events, maxSelections, nil
 }
 
-// parseContestRules parses contest rules JSON
-func parseContestRules(rulesJSON string) *ContestRules {
-	if rulesJSON == "" {
-		return &ContestRules{Type: "standard"}
-	}
-	var rules ContestRules
-	if err := json.Unmarshal([]byte(rulesJSON), &rules); err != nil {
-		return &ContestRules{Type: "standard"}
-	}
-	if rules.Type == "" {
-		rules.Type = "standard"
-	}
-	return &rules
-}
-
 // isRiskyContest checks if contest has risky type
 func isRiskyContest(rulesJSON string) bool {
-	rules := parseContestRules(rulesJSON)
-	return rules.Type == "risky"
+	return scoring.ParseRulesOrDefault(rulesJSON).Type == scoring.ContestTypeRisky
 }
 
 // getRiskyEvents returns risky events for a contest (fallback when API not available)
 func getRiskyEvents(rulesJSON string) []RiskyEvent {
-	rules := parseContestRules(rulesJSON)
-	if rules.Risky != nil && len(rules.Risky.Events) > 0 {
-		return rules.Risky.Events
+	rules := scoring.ParseRulesOrDefault(rulesJSON)
+	if rules.Risky == nil || len(rules.Risky.Events) == 0 {
+		return fallbackRiskyEvents
 	}
-	return fallbackRiskyEvents
+
+	events := make([]RiskyEvent, 0, len(rules.Risky.Events))
+	for _, e := range rules.Risky.Events {
+		events = append(events, RiskyEvent{
+			Slug:   e.Slug,
+			Name:   e.Name,
+			NameEn: e.NameEn,
+			Points: e.Points,
+		})
+	}
+	return events
 }
 
 // getMaxSelections returns max selections for risky contest
 func getMaxSelections(rulesJSON string) int {
-	rules := parseContestRules(rulesJSON)
-	if rules.Risky != nil && rules.Risky.MaxSelections > 0 {
-		return rules.Risky.MaxSelections
-	}
-	return 5
+	return scoring.ParseRulesOrDefault(rulesJSON).MaxRiskySelections()
 }
 
 // RiskyEventsKeyboard creates keyboard for selecting risky events
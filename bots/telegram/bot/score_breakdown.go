@@ -0,0 +1,63 @@
+package bot
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	scoringpb "github.com/sports-prediction-contests/shared/proto/scoring"
+	"google.golang.org/grpc/metadata"
+)
+
+// maxScoreBreakdowns limits how many recent scores are explained in one message
+const maxScoreBreakdowns = 5
+
+// handleMyScores explains the user's most recent scores in a contest
+func (h *Handlers) handleMyScores(chatID int64, msgID int, contestID uint32) {
+	session := h.getSession(chatID)
+	if session == nil || session.UserID == 0 {
+		h.editMessage(chatID, msgID, MsgNotLinked, BackToMainKeyboard())
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Add user_id to gRPC metadata for bot authentication
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", strconv.FormatUint(uint64(session.UserID), 10))
+
+	resp, err := h.clients.Scoring.GetUserScores(ctx, &scoringpb.GetUserScoresRequest{
+		UserId:    session.UserID,
+		ContestId: contestID,
+	})
+	if err != nil || resp == nil || resp.Response == nil || !resp.Response.Success {
+		log.Printf("[ERROR] Failed to get user scores: %v", err)
+		h.editMessage(chatID, msgID, MsgServiceError, ContestDetailKeyboard(contestID))
+		return
+	}
+
+	if len(resp.Scores) == 0 {
+		h.editMessage(chatID, msgID, MsgMyScores+MsgNoScores, ContestDetailKeyboard(contestID))
+		return
+	}
+
+	text := MsgMyScores
+	// Scores come back newest first
+	for i, score := range resp.Scores {
+		if i >= maxScoreBreakdowns {
+			break
+		}
+		breakdownResp, err := h.clients.Scoring.GetScoreBreakdown(ctx, &scoringpb.GetScoreBreakdownRequest{
+			PredictionId: score.PredictionId,
+		})
+		if err != nil || breakdownResp == nil || breakdownResp.Breakdown == nil {
+			log.Printf("[WARN] Failed to get score breakdown for prediction %d: %v", score.PredictionId, err)
+			continue
+		}
+		text += FormatScoreBreakdown(breakdownResp.Breakdown)
+	}
+	text += FormatTotalPoints(resp.TotalPoints)
+
+	h.editMessage(chatID, msgID, text, ContestDetailKeyboard(contestID))
+}
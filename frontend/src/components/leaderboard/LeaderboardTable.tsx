@@ -1,6 +1,6 @@
 import React, { useState, useEffect } from 'react'
 import { Table, Card, Typography, Tag, Avatar, Button, Tooltip, Space, Spin, Alert, Statistic, Row, Col } from 'antd'
-import { ReloadOutlined, TrophyOutlined } from '@ant-design/icons'
+import { ReloadOutlined, TrophyOutlined, ArrowUpOutlined, ArrowDownOutlined } from '@ant-design/icons'
 import type { ColumnsType } from 'antd/es/table'
 import { useQuery } from '@tanstack/react-query'
 import scoringService from '../../services/scoring-service'
@@ -39,6 +39,17 @@ const getRankIcon = (rank: number) => {
   return null
 }
 
+const getRankChange = (change?: number) => {
+  if (!change) {
+    return null
+  }
+  return change > 0 ? (
+    <Text type="success"><ArrowUpOutlined /> {change}</Text>
+  ) : (
+    <Text type="danger"><ArrowDownOutlined /> {-change}</Text>
+  )
+}
+
 export const LeaderboardTable: React.FC<LeaderboardTableProps> = ({
   contestId,
   currentUserId,
@@ -62,6 +73,16 @@ export const LeaderboardTable: React.FC<LeaderboardTableProps> = ({
     refetchIntervalInBackground: true,
   })
 
+  // Refetch as soon as the standings change instead of waiting for the next poll
+  useEffect(() => {
+    if (!autoRefresh) {
+      return
+    }
+    return scoringService.watchLeaderboard(contestId, () => {
+      refetch()
+    })
+  }, [contestId, autoRefresh, refetch])
+
   // Update lastUpdated when data changes
   useEffect(() => {
     if (leaderboard) {
@@ -106,7 +127,7 @@ export const LeaderboardTable: React.FC<LeaderboardTableProps> = ({
       title: 'Rank',
       dataIndex: 'rank',
       key: 'rank',
-      width: 80,
+      width: 110,
       render: (rank: number, record) => {
         const isCurrentUser = currentUserId === record.userId
         return (
@@ -115,6 +136,7 @@ export const LeaderboardTable: React.FC<LeaderboardTableProps> = ({
             <Text strong={rank <= 3 || isCurrentUser} style={{ color: isCurrentUser ? '#1890ff' : undefined }}>
               #{rank}
             </Text>
+            {getRankChange(record.rankChange)}
           </Space>
         )
       },
@@ -145,10 +167,17 @@ export const LeaderboardTable: React.FC<LeaderboardTableProps> = ({
       dataIndex: 'totalPoints',
       key: 'totalPoints',
       width: 120,
-      render: (points: number) => (
-        <Text strong style={{ color: points > 0 ? '#52c41a' : undefined }}>
-          {points.toFixed(1)}
-        </Text>
+      render: (points: number, record) => (
+        <Space>
+          <Text strong style={{ color: points > 0 ? '#52c41a' : undefined }}>
+            {points.toFixed(1)}
+          </Text>
+          {!!record.roundPoints && (
+            <Tooltip title="Points this round">
+              <Text type="secondary">+{record.roundPoints.toFixed(1)}</Text>
+            </Tooltip>
+          )}
+        </Space>
       ),
     },
     {
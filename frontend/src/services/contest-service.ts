@@ -1,6 +1,7 @@
 import grpcClient from './grpc-client'
 import type {
   Contest,
+  ContestRulesVersion,
   Participant,
   CreateContestRequest,
   UpdateContestRequest,
@@ -52,6 +53,14 @@ class ContestService {
     return response.contest
   }
 
+  // Get the versions of a contest's rules, oldest first
+  async getRulesHistory(id: number): Promise<ContestRulesVersion[]> {
+    const response = await grpcClient.get<GetContestResponse>(
+      `${this.basePath}/${id}?include_rules_history=true`
+    )
+    return response.rulesHistory || []
+  }
+
   // Delete a contest
   async deleteContest(id: number): Promise<void> {
     await grpcClient.delete<DeleteContestResponse>(`${this.basePath}/${id}`)
@@ -8,6 +8,9 @@ import type {
   GetLeaderboardRequest,
   GetUserRankRequest,
   GetUserStreakRequest,
+  GetLeaderboardAroundUserRequest,
+  GetGlobalLeaderboardRequest,
+  GetUserPointsTimelineRequest,
   UpdateLeaderboardRequest,
   CalculateScoreRequest,
   ListScoresRequest,
@@ -20,9 +23,18 @@ import type {
   GetLeaderboardResponse,
   GetUserRankResponse,
   GetUserStreakResponse,
+  GetLeaderboardAroundUserResponse,
+  GetGlobalLeaderboardResponse,
+  GetUserPointsTimelineResponse,
+  PointsTimelinePoint,
+  Achievement,
+  ListAchievementsResponse,
+  GetUserAchievementsResponse,
+  SimulateRulesResponse,
   UpdateLeaderboardResponse,
   CalculateScoreResponse,
   PaginationRequest,
+  LeaderboardUpdate,
 } from '../types/scoring.types'
 
 class ScoringService {
@@ -124,6 +136,9 @@ class ScoringService {
     if (request.limit) {
       params.append('limit', request.limit.toString())
     }
+    if (request.asOf) {
+      params.append('as_of', request.asOf)
+    }
 
     const queryString = params.toString()
     const url = queryString 
@@ -134,6 +149,34 @@ class ScoringService {
     return response.leaderboard
   }
 
+  // Watch a contest's leaderboard changes over Server-Sent Events. Returns a function that stops watching.
+  watchLeaderboard(contestId: number, onUpdate: (update: LeaderboardUpdate) => void): () => void {
+    const source = new EventSource(
+      `${grpcClient.getBaseUrl()}${this.leaderboardPath}/${contestId}/leaderboard/watch`
+    )
+    source.addEventListener('leaderboard', (event) => {
+      onUpdate(JSON.parse((event as MessageEvent).data) as LeaderboardUpdate)
+    })
+    return () => source.close()
+  }
+
+  // Get the cross-contest leaderboard, optionally for a sport, league, season or month
+  async getGlobalLeaderboard(request: GetGlobalLeaderboardRequest = {}): Promise<GetGlobalLeaderboardResponse> {
+    const params = new URLSearchParams()
+    if (request.sportType) params.append('sport_type', request.sportType)
+    if (request.leagueId) params.append('league_id', request.leagueId.toString())
+    if (request.season) params.append('season', request.season)
+    if (request.month) params.append('month', request.month)
+    if (request.normalization) params.append('normalization', request.normalization)
+    if (request.minPredictions) params.append('min_predictions', request.minPredictions.toString())
+    if (request.limit) params.append('limit', request.limit.toString())
+
+    const queryString = params.toString()
+    return grpcClient.get<GetGlobalLeaderboardResponse>(
+      queryString ? `/v1/leaderboards/global?${queryString}` : '/v1/leaderboards/global'
+    )
+  }
+
   // Get user rank in a contest
   async getUserRank(request: GetUserRankRequest): Promise<{
     rank: number
@@ -149,6 +192,14 @@ class ScoringService {
     }
   }
 
+  // Get the entries above and below a user with their percentile
+  async getLeaderboardAroundUser(request: GetLeaderboardAroundUserRequest): Promise<GetLeaderboardAroundUserResponse> {
+    const url = request.neighbors
+      ? `${this.leaderboardPath}/${request.contestId}/users/${request.userId}/leaderboard?neighbors=${request.neighbors}`
+      : `${this.leaderboardPath}/${request.contestId}/users/${request.userId}/leaderboard`
+    return grpcClient.get<GetLeaderboardAroundUserResponse>(url)
+  }
+
   // Get user streak in a contest
   async getUserStreak(request: GetUserStreakRequest): Promise<{
     currentStreak: number
@@ -166,6 +217,30 @@ class ScoringService {
     }
   }
 
+  // Get a user's cumulative points in a contest, oldest first
+  async getUserPointsTimeline(request: GetUserPointsTimelineRequest): Promise<PointsTimelinePoint[]> {
+    const response = await grpcClient.get<GetUserPointsTimelineResponse>(
+      `${this.leaderboardPath}/${request.contestId}/users/${request.userId}/points/timeline`
+    )
+    return response.points ?? []
+  }
+
+  // Get the badge catalog
+  async listAchievements(): Promise<Achievement[]> {
+    const response = await grpcClient.get<ListAchievementsResponse>('/v1/achievements')
+    return response.achievements ?? []
+  }
+
+  // Get a user's progress towards every badge
+  async getUserAchievements(userId: number): Promise<GetUserAchievementsResponse> {
+    return grpcClient.get<GetUserAchievementsResponse>(`/v1/users/${userId}/achievements`)
+  }
+
+  // Score a contest's settled predictions with alternative rules, without saving anything
+  async simulateRules(contestId: number, rules: string): Promise<SimulateRulesResponse> {
+    return grpcClient.post<SimulateRulesResponse>(`/v1/contests/${contestId}/rules/simulate`, { rules })
+  }
+
   // Update leaderboard for a contest
   async updateLeaderboard(request: UpdateLeaderboardRequest): Promise<Leaderboard> {
     const response = await grpcClient.post<UpdateLeaderboardResponse>(
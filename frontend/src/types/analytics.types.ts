@@ -40,6 +40,14 @@ export interface PlatformStats {
   totalPredictions: number
 }
 
+export interface SkillRatingChange {
+  eventId: number
+  previousRating: number
+  rating: number
+  opponents: number // Predictions compared with on the event
+  ratedAt: string
+}
+
 export interface UserAnalytics {
   userId: number
   totalPredictions: number
@@ -52,6 +60,9 @@ export interface UserAnalytics {
   trends: AccuracyTrend[]
   platformComparison: PlatformStats | null
   timeRange: string
+  skillRating: number
+  ratedEvents: number
+  ratingHistory: SkillRatingChange[]
 }
 
 export type TimeRange = '7d' | '30d' | '90d' | 'all'
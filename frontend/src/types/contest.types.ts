@@ -12,6 +12,7 @@ export interface Contest {
   description: string
   sportType: string
   rules: string // JSON string for flexible rule configuration
+  rulesBasis?: RulesBasis
   predictionSchema?: PredictionSchema
   status: 'draft' | 'active' | 'completed' | 'cancelled'
   startDate: string // ISO string
@@ -23,6 +24,17 @@ export interface Contest {
   updatedAt: string // ISO string
 }
 
+// Which rules version scores a prediction: the one in force at submission or at kickoff
+export type RulesBasis = 'submission' | 'kickoff'
+
+export interface ContestRulesVersion {
+  version: number
+  rules: string
+  effectiveFrom: string // ISO string
+  createdBy: number
+  createdAt: string // ISO string
+}
+
 export interface Participant {
   id: number
   contestId: number
@@ -41,6 +53,7 @@ export interface CreateContestRequest {
   startDate: string // ISO string
   endDate: string // ISO string
   maxParticipants: number
+  rulesBasis?: RulesBasis
 }
 
 export interface UpdateContestRequest {
@@ -53,6 +66,8 @@ export interface UpdateContestRequest {
   endDate: string // ISO string
   maxParticipants: number
   status: 'draft' | 'active' | 'completed' | 'cancelled'
+  rulesBasis?: RulesBasis
+  rulesEffectiveFrom?: string // ISO string, when changed rules take effect
 }
 
 export interface ListContestsRequest {
@@ -95,6 +110,7 @@ export interface UpdateContestResponse {
 export interface GetContestResponse {
   response: ApiResponse
   contest: Contest
+  rulesHistory?: ContestRulesVersion[]
 }
 
 export interface DeleteContestResponse {
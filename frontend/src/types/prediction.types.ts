@@ -24,10 +24,21 @@ export interface Event {
   eventDate: string
   status: 'scheduled' | 'live' | 'completed' | 'cancelled'
   resultData: string
+  rosters: string // JSON EventRosters, empty until the players are known
   createdAt: string
   updatedAt: string
 }
 
+export interface RosterPlayer {
+  id: string
+  name: string
+}
+
+export interface EventRosters {
+  home: RosterPlayer[]
+  away: RosterPlayer[]
+}
+
 // Request types
 export interface SubmitPredictionRequest {
   contestId: number
@@ -12,6 +12,7 @@ export interface PropType {
   minValue: number | null
   maxValue: number | null
   pointsCorrect: number
+  evaluation: string // JSON settlement definition, empty for built-in prop types
   isActive: boolean
 }
 
@@ -20,7 +21,7 @@ export interface PropPrediction {
   propSlug: string
   line?: number
   selection: string
-  playerId?: string
+  playerId?: string // required by player props, must be on the event's rosters
   pointsValue: number
 }
 
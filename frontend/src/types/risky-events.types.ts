@@ -25,6 +25,7 @@ export interface MatchRiskyEvent {
   icon?: string
   isEnabled: boolean
   outcome?: boolean | null  // null=pending, true=happened, false=didn't
+  outcomeSource?: 'auto' | 'manual' | ''  // auto when derived from the match result
 }
 
 // Contest Risky Event (selected for contest with custom points)
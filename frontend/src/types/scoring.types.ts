@@ -21,6 +21,23 @@ export interface LeaderboardEntry {
   currentStreak: number
   maxStreak: number
   multiplier: number
+  rankChange?: number // places climbed since the last round snapshot, negative when dropped
+  roundPoints?: number // points earned since the last round snapshot
+}
+
+// A change to one participant's standing, pushed while watching a leaderboard
+export interface LeaderboardDelta {
+  userId: number
+  rank?: number
+  previousRank?: number // Absent for participants who were not ranked yet
+  totalPoints?: number
+  pointsDelta?: number
+}
+
+export interface LeaderboardUpdate {
+  contestId: number
+  changes: LeaderboardDelta[]
+  updatedAt: string // ISO string
 }
 
 export interface Leaderboard {
@@ -29,6 +46,18 @@ export interface Leaderboard {
   updatedAt: string // ISO string
 }
 
+// A user's standing across contests
+export interface GlobalLeaderboardEntry {
+  rank: number
+  userId: number
+  score: number // Points after normalization
+  totalPoints: number
+  predictions: number
+  contests: number
+}
+
+export type LeaderboardNormalization = 'raw' | 'per_prediction' | 'relative' | 'rating'
+
 // Request types
 export interface CreateScoreRequest {
   userId: number
@@ -64,6 +93,28 @@ export interface GetUserScoresRequest {
 export interface GetLeaderboardRequest {
   contestId: number
   limit?: number // Number of top entries to return
+  asOf?: string // ISO string; rebuilds the standings as they were at this moment
+}
+
+export interface GetLeaderboardAroundUserRequest {
+  contestId: number
+  userId: number
+  neighbors?: number // Entries shown above and below the user
+}
+
+export interface GetGlobalLeaderboardRequest {
+  sportType?: string
+  leagueId?: number
+  season?: string // League season, e.g. "2025-26"
+  month?: string // Calendar month, e.g. "2026-05"
+  normalization?: LeaderboardNormalization
+  minPredictions?: number
+  limit?: number
+}
+
+export interface GetUserPointsTimelineRequest {
+  contestId: number
+  userId: number
 }
 
 export interface GetUserRankRequest {
@@ -127,6 +178,8 @@ export interface GetUserRankResponse {
   response: ApiResponse
   rank: number
   totalPoints: number
+  rankChange?: number
+  roundPoints?: number
 }
 
 export interface GetUserStreakResponse {
@@ -136,6 +189,79 @@ export interface GetUserStreakResponse {
   multiplier: number
 }
 
+export interface GetLeaderboardAroundUserResponse {
+  response: ApiResponse
+  entries: LeaderboardEntry[]
+  rank: number
+  totalParticipants: number
+  percentile: number // Share of the other participants ranked below the user, 0-100
+}
+
+export interface GetGlobalLeaderboardResponse {
+  response: ApiResponse
+  entries: GlobalLeaderboardEntry[]
+  totalParticipants: number
+}
+
+export interface PointsTimelinePoint {
+  scoredAt: string // ISO string
+  predictionId: number
+  points: number
+  totalPoints: number // Cumulative points after this prediction was scored
+}
+
+export interface GetUserPointsTimelineResponse {
+  response: ApiResponse
+  points: PointsTimelinePoint[]
+}
+
+// A badge definition from the catalog
+export interface Achievement {
+  code: string
+  name: string
+  description: string
+  threshold: number // Progress needed to unlock
+}
+
+// A user's progress towards one badge
+export interface UserAchievement {
+  achievement: Achievement
+  unlocked: boolean
+  unlockedAt?: string // ISO string
+  progress: number // Capped at the threshold
+}
+
+export interface ListAchievementsResponse {
+  response: ApiResponse
+  achievements: Achievement[]
+}
+
+export interface GetUserAchievementsResponse {
+  response: ApiResponse
+  achievements: UserAchievement[]
+  unlockedCount: number
+}
+
+// What-if comparison of a participant's standing under alternative rules
+export interface RulesSimulationEntry {
+  userId: number
+  currentPoints: number
+  simulatedPoints: number
+  pointsDelta: number
+  currentRank: number
+  simulatedRank: number
+  rankDelta: number // Places climbed under the alternative rules, negative when dropped
+}
+
+export interface SimulateRulesResponse {
+  response: ApiResponse
+  current: Leaderboard
+  simulated: Leaderboard
+  entries: RulesSimulationEntry[] // In simulated rank order
+  simulatedPredictions: number
+  keptPredictions: number // Manual or orphaned scores carried over unchanged
+}
+
 export interface UpdateLeaderboardResponse {
   response: ApiResponse
   leaderboard: Leaderboard
@@ -62,18 +62,23 @@ CREATE TABLE IF NOT EXISTS scores (
     contest_id INTEGER NOT NULL,
     prediction_id INTEGER NOT NULL,
     points DECIMAL(10,2) NOT NULL DEFAULT 0,
+    base_points DECIMAL(10,2) NOT NULL DEFAULT 0,
+    streak_multiplier DECIMAL(4,2) NOT NULL DEFAULT 1.0,
     time_coefficient DECIMAL(4,2) NOT NULL DEFAULT 1.0,
+    time_tier VARCHAR(50),
+    rule_matched VARCHAR(50),
+    rules_version VARCHAR(64),
+    details TEXT,
     scored_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
-    UNIQUE(user_id, contest_id, prediction_id)
+    UNIQUE(prediction_id)
 );
 
 -- Create indexes for scores table
 CREATE INDEX IF NOT EXISTS idx_scores_contest_id ON scores(contest_id);
 CREATE INDEX IF NOT EXISTS idx_scores_user_id ON scores(user_id);
-CREATE INDEX IF NOT EXISTS idx_scores_prediction_id ON scores(prediction_id);
 CREATE INDEX IF NOT EXISTS idx_scores_deleted_at ON scores(deleted_at);
 
 -- Create leaderboards table for ranking
@@ -103,6 +108,7 @@ CREATE TABLE IF NOT EXISTS user_streaks (
     max_streak INTEGER NOT NULL DEFAULT 0,
     last_prediction_id INTEGER,
     last_prediction_correct BOOLEAN,
+    freezes_used INTEGER NOT NULL DEFAULT 0,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP NULL,
@@ -112,6 +118,111 @@ CREATE TABLE IF NOT EXISTS user_streaks (
 CREATE INDEX IF NOT EXISTS idx_user_streaks_contest_user ON user_streaks(contest_id, user_id);
 CREATE INDEX IF NOT EXISTS idx_user_streaks_deleted_at ON user_streaks(deleted_at);
 
+-- Create score_audits table for result corrections
+CREATE TABLE IF NOT EXISTS score_audits (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL,
+    contest_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    previous_points DECIMAL(10,2) NOT NULL DEFAULT 0,
+    new_points DECIMAL(10,2) NOT NULL DEFAULT 0,
+    reason TEXT,
+    previous_result TEXT,
+    new_result TEXT,
+    corrected_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_score_audits_event ON score_audits(event_id);
+CREATE INDEX IF NOT EXISTS idx_score_audits_contest_user ON score_audits(contest_id, user_id);
+
+-- Create totalizator pool tables for parimutuel draws
+CREATE TABLE IF NOT EXISTS pool_draws (
+    id SERIAL PRIMARY KEY,
+    contest_id INTEGER NOT NULL,
+    series VARCHAR(100) NOT NULL,
+    pool_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    jackpot_in DECIMAL(12,2) NOT NULL DEFAULT 0,
+    rollover_out DECIMAL(12,2) NOT NULL DEFAULT 0,
+    settled_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pool_draw_contest ON pool_draws(contest_id);
+CREATE INDEX IF NOT EXISTS idx_pool_draw_series ON pool_draws(series);
+
+CREATE TABLE IF NOT EXISTS pool_payouts (
+    id SERIAL PRIMARY KEY,
+    contest_id INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    correct INTEGER NOT NULL DEFAULT 0,
+    amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pool_payout_contest_user ON pool_payouts(contest_id, user_id);
+
+CREATE TABLE IF NOT EXISTS pool_jackpots (
+    series VARCHAR(100) PRIMARY KEY,
+    amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create leaderboard round snapshot tables
+CREATE TABLE IF NOT EXISTS leaderboard_rounds (
+    id SERIAL PRIMARY KEY,
+    contest_id INTEGER NOT NULL,
+    round INTEGER NOT NULL,
+    label VARCHAR(100) NOT NULL,
+    taken_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_round ON leaderboard_rounds(contest_id, round);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_round_label ON leaderboard_rounds(contest_id, label);
+
+CREATE TABLE IF NOT EXISTS leaderboard_snapshots (
+    id SERIAL PRIMARY KEY,
+    contest_id INTEGER NOT NULL,
+    round INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    rank INTEGER NOT NULL DEFAULT 0,
+    total_points DECIMAL(10,2) NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_leaderboard_snapshot_user ON leaderboard_snapshots(contest_id, round, user_id);
+
+-- Create skill ratings tables
+CREATE TABLE IF NOT EXISTS skill_ratings (
+    user_id INTEGER PRIMARY KEY,
+    rating DECIMAL(10,2) NOT NULL DEFAULT 1500,
+    events INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS skill_rating_changes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    event_id INTEGER NOT NULL,
+    previous_rating DECIMAL(10,2) NOT NULL,
+    rating DECIMAL(10,2) NOT NULL,
+    opponents INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skill_rating_change_event ON skill_rating_changes(user_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_skill_rating_change_user ON skill_rating_changes(user_id);
+
+-- Create user achievements table
+CREATE TABLE IF NOT EXISTS user_achievements (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    code VARCHAR(50) NOT NULL,
+    unlocked_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_achievement ON user_achievements(user_id, code);
+
 -- Create sports table
 CREATE TABLE IF NOT EXISTS sports (
     id SERIAL PRIMARY KEY,
@@ -295,6 +406,21 @@ CREATE INDEX IF NOT EXISTS idx_user_team_contest_entries_contest_id ON user_team
 CREATE INDEX IF NOT EXISTS idx_user_team_contest_entries_rank ON user_team_contest_entries(contest_id, rank);
 CREATE INDEX IF NOT EXISTS idx_user_team_contest_entries_deleted_at ON user_team_contest_entries(deleted_at);
 
+-- Create contest_rules_versions table (immutable history of contest rules)
+CREATE TABLE IF NOT EXISTS contest_rules_versions (
+    id SERIAL PRIMARY KEY,
+    contest_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,
+    rules TEXT,
+    effective_from TIMESTAMP NOT NULL,
+    created_by INTEGER,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE(contest_id, version)
+);
+
+-- Predictions are scored with the rules version in force at 'submission' or at 'kickoff'
+ALTER TABLE IF EXISTS contests ADD COLUMN IF NOT EXISTS rules_basis VARCHAR(20) DEFAULT 'submission';
+
 
 -- Create prop_types table for props predictions
 CREATE TABLE IF NOT EXISTS prop_types (
@@ -309,6 +435,7 @@ CREATE TABLE IF NOT EXISTS prop_types (
     min_value DECIMAL(10,2),
     max_value DECIMAL(10,2),
     points_correct DECIMAL(10,2) NOT NULL DEFAULT 2,
+    evaluation TEXT,
     is_active BOOLEAN DEFAULT true,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
@@ -316,19 +443,34 @@ CREATE TABLE IF NOT EXISTS prop_types (
     UNIQUE(sport_type, slug)
 );
 
+-- Prop types created before evaluations were stored are settled by built-in definitions
+ALTER TABLE prop_types ADD COLUMN IF NOT EXISTS evaluation TEXT;
+
+-- Risky event outcomes derived from match results are marked 'auto', ones set by hand 'manual'
+ALTER TABLE IF EXISTS match_risky_events ADD COLUMN IF NOT EXISTS outcome_source VARCHAR(10);
+
 CREATE INDEX IF NOT EXISTS idx_prop_types_sport ON prop_types(sport_type);
 CREATE INDEX IF NOT EXISTS idx_prop_types_category ON prop_types(category);
 CREATE INDEX IF NOT EXISTS idx_prop_types_is_active ON prop_types(is_active);
 CREATE INDEX IF NOT EXISTS idx_prop_types_deleted_at ON prop_types(deleted_at);
 
 -- Insert default prop types for Soccer
-INSERT INTO prop_types (sport_type, name, slug, description, category, value_type, default_line, points_correct) VALUES
-('Soccer', 'Total Goals Over/Under', 'total-goals-ou', 'Predict if total goals will be over or under the line', 'match', 'over_under', 2.5, 2),
-('Soccer', 'Total Corners Over/Under', 'total-corners-ou', 'Predict if total corners will be over or under the line', 'match', 'over_under', 9.5, 2),
-('Soccer', 'Both Teams to Score', 'btts', 'Predict if both teams will score', 'match', 'yes_no', NULL, 2),
-('Soccer', 'First Team to Score', 'first-to-score', 'Predict which team scores first', 'match', 'team_select', NULL, 3),
-('Soccer', 'Player to Score Anytime', 'player-goal', 'Predict if a specific player will score', 'player', 'yes_no', NULL, 4),
-('Soccer', 'Total Cards Over/Under', 'total-cards-ou', 'Predict if total cards will be over or under the line', 'match', 'over_under', 3.5, 2)
+INSERT INTO prop_types (sport_type, name, slug, description, category, value_type, default_line, points_correct, evaluation) VALUES
+('Soccer', 'Total Goals Over/Under', 'total-goals-ou', 'Predict if total goals will be over or under the line', 'match', 'over_under', 2.5, 2, '{"stat": "total_goals", "comparator": "over_under"}'),
+('Soccer', 'Total Corners Over/Under', 'total-corners-ou', 'Predict if total corners will be over or under the line', 'match', 'over_under', 9.5, 2, '{"stat": "corners", "comparator": "over_under"}'),
+('Soccer', 'Both Teams to Score', 'btts', 'Predict if both teams will score', 'match', 'yes_no', NULL, 2, '{"stat": "both_teams_scored", "comparator": "yes_no"}'),
+('Soccer', 'First Team to Score', 'first-to-score', 'Predict which team scores first', 'match', 'team_select', NULL, 3, '{"stat": "first_to_score", "comparator": "equals"}'),
+('Soccer', 'Player to Score Anytime', 'player-goal', 'Predict if a specific player will score', 'player', 'yes_no', NULL, 4, '{"stat": "goals", "comparator": "yes_no", "scope": "player"}'),
+('Soccer', 'Total Cards Over/Under', 'total-cards-ou', 'Predict if total cards will be over or under the line', 'match', 'over_under', 3.5, 2, '{"stat": "cards", "comparator": "over_under"}'),
+('Soccer', 'Player Shots Over/Under', 'player-shots-ou', 'Predict if a specific player will take over or under the line of shots', 'player', 'over_under', 2.5, 3, '{"stat": "shots", "comparator": "over_under", "scope": "player"}'),
+('Soccer', 'First Goalscorer', 'first-goalscorer', 'Predict which player scores the first goal', 'player', 'player_select', NULL, 6, '{"stat": "first_goalscorer", "comparator": "is_player"}')
+ON CONFLICT (sport_type, slug) DO NOTHING;
+
+-- Player props are settled from result_data.player_stats; players missing from them or with
+-- zero minutes did not play and their picks are void
+INSERT INTO prop_types (sport_type, name, slug, description, category, value_type, default_line, points_correct, evaluation) VALUES
+('Basketball', 'Player Points Over/Under', 'player-points-ou', 'Predict if a specific player will score over or under the line of points', 'player', 'over_under', 19.5, 3, '{"stat": "points", "comparator": "over_under", "scope": "player"}'),
+('Basketball', 'Player Rebounds Over/Under', 'player-rebounds-ou', 'Predict if a specific player will grab over or under the line of rebounds', 'player', 'over_under', 7.5, 3, '{"stat": "rebounds", "comparator": "over_under", "scope": "player"}')
 ON CONFLICT (sport_type, slug) DO NOTHING;
 
 